@@ -5,16 +5,23 @@ package cmd
 
 import (
 	"html/template"
+	"log/slog"
 	"net/http"
+	"strconv"
 
 	"github.com/gin-contrib/cors"
 	"github.com/gin-gonic/gin"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
+	"github.com/zvirgilx/searxng-go/kernel/config"
 	"github.com/zvirgilx/searxng-go/kernel/internal/complete"
+	"github.com/zvirgilx/searxng-go/kernel/internal/engine"
 	"github.com/zvirgilx/searxng-go/kernel/internal/locale"
 	"github.com/zvirgilx/searxng-go/kernel/internal/metrics"
+	"github.com/zvirgilx/searxng-go/kernel/internal/prefs"
+	"github.com/zvirgilx/searxng-go/kernel/internal/ratelimit"
+	"github.com/zvirgilx/searxng-go/kernel/internal/result"
 	"github.com/zvirgilx/searxng-go/kernel/internal/search"
 	"github.com/zvirgilx/searxng-go/kernel/internal/util"
 	"github.com/zvirgilx/searxng-go/kernel/templates"
@@ -44,6 +51,11 @@ func runapi() {
 	gin.SetMode(viper.GetString("mode"))
 
 	router := gin.Default()
+	// the client ip is the remote address unless the request is from a trusted proxy,
+	// so that the clients can not spoof it by X-Forwarded-For to bypass the rate limit or change the geoip.
+	if err := router.SetTrustedProxies(config.Conf.TrustedProxies); err != nil {
+		panic(err)
+	}
 
 	// allows all origins when debugging
 	if viper.GetString("mode") == "debug" {
@@ -60,21 +72,39 @@ func runapi() {
 		})
 	})
 
+	// the search requests of each client ip are limited.
+	var limit []gin.HandlerFunc
+	if c := config.Conf.RateLimit; c.Enable {
+		limit = append(limit, ratelimit.RateLimit(ratelimit.NewMemoryStore(c.Rate, c.Burst)))
+	}
+
+	// a searxng compatible search api, e.g. /search?q=hello&pageno=1&format=json
+	router.GET("/search", append(limit, searxSearch)...)
+
 	api := router.Group("/api")
-	api.GET("/search", func(c *gin.Context) {
+	api.GET("/search", append(limit, apiSearch)...)
+	api.GET("/search/stream", append(limit, func(c *gin.Context) {
 		opts, err := search.VerifySearchOptions(c)
 		if err != nil {
 			c.JSON(http.StatusBadRequest, gin.H{"msg": err.Error()})
 			return
 		}
-		r := search.Search(c, opts)
-		c.JSON(http.StatusOK, gin.H{
-			"query":        opts.Query,
-			"results":      r.GetSortedData(),
-			"suggestions":  util.SetToArray[string](r.Suggestions),
-			"info_box":     r.InfoBox,
-			"next_page_no": opts.PageNo + 1,
-		})
+		c.Header("Content-Type", "application/x-ndjson")
+		c.Status(http.StatusOK)
+		// the stream is stopped once the client disconnects.
+		ctx := c.Request.Context()
+		if err := result.StreamJSONL(ctx, search.Stream(ctx, opts), c.Writer); err != nil {
+			slog.WarnContext(ctx, "stream search aborted", slog.String("err", err.Error()))
+		}
+	})...)
+	// the preferences of params are encoded to a token, which is shared by the prefs param of search.
+	api.GET("/prefs", func(c *gin.Context) {
+		p, err := prefs.FromParams(c.GetQuery)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"msg": err.Error()})
+			return
+		}
+		c.JSON(http.StatusOK, gin.H{"prefs": prefs.Encode(p)})
 	})
 	api.GET("/complete", func(c *gin.Context) {
 		q, ok := c.GetQuery("q")
@@ -93,8 +123,134 @@ func runapi() {
 	go func() {
 		internalRouter := gin.Default()
 		internalRouter.GET("/metrics", gin.WrapH(promhttp.Handler()))
+		// the engine disabled by its error rate is enabled again manually.
+		internalRouter.POST("/engines/:name/enable", func(c *gin.Context) {
+			name := c.Param("name")
+			if engine.GetEngine(name) == nil {
+				c.JSON(http.StatusNotFound, gin.H{"msg": "engine not found: " + name})
+				return
+			}
+			engine.SetEnabled(name, true)
+			c.JSON(http.StatusOK, gin.H{"engine": name, "enabled": true})
+		})
 		internalRouter.Run(viper.GetString("internal-addr"))
 	}()
 
 	router.Run(viper.GetString("addr"))
 }
+
+// searxSearch searches by the params of searxng api, the result is serialized by the format param, one of json, rss, csv and markdown.
+func searxSearch(c *gin.Context) {
+	format := c.DefaultQuery("format", "json")
+	if format != "json" && format != "rss" && format != "csv" && format != "markdown" {
+		c.JSON(http.StatusBadRequest, gin.H{"msg": "format error"})
+		return
+	}
+
+	opts, err := search.VerifySearxOptions(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"msg": err.Error()})
+		return
+	}
+
+	r := search.Search(c, opts)
+	r.SortBy(opts.SortBy)
+
+	switch format {
+	case "rss":
+		c.Header("Content-Type", "application/rss+xml; charset=utf-8")
+		if err := result.ToRSS(r, opts.Query, c.Request.URL.String(), c.Writer); err != nil {
+			slog.ErrorContext(c, "failed to write rss", slog.String("err", err.Error()))
+		}
+	case "csv":
+		c.Header("Content-Type", "text/csv; charset=utf-8")
+		c.Header("Content-Disposition", "attachment; filename=searxng-go.csv")
+		if err := result.ToCSV(r, c.Writer); err != nil {
+			slog.ErrorContext(c, "failed to write csv", slog.String("err", err.Error()))
+		}
+	case "markdown":
+		c.Header("Content-Type", "text/markdown; charset=utf-8")
+		if err := result.ToMarkdown(r, c.Writer); err != nil {
+			slog.ErrorContext(c, "failed to write markdown", slog.String("err", err.Error()))
+		}
+	default:
+		results := make([]gin.H, 0, r.GetDataSize())
+		for _, d := range r.GetData() {
+			item := gin.H{
+				"url":       d.Url,
+				"title":     d.Title,
+				"content":   d.Content,
+				"engine":    d.Engine,
+				"category":  d.Category,
+				"thumbnail": d.Thumbnail,
+				"img_src":   d.ImgSrc,
+			}
+			if !d.PublishedDate.IsZero() {
+				item["publishedDate"] = d.PublishedDate
+			}
+			results = append(results, item)
+		}
+
+		answers := make([]string, 0, len(r.Answers))
+		for _, a := range r.Answers {
+			answers = append(answers, a.Answer)
+		}
+
+		var infoboxes []*result.InfoBox
+		if r.InfoBox != nil {
+			infoboxes = append(infoboxes, r.InfoBox)
+		}
+
+		unresponsive := make([][]string, 0, len(r.TimedOutEngines))
+		for _, name := range r.TimedOutEngines {
+			unresponsive = append(unresponsive, []string{name, "timeout"})
+		}
+
+		c.JSON(http.StatusOK, gin.H{
+			"query":                opts.Query,
+			"number_of_results":    len(results),
+			"results":              results,
+			"answers":              answers,
+			"corrections":          r.Corrections,
+			"infoboxes":            infoboxes,
+			"suggestions":          util.SetToArray[string](r.Suggestions),
+			"unresponsive_engines": unresponsive,
+		})
+	}
+}
+
+// apiSearch is the search api in the json schema of requested version, e.g. /api/search?q=hello&schema_version=1.
+func apiSearch(c *gin.Context) {
+	opts, err := search.VerifySearchOptions(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"msg": err.Error()})
+		return
+	}
+	version := result.DefaultSchemaVersion
+	if v := c.Query("schema_version"); v != "" {
+		if version, err = strconv.Atoi(v); err != nil || version < result.SchemaVersion1 || version > result.LatestSchemaVersion {
+			c.JSON(http.StatusBadRequest, gin.H{"msg": "invalid schema_version: " + v})
+			return
+		}
+	}
+	r := search.Search(c, opts)
+	// the navigational query is redirected to the site if the client asks for it.
+	if redirect, _ := strconv.ParseBool(c.Query("redirect")); redirect && r.RedirectURL != "" {
+		c.Redirect(http.StatusFound, r.RedirectURL)
+		return
+	}
+	r.SortBy(opts.SortBy)
+	// the results of multiple categories are mixed, so that no category is buried by others.
+	if len(opts.Categories) > 1 {
+		r.InterleaveByCategory(opts.Categories)
+	}
+	resp, err := result.ToJSON(r, opts.Query, opts.PageNo+1, version)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"msg": err.Error()})
+		return
+	}
+	if v2, ok := resp.(*result.Response); ok {
+		v2.EngineUrls = r.EngineSearchURLs("/api")
+	}
+	c.JSON(http.StatusOK, resp)
+}
@@ -0,0 +1,214 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/zvirgilx/searxng-go/kernel/internal/engine"
+	"github.com/zvirgilx/searxng-go/kernel/internal/network"
+	"github.com/zvirgilx/searxng-go/kernel/internal/result"
+	"github.com/zvirgilx/searxng-go/kernel/internal/search"
+)
+
+// stubEngine returns a single data of the query, the page requested is served by a local server.
+type stubEngine struct {
+	base   *url.URL
+	client *network.Client
+}
+
+func (e *stubEngine) Request(_ context.Context, opts *engine.Options) error {
+	opts.Request = e.client.Get().Base(e.base).Path("/search").Param("q", opts.Query)
+	return nil
+}
+
+func (e *stubEngine) Response(_ context.Context, opts *engine.Options, _ []byte) (*result.Result, error) {
+	res := result.CreateResult(e.GetName(), opts.PageNo)
+	res.AppendData(&result.Data{
+		Engine:        e.GetName(),
+		Title:         "Result of " + opts.Query,
+		Url:           "https://example.com/" + opts.Query,
+		Content:       "about " + opts.Query,
+		PublishedDate: time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC),
+	})
+	return res, nil
+}
+
+func (e *stubEngine) GetName() string { return "stub" }
+
+func (e *stubEngine) ApplyConfig(engine.Config) error { return nil }
+
+func setupStubEngine(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain")
+		w.Write([]byte("ok"))
+	}))
+	t.Cleanup(srv.Close)
+
+	base, _ := url.Parse(srv.URL)
+	e := &stubEngine{base: base, client: network.NewClient(&network.Config{})}
+	engine.SetGlobalEngines(engine.RegisterTo(map[string]map[string]engine.Engine{}, e, engine.CategoryGeneral))
+	search.InitConfig(search.Config{})
+	t.Cleanup(func() { engine.SetGlobalEngines(map[string]map[string]engine.Engine{}) })
+}
+
+// serve serves the GET request of target by the handler.
+func serve(h gin.HandlerFunc, target string) *httptest.ResponseRecorder {
+	gin.SetMode(gin.TestMode)
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodGet, target, nil)
+	h(c)
+	return w
+}
+
+func serveSearx(target string) *httptest.ResponseRecorder {
+	return serve(searxSearch, target)
+}
+
+func TestSearxSearchFormats(t *testing.T) {
+	setupStubEngine(t)
+
+	cases := map[string]struct {
+		contentType string
+		contains    []string
+	}{
+		"json":     {"application/json", []string{`"number_of_results":1`, `"publishedDate":"2024-03-01T12:00:00Z"`}},
+		"rss":      {"application/rss+xml", []string{"<rss version=\"2.0\">", "<link>https://example.com/golang</link>"}},
+		"csv":      {"text/csv", []string{"stub,Result of golang,https://example.com/golang"}},
+		"markdown": {"text/markdown", []string{"[Result of golang](https://example.com/golang)"}},
+	}
+	for format, c := range cases {
+		t.Run(format, func(t *testing.T) {
+			w := serveSearx("/search?q=golang&pageno=1&safesearch=0&format=" + format)
+			if w.Code != http.StatusOK {
+				t.Fatalf("status = %d: %s", w.Code, w.Body.String())
+			}
+			if ct := w.Header().Get("Content-Type"); !strings.HasPrefix(ct, c.contentType) {
+				t.Errorf("content type = %q, want %s", ct, c.contentType)
+			}
+			for _, s := range c.contains {
+				if !strings.Contains(w.Body.String(), s) {
+					t.Errorf("%q is not in the %s body:\n%s", s, format, w.Body.String())
+				}
+			}
+		})
+	}
+}
+
+func TestSearxSearchDefaultFormat(t *testing.T) {
+	setupStubEngine(t)
+
+	w := serveSearx("/search?q=golang")
+	var resp struct {
+		Query   string `json:"query"`
+		Results []struct {
+			Url    string `json:"url"`
+			Engine string `json:"engine"`
+		} `json:"results"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("the default format is not json: %v", err)
+	}
+	if resp.Query != "golang" || len(resp.Results) != 1 || resp.Results[0].Engine != "stub" {
+		t.Errorf("got %+v", resp)
+	}
+}
+
+func TestSearxSearchBadRequest(t *testing.T) {
+	setupStubEngine(t)
+
+	for _, target := range []string{
+		"/search?q=golang&format=xml",
+		"/search?format=json",
+		"/search?q=golang&pageno=first",
+		"/search?q=golang&safesearch=3",
+	} {
+		if w := serveSearx(target); w.Code != http.StatusBadRequest {
+			t.Errorf("%s: status = %d, want 400", target, w.Code)
+		}
+	}
+}
+
+func TestApiSearchSchemaVersion(t *testing.T) {
+	setupStubEngine(t)
+
+	cases := map[string]struct {
+		query string
+		v2    bool
+	}{
+		"version 1 by default": {},
+		"version 1":            {query: "&schema_version=1"},
+		"version 2":            {query: "&schema_version=2", v2: true},
+	}
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			w := serve(apiSearch, "/api/search?q=golang"+c.query)
+			if w.Code != http.StatusOK {
+				t.Fatalf("status = %d: %s", w.Code, w.Body.String())
+			}
+			var resp map[string]any
+			if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+				t.Fatal(err)
+			}
+			if resp["query"] != "golang" {
+				t.Errorf("query = %v", resp["query"])
+			}
+			// the fields since version 2 are omitted in version 1, the fields of version 1 are kept.
+			for _, field := range []string{"version", "stale", "redirect_url", "engine_urls"} {
+				if _, ok := resp[field]; ok != c.v2 {
+					t.Errorf("field %s is present %v, want %v", field, ok, c.v2)
+				}
+			}
+			for _, field := range []string{"answers", "corrections", "cached", "timed_out_engines"} {
+				if _, ok := resp[field]; !ok {
+					t.Errorf("field %s is missing", field)
+				}
+			}
+			if c.v2 && resp["version"] != float64(2) {
+				t.Errorf("version = %v, want 2", resp["version"])
+			}
+			results, _ := resp["results"].([]any)
+			if len(results) != 1 {
+				t.Fatalf("got results %v", resp["results"])
+			}
+			if _, ok := results[0].(map[string]any)["score"]; ok != c.v2 {
+				t.Errorf("score of data is present %v, want %v", ok, c.v2)
+			}
+		})
+	}
+}
+
+func TestApiSearchInvalidSchemaVersion(t *testing.T) {
+	setupStubEngine(t)
+
+	for _, v := range []string{"0", "3", "latest"} {
+		if w := serve(apiSearch, "/api/search?q=golang&schema_version="+v); w.Code != http.StatusBadRequest {
+			t.Errorf("schema version %s: status = %d, want 400", v, w.Code)
+		}
+	}
+}
+
+func TestApiSearchEngineUrls(t *testing.T) {
+	setupStubEngine(t)
+
+	w := serve(apiSearch, "/api/search?q=golang&schema_version=2")
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d: %s", w.Code, w.Body.String())
+	}
+	var resp struct {
+		EngineUrls map[string]string `json:"engine_urls"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := resp.EngineUrls["stub"], "/api/search?category=general&engines=stub&q=golang"; got != want {
+		t.Errorf("engine url = %q, want %q", got, want)
+	}
+}
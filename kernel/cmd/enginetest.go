@@ -0,0 +1,98 @@
+/*
+Copyright © 2024 zvirgilx
+*/
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/zvirgilx/searxng-go/kernel/internal/engine"
+	"github.com/zvirgilx/searxng-go/kernel/internal/result"
+	"github.com/zvirgilx/searxng-go/kernel/internal/search"
+)
+
+// engineTestCmd represents the engine-test command
+var engineTestCmd = &cobra.Command{
+	Use:   "engine-test <engine> <query>",
+	Short: "Search by a single engine and print the raw result with timing and error",
+	Args:  cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		e, opts, err := parseEngineTestArgs(cmd, args)
+		if err != nil {
+			return err
+		}
+
+		start := time.Now()
+		r, err := search.SearchEngine(context.Background(), opts, e)
+		report := engineTestReport{
+			Engine:   e.GetName(),
+			Query:    opts.Query,
+			PageNo:   opts.PageNo,
+			Locale:   opts.Locale,
+			Category: opts.Category,
+			Duration: time.Since(start).String(),
+			Result:   r,
+		}
+		if err != nil {
+			report.Error = err.Error()
+		}
+
+		// the urls of result are printed as they are.
+		enc := json.NewEncoder(cmd.OutOrStdout())
+		enc.SetEscapeHTML(false)
+		enc.SetIndent("", "  ")
+		return enc.Encode(report)
+	},
+}
+
+// engineTestReport is the output of engine-test command.
+type engineTestReport struct {
+	Engine   string         `json:"engine"`
+	Query    string         `json:"query"`
+	PageNo   int            `json:"page_no"`
+	Locale   string         `json:"locale"`
+	Category string         `json:"category"`
+	Duration string         `json:"duration"`
+	Error    string         `json:"error,omitempty"`
+	Result   *result.Result `json:"result"`
+}
+
+// parseEngineTestArgs parses the engine and options of search from the args and flags of engine-test command.
+// The engine is resolved by name or alias, an error is returned if it is not enabled.
+func parseEngineTestArgs(cmd *cobra.Command, args []string) (engine.Engine, engine.Options, error) {
+	name, err := engine.ResolveEngineName(args[0])
+	if err != nil {
+		return nil, engine.Options{}, err
+	}
+	e := engine.GetEngine(name)
+	if e == nil {
+		return nil, engine.Options{}, fmt.Errorf("engine %s is not enabled", name)
+	}
+
+	pageNo, _ := cmd.Flags().GetInt("page-no")
+	if pageNo < 1 {
+		return nil, engine.Options{}, fmt.Errorf("page number error: %d", pageNo)
+	}
+	locale, _ := cmd.Flags().GetString("locale")
+	category, _ := cmd.Flags().GetString("category")
+
+	return e, engine.Options{
+		Query:      args[1],
+		PageNo:     pageNo,
+		Locale:     locale,
+		Category:   category,
+		Categories: []string{category},
+	}, nil
+}
+
+func init() {
+	engineTestCmd.Flags().Int("page-no", 1, "page number of search")
+	engineTestCmd.Flags().String("locale", "en-US", "locale of search")
+	engineTestCmd.Flags().String("category", engine.CategoryGeneral, "category of search")
+
+	rootCmd.AddCommand(engineTestCmd)
+}
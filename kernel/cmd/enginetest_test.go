@@ -0,0 +1,108 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/zvirgilx/searxng-go/kernel/internal/engine"
+	"github.com/zvirgilx/searxng-go/kernel/internal/network"
+)
+
+// runEngineTest runs the engine-test command with the flags, the flags are restored after the test.
+func runEngineTest(t *testing.T, args []string, flags map[string]string) (*bytes.Buffer, error) {
+	t.Helper()
+	for name, v := range flags {
+		f := engineTestCmd.Flags().Lookup(name)
+		def := f.DefValue
+		if err := f.Value.Set(v); err != nil {
+			t.Fatal(err)
+		}
+		t.Cleanup(func() { f.Value.Set(def) })
+	}
+
+	var out bytes.Buffer
+	engineTestCmd.SetOut(&out)
+	t.Cleanup(func() { engineTestCmd.SetOut(nil) })
+	return &out, engineTestCmd.RunE(engineTestCmd, args)
+}
+
+func TestEngineTest(t *testing.T) {
+	setupStubEngine(t)
+	engine.RegisterAlias("enginetest_stub", "stub")
+
+	for _, name := range []string{"stub", "enginetest_stub"} {
+		t.Run(name, func(t *testing.T) {
+			out, err := runEngineTest(t, []string{name, "golang"}, map[string]string{"page-no": "2", "locale": "de-DE"})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var report struct {
+				engineTestReport
+				Result struct {
+					Data []struct {
+						Url string `json:"url"`
+					} `json:"merged_data"`
+				} `json:"result"`
+			}
+			if err = json.Unmarshal(out.Bytes(), &report); err != nil {
+				t.Fatalf("%v: %s", err, out.String())
+			}
+			if report.Engine != "stub" || report.Query != "golang" || report.PageNo != 2 || report.Locale != "de-DE" || report.Category != engine.CategoryGeneral {
+				t.Errorf("unexpected report %+v", report.engineTestReport)
+			}
+			if report.Duration == "" || report.Error != "" {
+				t.Errorf("duration = %q, error = %q", report.Duration, report.Error)
+			}
+			if len(report.Result.Data) != 1 || report.Result.Data[0].Url != "https://example.com/golang" {
+				t.Errorf("unexpected result %s", out.String())
+			}
+		})
+	}
+}
+
+func TestEngineTestFailed(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer srv.Close()
+	base, _ := url.Parse(srv.URL)
+	engine.SetGlobalEngines(engine.RegisterTo(map[string]map[string]engine.Engine{}, &stubEngine{base: base, client: network.NewClient(&network.Config{})}, engine.CategoryGeneral))
+	t.Cleanup(func() { engine.SetGlobalEngines(map[string]map[string]engine.Engine{}) })
+
+	// the error of engine is reported rather than failing the command.
+	out, err := runEngineTest(t, []string{"stub", "golang"}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var report engineTestReport
+	if err = json.Unmarshal(out.Bytes(), &report); err != nil {
+		t.Fatal(err)
+	}
+	if report.Error == "" {
+		t.Errorf("the error of engine is not reported: %s", out.String())
+	}
+}
+
+func TestEngineTestInvalidArgs(t *testing.T) {
+	setupStubEngine(t)
+
+	cases := map[string]struct {
+		args  []string
+		flags map[string]string
+	}{
+		"unknown engine":   {args: []string{"unknown", "golang"}},
+		"page number zero": {args: []string{"stub", "golang"}, flags: map[string]string{"page-no": "0"}},
+	}
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			if _, err := runEngineTest(t, c.args, c.flags); err == nil {
+				t.Error("the invalid args are accepted")
+			}
+		})
+	}
+}
@@ -10,10 +10,16 @@ import (
 
 	"github.com/spf13/cobra"
 	"github.com/zvirgilx/searxng-go/kernel/config"
+	"github.com/zvirgilx/searxng-go/kernel/internal/analytics"
 	"github.com/zvirgilx/searxng-go/kernel/internal/complete"
+	"github.com/zvirgilx/searxng-go/kernel/internal/engine"
 	"github.com/zvirgilx/searxng-go/kernel/internal/engines"
 	"github.com/zvirgilx/searxng-go/kernel/internal/engines/traits"
+	"github.com/zvirgilx/searxng-go/kernel/internal/locale"
+	"github.com/zvirgilx/searxng-go/kernel/internal/network"
+	"github.com/zvirgilx/searxng-go/kernel/internal/prefs"
 	"github.com/zvirgilx/searxng-go/kernel/internal/result"
+	"github.com/zvirgilx/searxng-go/kernel/internal/search"
 )
 
 var loglevel string
@@ -54,6 +60,26 @@ func initConfig() {
 
 	result.InitConfig(config.Conf.Result)
 
+	search.InitConfig(config.Conf.Search)
+
+	prefs.InitConfig(config.Conf.Prefs)
+
+	if err := analytics.InitConfig(config.Conf.Analytics); err != nil {
+		panic(err)
+	}
+
+	engine.InitDebugConfig(config.Conf.Debug)
+
+	if err := locale.InitGeoIP(config.Conf.GeoIP); err != nil {
+		panic(err)
+	}
+
+	if err := network.InitProxyPool(config.Conf.ProxyPool); err != nil {
+		panic(err)
+	}
+
+	network.InitHostLimit(config.Conf.HostLimit)
+
 	engines.InitConfiguration(config.Conf.Engines)
 }
 
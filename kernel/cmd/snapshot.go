@@ -0,0 +1,76 @@
+/*
+Copyright © 2024 zvirgilx
+*/
+package cmd
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/zvirgilx/searxng-go/kernel/internal/engine"
+	"github.com/zvirgilx/searxng-go/kernel/internal/snapshot"
+)
+
+var snapshotDir string
+
+// snapshotCmd represents the snapshot command
+var snapshotCmd = &cobra.Command{
+	Use:   "snapshot",
+	Short: "Record and replay responses of engines to detect upstream layout changes",
+}
+
+var snapshotRecordCmd = &cobra.Command{
+	Use:   "record <engine> <name> <query>",
+	Short: "Record the live response of engine as a snapshot",
+	Args:  cobra.ExactArgs(3),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		e := engine.GetEngine(args[0])
+		if e == nil {
+			return fmt.Errorf("engine %s is not enabled", args[0])
+		}
+		pageNo, _ := cmd.Flags().GetInt("page-no")
+		locale, _ := cmd.Flags().GetString("locale")
+		return snapshot.Record(context.Background(), e, snapshot.Options{Query: args[2], PageNo: pageNo, Locale: locale}, snapshotDir, args[1])
+	},
+}
+
+var snapshotReplayCmd = &cobra.Command{
+	Use:   "replay <engine>",
+	Short: "Replay the snapshots of engine and diff against the expected data",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		e := engine.GetEngine(args[0])
+		if e == nil {
+			return fmt.Errorf("engine %s is not enabled", args[0])
+		}
+
+		names, err := snapshot.Names(snapshotDir, e.GetName())
+		if err != nil {
+			return err
+		}
+
+		failed := 0
+		for _, name := range names {
+			if err := snapshot.Replay(context.Background(), e, snapshotDir, name); err != nil {
+				failed++
+				cmd.Printf("FAIL %s/%s\n%s\n", e.GetName(), name, err)
+				continue
+			}
+			cmd.Printf("ok   %s/%s\n", e.GetName(), name)
+		}
+		if failed > 0 {
+			return fmt.Errorf("%d of %d snapshots failed", failed, len(names))
+		}
+		return nil
+	},
+}
+
+func init() {
+	snapshotCmd.PersistentFlags().StringVarP(&snapshotDir, "dir", "d", snapshot.DefaultDir, "directory of snapshots")
+	snapshotRecordCmd.Flags().Int("page-no", 1, "page number of search")
+	snapshotRecordCmd.Flags().String("locale", "en-US", "locale of search")
+
+	snapshotCmd.AddCommand(snapshotRecordCmd, snapshotReplayCmd)
+	rootCmd.AddCommand(snapshotCmd)
+}
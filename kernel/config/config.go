@@ -5,18 +5,35 @@ import (
 	_ "embed"
 
 	"github.com/spf13/viper"
+	"github.com/zvirgilx/searxng-go/kernel/internal/analytics"
 	"github.com/zvirgilx/searxng-go/kernel/internal/complete"
 	"github.com/zvirgilx/searxng-go/kernel/internal/engine"
+	"github.com/zvirgilx/searxng-go/kernel/internal/locale"
+	"github.com/zvirgilx/searxng-go/kernel/internal/network"
+	"github.com/zvirgilx/searxng-go/kernel/internal/prefs"
+	"github.com/zvirgilx/searxng-go/kernel/internal/ratelimit"
 	"github.com/zvirgilx/searxng-go/kernel/internal/result"
+	"github.com/zvirgilx/searxng-go/kernel/internal/search"
 )
 
 //go:embed default.yaml
 var defaultConfig []byte
 
 type Config struct {
-	Engines  map[string]map[string]engine.Config `mapstructure:"engines"`
-	Complete complete.Config                     `mapstructure:"complete"`
-	Result   result.Config                       `mapstructure:"result"`
+	Engines   map[string]map[string]engine.Config `mapstructure:"engines"`
+	Complete  complete.Config                     `mapstructure:"complete"`
+	Result    result.Config                       `mapstructure:"result"`
+	Search    search.Config                       `mapstructure:"search"`
+	Debug     engine.DebugConfig                  `mapstructure:"debug"`
+	GeoIP     locale.GeoIPConfig                  `mapstructure:"geoip"`
+	RateLimit ratelimit.Config                    `mapstructure:"rate_limit"`
+	ProxyPool network.ProxyPoolConfig             `mapstructure:"proxy_pool"`
+	HostLimit network.HostLimitConfig             `mapstructure:"host_limit"`
+	Prefs     prefs.Config                        `mapstructure:"prefs"`
+	Analytics analytics.Config                    `mapstructure:"analytics"`
+
+	// TrustedProxies are the ips or cidrs of proxies trusted to set the client ip by headers, e.g. X-Forwarded-For.
+	TrustedProxies []string `mapstructure:"trusted_proxies"`
 }
 
 var (
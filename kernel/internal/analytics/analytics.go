@@ -0,0 +1,175 @@
+// Package analytics records an event of each search for the analysis of popular queries and engine performance,
+// the events are recorded asynchronously so that the search is never blocked or failed by the sink.
+package analytics
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/zvirgilx/searxng-go/kernel/internal/util"
+)
+
+const (
+	SinkNone  = "none"
+	SinkJSONL = "jsonl"
+
+	defaultQueueSize = 1024
+)
+
+type Config struct {
+	// Sink is the storage of events, none or jsonl.
+	Sink string `mapstructure:"sink"`
+	// Path is the file of jsonl sink, the events are appended to it.
+	Path string `mapstructure:"path"`
+
+	// HashQuery records the sha256 of query salted by HashSalt instead of the query, for privacy.
+	HashQuery bool   `mapstructure:"hash_query"`
+	HashSalt  string `mapstructure:"hash_salt"`
+
+	// QueueSize is the count of events waiting for the sink, the events beyond it are dropped.
+	QueueSize int `mapstructure:"queue_size"`
+}
+
+// SearchEvent is the event of a search.
+type SearchEvent struct {
+	Time       time.Time `json:"time"`
+	Query      string    `json:"query"` // Query is the query, or its hash if the query is hashed.
+	Categories []string  `json:"categories"`
+	PageNo     int       `json:"page_no"`
+
+	Engines         []string `json:"engines"`           // Engines are the engines returned before the deadline in order of arrival.
+	FailedEngines   []string `json:"failed_engines"`    // FailedEngines are the engines returned with an error.
+	TimedOutEngines []string `json:"timed_out_engines"` // TimedOutEngines are the engines not finished before the deadline.
+
+	Results       int            `json:"results"`        // Results is the count of results of search.
+	EngineResults map[string]int `json:"engine_results"` // EngineResults are the counts of results of each engine.
+
+	DurationMs        int64            `json:"duration_ms"`         // DurationMs is the duration of search.
+	EngineDurationsMs map[string]int64 `json:"engine_durations_ms"` // EngineDurationsMs are the durations until each engine returned.
+}
+
+// Sink stores the events, it is called by a single goroutine.
+type Sink interface {
+	Record(SearchEvent)
+}
+
+// NopSink drops the events.
+type NopSink struct{}
+
+func (NopSink) Record(SearchEvent) {}
+
+// JSONLSink appends the events to a file, one json per line.
+type JSONLSink struct {
+	mu  sync.Mutex
+	f   *os.File
+	enc *json.Encoder
+}
+
+// NewJSONLSink opens the file to append the events to, it is created if not exists.
+func NewJSONLSink(path string) (*JSONLSink, error) {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644)
+	if err != nil {
+		return nil, err
+	}
+	return &JSONLSink{f: f, enc: json.NewEncoder(f)}, nil
+}
+
+func (s *JSONLSink) Record(e SearchEvent) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := s.enc.Encode(e); err != nil {
+		slog.Error("failed to record search event", slog.String("err", err.Error()))
+	}
+}
+
+func (s *JSONLSink) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.f.Close()
+}
+
+// recorder dispatches the events to the sink, nil means the events are not recorded.
+var recorder *Recorder
+
+// InitConfig creates the recorder of events by config, an error is returned for an unknown sink.
+func InitConfig(c Config) error {
+	var sink Sink
+	switch c.Sink {
+	case "", SinkNone:
+		recorder = nil
+		return nil
+	case SinkJSONL:
+		s, err := NewJSONLSink(c.Path)
+		if err != nil {
+			return err
+		}
+		sink = s
+	default:
+		return fmt.Errorf("unknown analytics sink: %s", c.Sink)
+	}
+	recorder = NewRecorder(c, sink)
+	return nil
+}
+
+// Enabled reports whether the events are recorded.
+func Enabled() bool {
+	return recorder != nil
+}
+
+// Record records the event by the configured recorder, it is a no-op if none is configured.
+func Record(e SearchEvent) {
+	if recorder != nil {
+		recorder.Record(e)
+	}
+}
+
+// Recorder hashes the query of events if configured, and passes them to the sink in the background.
+type Recorder struct {
+	conf   Config
+	sink   Sink
+	events chan SearchEvent
+}
+
+func NewRecorder(c Config, sink Sink) *Recorder {
+	if c.QueueSize <= 0 {
+		c.QueueSize = defaultQueueSize
+	}
+	r := &Recorder{conf: c, sink: sink, events: make(chan SearchEvent, c.QueueSize)}
+	go r.run()
+	return r
+}
+
+// Record queues the event without blocking, it is dropped if the queue is full.
+func (r *Recorder) Record(e SearchEvent) {
+	if r.conf.HashQuery {
+		e.Query = hashQuery(r.conf.HashSalt, e.Query)
+	}
+	select {
+	case r.events <- e:
+	default:
+		slog.Warn("search event dropped since the analytics queue is full")
+	}
+}
+
+func (r *Recorder) run() {
+	for e := range r.events {
+		r.record(e)
+	}
+}
+
+// record passes the event to the sink, a panic of sink does not stop the recorder.
+func (r *Recorder) record(e SearchEvent) {
+	defer util.RecoverFromPanic()
+	r.sink.Record(e)
+}
+
+func hashQuery(salt, q string) string {
+	h := sha256.Sum256([]byte(salt + q))
+	return hex.EncodeToString(h[:])
+}
@@ -0,0 +1,154 @@
+package analytics
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// chanSink passes the events to a channel.
+type chanSink chan SearchEvent
+
+func (s chanSink) Record(e SearchEvent) { s <- e }
+
+// panicSink panics on the first event, and passes the others to the channel.
+type panicSink struct {
+	events   chan SearchEvent
+	panicked bool
+}
+
+func (s *panicSink) Record(e SearchEvent) {
+	if !s.panicked {
+		s.panicked = true
+		panic("broken sink")
+	}
+	s.events <- e
+}
+
+func receive(t *testing.T, events <-chan SearchEvent) SearchEvent {
+	t.Helper()
+	select {
+	case e := <-events:
+		return e
+	case <-time.After(time.Second):
+		t.Fatal("the event is not recorded")
+		return SearchEvent{}
+	}
+}
+
+func TestRecorderHashQuery(t *testing.T) {
+	cases := map[string]struct {
+		conf Config
+		want string
+	}{
+		"plain":  {want: "golang"},
+		"hashed": {conf: Config{HashQuery: true, HashSalt: "salt"}, want: hashQuery("salt", "golang")},
+	}
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			sink := make(chanSink, 1)
+			NewRecorder(c.conf, sink).Record(SearchEvent{Query: "golang"})
+			if got := receive(t, sink).Query; got != c.want {
+				t.Errorf("query = %q, want %q", got, c.want)
+			}
+		})
+	}
+
+	// the hash is salted, so that the queries are not found by the hashes of common words.
+	if hashQuery("salt", "golang") == hashQuery("", "golang") || len(hashQuery("", "golang")) != 64 {
+		t.Error("the hash is not salted sha256")
+	}
+}
+
+func TestRecorderPanicSink(t *testing.T) {
+	sink := &panicSink{events: make(chan SearchEvent, 1)}
+	r := NewRecorder(Config{}, sink)
+	r.Record(SearchEvent{Query: "first"})
+	r.Record(SearchEvent{Query: "second"})
+
+	// the panic of sink does not stop the recorder.
+	if got := receive(t, sink.events).Query; got != "second" {
+		t.Errorf("query = %q, want second", got)
+	}
+}
+
+func TestRecorderQueueFull(t *testing.T) {
+	// the recorder is not running, so the events beyond the queue are dropped without blocking.
+	r := &Recorder{sink: NopSink{}, events: make(chan SearchEvent, 2)}
+	for i := 0; i < 5; i++ {
+		r.Record(SearchEvent{})
+	}
+	if n := len(r.events); n != 2 {
+		t.Errorf("%d events queued, want 2", n)
+	}
+}
+
+func TestJSONLSink(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "events.jsonl")
+	for _, q := range []string{"golang", "rust"} {
+		// the file is appended to rather than truncated when opened again.
+		s, err := NewJSONLSink(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		s.Record(SearchEvent{Query: q, EngineResults: map[string]int{"bing": 3}})
+		if err = s.Close(); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	var queries []string
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var e SearchEvent
+		if err = json.Unmarshal(scanner.Bytes(), &e); err != nil {
+			t.Fatalf("%v: %s", err, scanner.Text())
+		}
+		if e.EngineResults["bing"] != 3 {
+			t.Errorf("unexpected event %+v", e)
+		}
+		queries = append(queries, e.Query)
+	}
+	if len(queries) != 2 || queries[0] != "golang" || queries[1] != "rust" {
+		t.Errorf("queries = %q, want golang and rust", queries)
+	}
+}
+
+func TestInitConfig(t *testing.T) {
+	t.Cleanup(func() { recorder = nil })
+
+	cases := map[string]struct {
+		conf        Config
+		wantErr     bool
+		wantEnabled bool
+	}{
+		"default":      {},
+		"none":         {conf: Config{Sink: SinkNone}},
+		"jsonl":        {conf: Config{Sink: SinkJSONL, Path: filepath.Join(t.TempDir(), "events.jsonl")}, wantEnabled: true},
+		"invalid path": {conf: Config{Sink: SinkJSONL, Path: filepath.Join(t.TempDir(), "missing", "events.jsonl")}, wantErr: true},
+		"unknown sink": {conf: Config{Sink: "kafka"}, wantErr: true},
+	}
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			recorder = nil
+			if err := InitConfig(c.conf); (err != nil) != c.wantErr {
+				t.Fatalf("err = %v, want error %v", err, c.wantErr)
+			}
+			if Enabled() != c.wantEnabled {
+				t.Errorf("enabled = %v, want %v", Enabled(), c.wantEnabled)
+			}
+		})
+	}
+
+	// the event is dropped without a recorder.
+	recorder = nil
+	Record(SearchEvent{Query: "golang"})
+}
@@ -2,6 +2,7 @@ package complete
 
 import (
 	"context"
+	"log/slog"
 )
 
 const (
@@ -11,6 +12,9 @@ const (
 
 type Config struct {
 	EnableEngines []string `mapstructure:"enable_engines"`
+
+	// Extra are the additional parameters of completers keyed by name, used by completers implement ConfigurableCompleter.
+	Extra map[string]interface{} `mapstructure:"extra"`
 }
 
 // Completer defines an engine that completes a search query.
@@ -19,6 +23,13 @@ type Completer interface {
 	Complete(ctx context.Context, query string, locale string) []Result
 }
 
+// ConfigurableCompleter is a completer configured by its extra parameters, e.g. the urls of suggestions.
+type ConfigurableCompleter interface {
+	Completer
+
+	ApplyConfig(extra interface{}) error
+}
+
 var completers = map[string]Completer{}
 
 func RegisterCompleter(name string, completer Completer) {
@@ -28,9 +39,17 @@ func RegisterCompleter(name string, completer Completer) {
 func InitCompleters(conf Config) {
 	enable := make(map[string]Completer)
 	for _, name := range conf.EnableEngines {
-		if f, ok := completers[name]; ok {
-			enable[name] = f
+		f, ok := completers[name]
+		if !ok {
+			continue
+		}
+		if cc, ok := f.(ConfigurableCompleter); ok {
+			if err := cc.ApplyConfig(conf.Extra[name]); err != nil {
+				slog.Error("failed to init configuration of completer", slog.String("completer", name), slog.String("error", err.Error()))
+				continue
+			}
 		}
+		enable[name] = f
 	}
 	completers = enable
 }
@@ -0,0 +1,38 @@
+package dom
+
+import (
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// find finds the elements matched by selector under s, s itself is used for an empty selector.
+func find(s *goquery.Selection, selector string) *goquery.Selection {
+	if selector == "" {
+		return s
+	}
+	return s.Find(selector)
+}
+
+// Attr gets the attribute of the first element matched by selector under s, e.g. Attr(s, "img", "src", "").
+// An empty selector reads the attribute of s itself.
+// The default is returned if s is nil, nothing is matched, or the attribute is missing or blank.
+func Attr(s *goquery.Selection, selector, attr, def string) string {
+	if s == nil {
+		return def
+	}
+	v, ok := find(s, selector).First().Attr(attr)
+	if v = strings.TrimSpace(v); !ok || v == "" {
+		return def
+	}
+	return v
+}
+
+// Text gets the trimmed text of all elements matched by selector under s, like the Text of goquery.
+// An empty selector reads the text of s itself, empty is returned if s is nil or nothing is matched.
+func Text(s *goquery.Selection, selector string) string {
+	if s == nil {
+		return ""
+	}
+	return strings.TrimSpace(find(s, selector).Text())
+}
@@ -0,0 +1,68 @@
+package dom
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+const page = `<div class="result" data-id=" 42 ">
+  <a class="link" href=" https://example.com/a ">  First  </a>
+  <a class="link" href="https://example.com/b">Second</a>
+  <img class="thumb" src="  ">
+</div>`
+
+func newSelection(t *testing.T) *goquery.Selection {
+	t.Helper()
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(page))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return doc.Find("div.result")
+}
+
+func TestAttr(t *testing.T) {
+	s := newSelection(t)
+	cases := map[string]struct {
+		s        *goquery.Selection
+		selector string
+		attr     string
+		want     string
+	}{
+		"first matched": {s: s, selector: "a.link", attr: "href", want: "https://example.com/a"},
+		"self":          {s: s, attr: "data-id", want: "42"},
+		"not matched":   {s: s, selector: "span", attr: "href", want: "default"},
+		"missing":       {s: s, selector: "a.link", attr: "title", want: "default"},
+		"blank":         {s: s, selector: "img.thumb", attr: "src", want: "default"},
+		"nil":           {selector: "a.link", attr: "href", want: "default"},
+	}
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			if got := Attr(c.s, c.selector, c.attr, "default"); got != c.want {
+				t.Errorf("Attr(%q, %q) = %q, want %q", c.selector, c.attr, got, c.want)
+			}
+		})
+	}
+}
+
+func TestText(t *testing.T) {
+	s := newSelection(t)
+	cases := map[string]struct {
+		s        *goquery.Selection
+		selector string
+		want     string
+	}{
+		"all matched": {s: s, selector: "a.link", want: "First  Second"},
+		"self":        {s: s.Find("a.link").First(), want: "First"},
+		"not matched": {s: s, selector: "span"},
+		"nil":         {selector: "a.link"},
+	}
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			if got := Text(c.s, c.selector); got != c.want {
+				t.Errorf("Text(%q) = %q, want %q", c.selector, got, c.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,39 @@
+package engine
+
+import (
+	"fmt"
+	"sync"
+)
+
+var (
+	aliasMu  sync.RWMutex
+	_aliases = map[string]string{}
+)
+
+// RegisterAlias registers a short name of engine, e.g. RegisterAlias("g", "google").
+// The alias registered later overrides the former one.
+func RegisterAlias(alias, name string) {
+	aliasMu.Lock()
+	defer aliasMu.Unlock()
+	_aliases[alias] = name
+}
+
+// ResolveEngineName resolves the engine name or alias to the canonical engine name.
+// The engine names are resolved to themselves, an error is returned if the name is neither an enabled engine nor an alias,
+// or the engine of alias is not enabled.
+func ResolveEngineName(name string) (string, error) {
+	if GetEngine(name) != nil {
+		return name, nil
+	}
+
+	aliasMu.RLock()
+	canonical, ok := _aliases[name]
+	aliasMu.RUnlock()
+	if !ok {
+		return "", fmt.Errorf("unknown engine or alias: %s", name)
+	}
+	if GetEngine(canonical) == nil {
+		return "", fmt.Errorf("engine %s of alias %s is not enabled", canonical, name)
+	}
+	return canonical, nil
+}
@@ -0,0 +1,54 @@
+package engine
+
+import "testing"
+
+func TestResolveEngineName(t *testing.T) {
+	SetGlobalEngines(RegisterTo(map[string]map[string]Engine{}, &plainEngine{}, CategoryGeneral))
+	RegisterAlias("p", "plain")
+	RegisterAlias("missing", "disabled")
+	t.Cleanup(func() {
+		SetGlobalEngines(map[string]map[string]Engine{})
+		aliasMu.Lock()
+		delete(_aliases, "p")
+		delete(_aliases, "missing")
+		aliasMu.Unlock()
+	})
+
+	cases := map[string]struct {
+		name, want string
+		err        bool
+	}{
+		"engine name":         {name: "plain", want: "plain"},
+		"alias":               {name: "p", want: "plain"},
+		"unknown":             {name: "unknown", err: true},
+		"alias of disabled":   {name: "missing", err: true},
+		"canonical not alias": {name: "disabled", err: true},
+	}
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			got, err := ResolveEngineName(c.name)
+			if (err != nil) != c.err {
+				t.Fatalf("err = %v, want error %v", err, c.err)
+			}
+			if got != c.want {
+				t.Errorf("ResolveEngineName(%q) = %q, want %q", c.name, got, c.want)
+			}
+		})
+	}
+}
+
+func TestRegisterAliasOverride(t *testing.T) {
+	SetGlobalEngines(RegisterTo(map[string]map[string]Engine{}, &plainEngine{}, CategoryGeneral))
+	RegisterAlias("q", "disabled")
+	RegisterAlias("q", "plain")
+	t.Cleanup(func() {
+		SetGlobalEngines(map[string]map[string]Engine{})
+		aliasMu.Lock()
+		delete(_aliases, "q")
+		aliasMu.Unlock()
+	})
+
+	if got, err := ResolveEngineName("q"); err != nil || got != "plain" {
+		t.Errorf("the later alias does not override, got %q, %v", got, err)
+	}
+}
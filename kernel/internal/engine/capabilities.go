@@ -0,0 +1,147 @@
+package engine
+
+import (
+	"strings"
+	"unicode"
+)
+
+// Capabilities describes the features supported by an engine.
+type Capabilities struct {
+	Categories []string `json:"categories"`  // Categories are the categories the engine is registered to.
+	Paging     bool     `json:"paging"`      // Paging means the engine supports requesting the next page.
+	TimeRange  bool     `json:"time_range"`  // TimeRange means the engine supports filtering results by time range.
+	SafeSearch bool     `json:"safe_search"` // SafeSearch means the engine supports filtering adult content.
+	Language   bool     `json:"language"`    // Language means the engine supports searching in a certain language.
+	Operators  bool     `json:"operators"`   // Operators means the engine supports operators in query natively, e.g. site:, filetype:.
+	Verbatim   bool     `json:"verbatim"`    // Verbatim means the engine supports searching the exact query without rewriting.
+
+	// QueryExpansion means the engine supports the OR syntax, so the query can be expanded with synonyms.
+	QueryExpansion bool `json:"query_expansion"`
+
+	// BaseUrl is the url the relative urls of results are resolved against, e.g. https://www.bing.com.
+	// The protocol-relative urls are resolved to https even if it is empty.
+	BaseUrl string `json:"base_url"`
+
+	// MinQueryLen is the minimum length of query in characters, the engine is not requested for shorter queries.
+	// 0 means no limit, it is overridden by the min_query_len of engine config.
+	MinQueryLen int `json:"min_query_len"`
+
+	// UserAgentClass is the preferred class of user agents of engine, e.g. desktop, mobile.
+	// It is used if the client config of engine does not set one.
+	UserAgentClass string `json:"user_agent_class"`
+
+	// ContentType is the media type of response expected by the engine, e.g. text/html. Empty means any.
+	ContentType string `json:"content_type"`
+}
+
+// CapableEngine is an engine that reports its supported features.
+// Engines without it are considered to support all features except operators,
+// which are only passed through to the engines declaring native support.
+type CapableEngine interface {
+	Engine
+
+	Capabilities() Capabilities
+}
+
+// ApplyCapabilities drops the params of options which are not supported by the engine.
+// It reports false if the engine should not be requested, e.g. the next page of an engine without paging.
+func ApplyCapabilities(e Engine, opts *Options) bool {
+	ce, ok := e.(CapableEngine)
+	if !ok {
+		parseOperators(opts)
+		return true
+	}
+
+	caps := ce.Capabilities()
+	if !caps.Paging && opts.PageNo > 1 {
+		return false
+	}
+	if !caps.TimeRange {
+		opts.TimeRange = ""
+	}
+	if !caps.SafeSearch {
+		opts.SafeSearch = SafeSearchNone
+	}
+	if !caps.Language {
+		opts.Locale = ""
+	}
+	if !caps.Verbatim {
+		opts.Verbatim = false
+	}
+	if !caps.Operators {
+		parseOperators(opts)
+	}
+	return true
+}
+
+// parseOperators strips the operators from query, the results are post-filtered by them instead.
+// The query of operators only is kept as typed, rather than searched as an empty query.
+func parseOperators(opts *Options) {
+	query, ops := ParseOperators(opts.Query)
+	if query == "" {
+		query = opts.Query
+	}
+	opts.Query, opts.Operators = query, ops
+}
+
+// SupportsQueryExpansion reports whether the query of engine can be expanded by ExpandQuery.
+// Only the engines declaring it are expanded, the OR syntax is searched as terms by the others.
+func SupportsQueryExpansion(e Engine) bool {
+	ce, ok := e.(CapableEngine)
+	return ok && ce.Capabilities().QueryExpansion
+}
+
+// VerbatimQuery quotes each term of query for exact matching, e.g. go site:go.dev -java -> "go" site:go.dev -java.
+// The operators and the phrases already quoted are kept, so that they are not searched as terms.
+func VerbatimQuery(query string) string {
+	var terms []string
+	for _, term := range splitTerms(query) {
+		lower := strings.ToLower(term)
+		switch {
+		case isOperator(lower), isQuotedPhrase(term):
+			terms = append(terms, term)
+		default:
+			// the stray quotes of unbalanced phrases are dropped.
+			if t := strings.ReplaceAll(term, `"`, ""); t != "" {
+				terms = append(terms, `"`+t+`"`)
+			}
+		}
+	}
+	return strings.Join(terms, " ")
+}
+
+// splitTerms splits the query by spaces, the quoted phrases are kept as single terms, e.g. "hello world".
+func splitTerms(query string) []string {
+	var terms []string
+	var term strings.Builder
+	quoted := false
+	for _, r := range query {
+		switch {
+		case r == '"':
+			quoted = !quoted
+			term.WriteRune(r)
+		case unicode.IsSpace(r) && !quoted:
+			if term.Len() > 0 {
+				terms = append(terms, term.String())
+				term.Reset()
+			}
+		default:
+			term.WriteRune(r)
+		}
+	}
+	if term.Len() > 0 {
+		terms = append(terms, term.String())
+	}
+	return terms
+}
+
+// isOperator reports whether the lower case term is an operator, e.g. site:go.dev, filetype:pdf or -java.
+func isOperator(term string) bool {
+	return strings.HasPrefix(term, OperatorSite) && len(term) > len(OperatorSite) ||
+		strings.HasPrefix(term, OperatorFileType) && len(term) > len(OperatorFileType) ||
+		isExcludedTerm(term)
+}
+
+func isQuotedPhrase(term string) bool {
+	return len(term) > 2 && strings.HasPrefix(term, `"`) && strings.HasSuffix(term, `"`) && strings.Count(term, `"`) == 2
+}
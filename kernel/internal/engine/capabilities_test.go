@@ -0,0 +1,84 @@
+package engine
+
+import (
+	"context"
+	"testing"
+
+	"github.com/zvirgilx/searxng-go/kernel/internal/result"
+)
+
+// capableEngine reports the capabilities of caps.
+type capableEngine struct {
+	caps Capabilities
+}
+
+func (e *capableEngine) Request(context.Context, *Options) error { return nil }
+
+func (e *capableEngine) Response(context.Context, *Options, []byte) (*result.Result, error) {
+	return nil, nil
+}
+
+func (e *capableEngine) GetName() string { return "capable" }
+
+func (e *capableEngine) ApplyConfig(Config) error { return nil }
+
+func (e *capableEngine) Capabilities() Capabilities { return e.caps }
+
+func TestApplyCapabilitiesDropsUnsupported(t *testing.T) {
+	e := &capableEngine{caps: Capabilities{Paging: true, TimeRange: true, Operators: true}}
+	opts := Options{Query: "go", PageNo: 2, TimeRange: TimeRangeWeek, SafeSearch: SafeSearchStrict, Locale: "de-DE", Verbatim: true}
+
+	if !ApplyCapabilities(e, &opts) {
+		t.Fatal("the engine supporting paging is skipped")
+	}
+	if opts.TimeRange != TimeRangeWeek || opts.PageNo != 2 {
+		t.Errorf("the supported params are dropped: %+v", opts)
+	}
+	if opts.SafeSearch != SafeSearchNone || opts.Locale != "" || opts.Verbatim {
+		t.Errorf("the unsupported params are kept: %+v", opts)
+	}
+}
+
+func TestApplyCapabilitiesKeepsSupported(t *testing.T) {
+	e := &capableEngine{caps: Capabilities{Paging: true, TimeRange: true, SafeSearch: true, Language: true, Verbatim: true, Operators: true}}
+	opts := Options{Query: "go", PageNo: 1, TimeRange: TimeRangeDay, SafeSearch: SafeSearchStrict, Locale: "de-DE", Verbatim: true}
+	want := opts
+
+	if !ApplyCapabilities(e, &opts) {
+		t.Fatal("the engine is skipped")
+	}
+	if opts.TimeRange != want.TimeRange || opts.SafeSearch != want.SafeSearch || opts.Locale != want.Locale || opts.Verbatim != want.Verbatim {
+		t.Errorf("got %+v, want %+v", opts, want)
+	}
+}
+
+func TestApplyCapabilitiesWithoutPaging(t *testing.T) {
+	e := &capableEngine{caps: Capabilities{Operators: true}}
+	if opts := (Options{Query: "go", PageNo: 1}); !ApplyCapabilities(e, &opts) {
+		t.Error("the first page of engine without paging is skipped")
+	}
+	if opts := (Options{Query: "go", PageNo: 2}); ApplyCapabilities(e, &opts) {
+		t.Error("the next page of engine without paging is requested")
+	}
+}
+
+func TestVerbatimQuery(t *testing.T) {
+	cases := map[string]string{
+		"golang tutorial":                 `"golang" "tutorial"`,
+		`"golang tutorial"`:               `"golang tutorial"`,
+		`golang "for loop" range`:         `"golang" "for loop" "range"`,
+		"golang site:go.dev filetype:pdf": `"golang" site:go.dev filetype:pdf`,
+		"golang -java -\"rust lang\"":     `"golang" -java -"rust lang"`,
+		"go -5":                           `"go" "-5"`,
+		`go"lang`:                         `"golang"`,
+		`golang "unbalanced phrase`:       `"golang" "unbalanced phrase"`,
+		`"" golang`:                       `"golang"`,
+		"site:":                           `"site:"`,
+		"":                                "",
+	}
+	for q, want := range cases {
+		if got := VerbatimQuery(q); got != want {
+			t.Errorf("VerbatimQuery(%q) = %q, want %q", q, got, want)
+		}
+	}
+}
@@ -0,0 +1,52 @@
+package engine
+
+import (
+	"regexp"
+	"strings"
+)
+
+var (
+	// urlPattern matches the urls, e.g. https://go.dev/doc, www.example.com.
+	urlPattern = regexp.MustCompile(`^(https?://|www\.)\S+$`)
+	// repoPattern matches the paths of repositories or go modules, e.g. github.com/spf13/cobra.
+	repoPattern = regexp.MustCompile(`^([a-z0-9-]+\.)+[a-z]{2,}/[\w.-]+(/[\w.-]+)*$`)
+	// scopedPackagePattern matches the scoped npm packages, e.g. @types/node.
+	scopedPackagePattern = regexp.MustCompile(`^@[\w.-]+/[\w.-]+$`)
+)
+
+// installCommands are the prefixes of package installing commands, e.g. pip install requests.
+var installCommands = []string{
+	"npm install ", "npm i ", "yarn add ", "pip install ", "go get ", "go install ",
+	"cargo add ", "cargo install ", "gem install ", "brew install ", "apt install ", "apt-get install ",
+}
+
+// SuggestCategories suggests the categories of query by heuristics, nil means no suggestion.
+// It is conservative, only the unambiguous queries are suggested, and the general category is always kept,
+// e.g. "lyrics yesterday" -> [music general], "github.com/spf13/cobra" -> [it general], "weather paris" -> [general].
+// The queries of images are not suggested, since "cat pictures" is a common general search.
+func SuggestCategories(query string) []string {
+	q := strings.ToLower(strings.Join(strings.Fields(query), " "))
+	if q == "" {
+		return nil
+	}
+
+	// the weather is answered by the answer engines of general category.
+	if q == "weather" || strings.HasPrefix(q, "weather ") {
+		return []string{CategoryGeneral}
+	}
+
+	if strings.HasPrefix(q, "lyrics ") || strings.HasSuffix(q, " lyrics") {
+		return []string{CategoryMusic, CategoryGeneral}
+	}
+
+	for _, cmd := range installCommands {
+		if strings.HasPrefix(q, cmd) {
+			return []string{CategoryIT, CategoryGeneral}
+		}
+	}
+	// a single token of url or package, multiple words are likely a general search about it.
+	if !strings.Contains(q, " ") && (urlPattern.MatchString(q) || repoPattern.MatchString(q) || scopedPackagePattern.MatchString(q)) {
+		return []string{CategoryIT, CategoryGeneral}
+	}
+	return nil
+}
@@ -0,0 +1,34 @@
+package engine
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestSuggestCategories(t *testing.T) {
+	cases := map[string]struct {
+		query string
+		want  []string
+	}{
+		"empty":              {query: "  "},
+		"general":            {query: "best pizza in rome"},
+		"weather":            {query: "Weather  Paris", want: []string{CategoryGeneral}},
+		"lyrics prefix":      {query: "lyrics yesterday", want: []string{CategoryMusic, CategoryGeneral}},
+		"lyrics suffix":      {query: "yesterday lyrics", want: []string{CategoryMusic, CategoryGeneral}},
+		"install command":    {query: "pip install requests", want: []string{CategoryIT, CategoryGeneral}},
+		"url":                {query: "https://go.dev/doc", want: []string{CategoryIT, CategoryGeneral}},
+		"www":                {query: "www.example.com", want: []string{CategoryIT, CategoryGeneral}},
+		"repository":         {query: "github.com/spf13/cobra", want: []string{CategoryIT, CategoryGeneral}},
+		"scoped package":     {query: "@types/node", want: []string{CategoryIT, CategoryGeneral}},
+		"url of more words":  {query: "github.com/spf13/cobra tutorial"},
+		"install in a query": {query: "how to pip install requests"},
+		"images":             {query: "cat pictures"},
+	}
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			if got := SuggestCategories(c.query); !slices.Equal(got, c.want) {
+				t.Errorf("SuggestCategories(%q) = %v, want %v", c.query, got, c.want)
+			}
+		})
+	}
+}
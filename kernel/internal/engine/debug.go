@@ -0,0 +1,81 @@
+package engine
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/zvirgilx/searxng-go/kernel/internal/result"
+)
+
+// ErrParse means the response of engine can not be parsed, usually the layout of upstream is changed.
+var ErrParse = errors.New("failed to parse engine response")
+
+type DebugConfig struct {
+	// ParseFailureSample enables attaching a sample of unparsed body to the parse error.
+	// It is off by default to avoid logging large bodies.
+	ParseFailureSample bool `mapstructure:"parse_failure_sample"`
+	// SampleSize is the maximum bytes of the sample.
+	SampleSize int `mapstructure:"sample_size"`
+	// StrictParse enables reporting the items skipped by engines during parsing as warnings of result,
+	// so that a partial breakage of upstream layout is noticed before it is total.
+	StrictParse bool `mapstructure:"strict_parse"`
+}
+
+const defaultSampleSize = 512
+
+var debugConf DebugConfig
+
+func InitDebugConfig(c DebugConfig) {
+	if c.SampleSize <= 0 {
+		c.SampleSize = defaultSampleSize
+	}
+	debugConf = c
+}
+
+// Skips counts the items skipped by reasons while an engine parses the response.
+type Skips map[string]int
+
+// Add counts an item skipped for the reason.
+func (s Skips) Add(reason string) {
+	s[reason]++
+}
+
+// Report adds the skipped items to the warnings of result in strict parse mode, total is the count of parsed items.
+func (s Skips) Report(res *result.Result, engineName string, total int) {
+	if !debugConf.StrictParse || res == nil || len(s) == 0 {
+		return
+	}
+
+	reasons := make([]string, 0, len(s))
+	for reason := range s {
+		reasons = append(reasons, reason)
+	}
+	slices.Sort(reasons)
+	for _, reason := range reasons {
+		res.Warnings = append(res.Warnings, fmt.Sprintf("%s: skipped %d of %d items, %s", engineName, s[reason], total, reason))
+	}
+}
+
+// NewParseError returns a parse error of engine.
+// A truncated sample of body is attached if ParseFailureSample is enabled,
+// the query is redacted from the sample so that user input is not logged.
+func NewParseError(opts *Options, msg string, body []byte) error {
+	if !debugConf.ParseFailureSample {
+		return fmt.Errorf("%w: %s", ErrParse, msg)
+	}
+	return fmt.Errorf("%w: %s, sample: %q", ErrParse, msg, sampleBody(body, opts.Query, debugConf.SampleSize))
+}
+
+// sampleBody collapses whitespaces of body, redacts the query and truncates it to size.
+func sampleBody(body []byte, query string, size int) string {
+	sample := strings.Join(strings.Fields(string(body)), " ")
+	if query != "" {
+		sample = strings.ReplaceAll(sample, query, "[query]")
+	}
+	if len(sample) > size {
+		sample = sample[:size] + "..."
+	}
+	return sample
+}
@@ -0,0 +1,70 @@
+package engine
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/zvirgilx/searxng-go/kernel/internal/result"
+)
+
+// withDebugConfig sets the debug config during the test.
+func withDebugConfig(t *testing.T, c DebugConfig) {
+	t.Helper()
+	old := debugConf
+	InitDebugConfig(c)
+	t.Cleanup(func() { debugConf = old })
+}
+
+func TestNewParseErrorWithoutSample(t *testing.T) {
+	withDebugConfig(t, DebugConfig{})
+
+	err := NewParseError(&Options{Query: "golang"}, "failed to match videos", []byte("<html><body>layout changed</body></html>"))
+	if !errors.Is(err, ErrParse) {
+		t.Errorf("%v is not a parse error", err)
+	}
+	// the body is not attached by default.
+	if strings.Contains(err.Error(), "sample") || strings.Contains(err.Error(), "layout changed") {
+		t.Errorf("the sample is attached without the flag: %v", err)
+	}
+}
+
+func TestNewParseErrorWithSample(t *testing.T) {
+	withDebugConfig(t, DebugConfig{ParseFailureSample: true, SampleSize: 40})
+
+	body := "<html>\n  <body>\n    <div>results of golang tutorial</div>" + strings.Repeat("<p>padding</p>", 100) + "</body></html>"
+	err := NewParseError(&Options{Query: "golang"}, "failed to match videos", []byte(body))
+	if !errors.Is(err, ErrParse) {
+		t.Errorf("%v is not a parse error", err)
+	}
+
+	msg := err.Error()
+	// the whitespaces are collapsed, the query is redacted and the sample is capped.
+	if want := `sample: "<html> <body> <div>results of [query] tu..."`; !strings.HasSuffix(msg, want) {
+		t.Errorf("error = %s, want suffix %s", msg, want)
+	}
+}
+
+func TestSkipsReport(t *testing.T) {
+	skips := Skips{}
+	skips.Add("missing title")
+	skips.Add("missing title")
+	skips.Add("invalid metadata")
+
+	withDebugConfig(t, DebugConfig{})
+	res := result.CreateResult("bing_videos", 1)
+	skips.Report(res, "bing_videos", 10)
+	if len(res.Warnings) != 0 {
+		t.Errorf("the skips are reported without strict parse: %v", res.Warnings)
+	}
+
+	withDebugConfig(t, DebugConfig{StrictParse: true})
+	skips.Report(res, "bing_videos", 10)
+	want := []string{
+		"bing_videos: skipped 1 of 10 items, invalid metadata",
+		"bing_videos: skipped 2 of 10 items, missing title",
+	}
+	if strings.Join(res.Warnings, "\n") != strings.Join(want, "\n") {
+		t.Errorf("warnings = %q, want %q", res.Warnings, want)
+	}
+}
@@ -0,0 +1,128 @@
+package engine
+
+import (
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/language"
+)
+
+// scriptLanguages are the languages detected by the script of letters, the first matched script wins.
+var scriptLanguages = []struct {
+	table *unicode.RangeTable
+	lang  string
+}{
+	{unicode.Hiragana, "ja"},
+	{unicode.Katakana, "ja"},
+	{unicode.Hangul, "ko"},
+	{unicode.Han, "zh"},
+	{unicode.Cyrillic, "ru"},
+	{unicode.Greek, "el"},
+	{unicode.Arabic, "ar"},
+	{unicode.Hebrew, "he"},
+	{unicode.Thai, "th"},
+}
+
+// latinStopwords are the frequent words of languages written in latin script.
+var latinStopwords = map[string][]string{
+	"en": {"the", "and", "of", "to", "in", "is", "for", "how", "what", "with", "on", "are", "best", "my"},
+	"de": {"der", "die", "das", "und", "ist", "nicht", "mit", "wie", "ein", "eine", "für", "auf", "ich", "was"},
+	"fr": {"le", "la", "les", "et", "est", "des", "une", "du", "pour", "dans", "que", "comment", "avec", "qui"},
+	"es": {"el", "los", "las", "y", "es", "del", "una", "para", "por", "que", "cómo", "con", "en", "qué"},
+	"it": {"il", "lo", "gli", "che", "è", "della", "per", "una", "come", "con", "sono", "non", "di", "del"},
+	"pt": {"o", "os", "as", "que", "é", "do", "da", "uma", "para", "com", "como", "não", "em", "dos"},
+	"nl": {"de", "het", "een", "en", "van", "is", "niet", "met", "voor", "hoe", "wat", "op", "zijn", "ik"},
+}
+
+// latinLetters are the letters specific to languages written in latin script.
+var latinLetters = map[string]string{
+	"de": "äöüß",
+	"fr": "çèêëîïôœûù",
+	"es": "ñ¿¡",
+	"pt": "ãõ",
+}
+
+// DetectLanguage detects the language of query, e.g. "как дела" -> ru.
+// The confidence is in [0, 1], an empty language with 0 is returned if it is unknown.
+func DetectLanguage(query string) (string, float64) {
+	var letters int
+	counts := map[string]int{}
+	for _, r := range query {
+		if !unicode.IsLetter(r) {
+			continue
+		}
+		letters++
+		for _, sl := range scriptLanguages {
+			if unicode.Is(sl.table, r) {
+				counts[sl.lang]++
+				break
+			}
+		}
+	}
+	if letters == 0 {
+		return "", 0
+	}
+
+	// japanese is written in kana mixed with han.
+	if counts["ja"] > 0 {
+		counts["ja"] += counts["zh"]
+		delete(counts, "zh")
+	}
+
+	lang, best := "", 0
+	for l, n := range counts {
+		if n > best {
+			lang, best = l, n
+		}
+	}
+	if best*2 > letters {
+		return lang, float64(best) / float64(letters)
+	}
+
+	return detectLatinLanguage(strings.ToLower(query))
+}
+
+// detectLatinLanguage detects the language written in latin script by stopwords and specific letters,
+// the confidence is the share of the best language in all hits.
+func detectLatinLanguage(query string) (string, float64) {
+	hits := map[string]int{}
+	for _, word := range strings.FieldsFunc(query, func(r rune) bool { return !unicode.IsLetter(r) }) {
+		for lang, stopwords := range latinStopwords {
+			for _, sw := range stopwords {
+				if word == sw {
+					hits[lang]++
+					break
+				}
+			}
+		}
+	}
+	for lang, letters := range latinLetters {
+		if strings.ContainsAny(query, letters) {
+			hits[lang] += 2
+		}
+	}
+
+	var total int
+	lang, best := "", 0
+	for l, n := range hits {
+		total += n
+		if n > best || n == best && l < lang {
+			lang, best = l, n
+		}
+	}
+	if total == 0 {
+		return "", 0
+	}
+	return lang, float64(best) / float64(total)
+}
+
+// LocaleOfLanguage returns the locale with the most likely region of language, e.g. ru -> ru-RU.
+func LocaleOfLanguage(lang string) string {
+	tag, err := language.Parse(lang)
+	if err != nil {
+		return ""
+	}
+	base, _ := tag.Base()
+	region, _ := tag.Region()
+	return base.String() + "-" + region.String()
+}
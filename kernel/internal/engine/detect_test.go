@@ -0,0 +1,38 @@
+package engine
+
+import "testing"
+
+func TestDetectLanguage(t *testing.T) {
+	cases := map[string]struct {
+		lang          string
+		minConfidence float64
+	}{
+		"как дела":                {"ru", 1},
+		"東京の天気":                   {"ja", 1},
+		"天气预报":                    {"zh", 1},
+		"날씨 예보":                   {"ko", 1},
+		"what is the best editor": {"en", 0.8},
+		"wie ist das wetter":      {"de", 0.6},
+		"comment faire une crêpe": {"fr", 0.8},
+		"größe":                   {"de", 1},
+		"golang":                  {"", 0},
+		"12345":                   {"", 0},
+		"":                        {"", 0},
+		"golang как установить на ubuntu": {"ru", 0.5},
+	}
+	for q, c := range cases {
+		lang, confidence := DetectLanguage(q)
+		if lang != c.lang || confidence < c.minConfidence || confidence > 1 {
+			t.Errorf("DetectLanguage(%q) = %s, %.2f, want %s with confidence at least %.2f", q, lang, confidence, c.lang, c.minConfidence)
+		}
+	}
+}
+
+func TestLocaleOfLanguage(t *testing.T) {
+	cases := map[string]string{"ru": "ru-RU", "ja": "ja-JP", "en": "en-US", "de": "de-DE", "": "", "not a language": ""}
+	for lang, want := range cases {
+		if got := LocaleOfLanguage(lang); got != want {
+			t.Errorf("LocaleOfLanguage(%q) = %q, want %q", lang, got, want)
+		}
+	}
+}
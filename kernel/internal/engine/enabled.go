@@ -0,0 +1,27 @@
+package engine
+
+import "sync"
+
+var (
+	disabledMu sync.RWMutex
+	// disabled are the engines disabled at runtime, e.g. by the error rate, they are still registered.
+	disabled = map[string]bool{}
+)
+
+// SetEnabled enables or disables the engine at runtime, the disabled engine is not searched until it is enabled again.
+func SetEnabled(name string, enabled bool) {
+	disabledMu.Lock()
+	defer disabledMu.Unlock()
+	if enabled {
+		delete(disabled, name)
+	} else {
+		disabled[name] = true
+	}
+}
+
+// IsEnabled reports whether the engine is not disabled at runtime.
+func IsEnabled(name string) bool {
+	disabledMu.RLock()
+	defer disabledMu.RUnlock()
+	return !disabled[name]
+}
@@ -3,6 +3,7 @@ package engine
 import (
 	"context"
 
+	"github.com/zvirgilx/searxng-go/kernel/internal/network"
 	"github.com/zvirgilx/searxng-go/kernel/internal/result"
 )
 
@@ -12,10 +13,37 @@ const (
 
 	// CategoryVideo search for video result.
 	CategoryVideo = "video"
+
+	// CategoryMusic search for music result, like artists, albums and tracks.
+	CategoryMusic = "music"
+
+	// CategoryImage search for image result.
+	CategoryImage = "images"
+
+	// CategoryNews search for news result.
+	CategoryNews = "news"
+
+	// CategorySocial search for social media result, like posts of fediverse.
+	CategorySocial = "social"
+
+	// CategoryScience search for science result, like papers and articles.
+	CategoryScience = "science"
+
+	// CategoryBooks search for book result.
+	CategoryBooks = "books"
+
+	// CategoryMovies search for movie result.
+	CategoryMovies = "movies"
+
+	// CategoryIT search for it result, like packages and repositories.
+	CategoryIT = "it"
 )
 
 type Engine interface {
-	// Request reports how the engine initiates a request.
+	// Request reports how the engine initiates a request by setting Options.Request.
+	// It is called for each search of the engine, e.g. again when the corrected query is searched,
+	// and the changes of options other than Options.Request are discarded.
+	// It may wait or request by itself, e.g. for a rate limit or a session token, but must return once ctx is done.
 	Request(context.Context, *Options) error
 	// Response reports how the engine parse the response.
 	Response(context.Context, *Options, []byte) (*result.Result, error)
@@ -25,6 +53,63 @@ type Engine interface {
 	ApplyConfig(config Config) error
 }
 
+// SessionEngine is an engine that needs cookies across requests within a search,
+// e.g. a token is fetched in the first request and sent in the next request.
+type SessionEngine interface {
+	Engine
+
+	// NeedSession reports whether a cookie jar is needed by the engine.
+	NeedSession() bool
+}
+
+// TrendingEngine is an engine that returns trending items without query, e.g. the front page of a site.
+type TrendingEngine interface {
+	Engine
+
+	// Trending reports how the engine initiates a request of trending items,
+	// the response is parsed by Response with an empty query.
+	Trending(context.Context, *Options) error
+}
+
+// FollowUpEngine is an engine that requests in two steps, e.g. the ids of articles are searched before their summaries.
+// The follow-up request is sent by the search after the first response is parsed, so that Response never requests itself.
+type FollowUpEngine interface {
+	Engine
+
+	// FollowUp reports how the engine initiates the follow-up request by the first response and the result parsed from it.
+	// Options.Request is left nil if no follow-up is needed, e.g. nothing is found.
+	FollowUp(ctx context.Context, opts *Options, res *result.Result, resp []byte) error
+	// FollowUpResponse parses the follow-up response into the result of the first response.
+	FollowUpResponse(ctx context.Context, opts *Options, res *result.Result, resp []byte) error
+}
+
+// RedirectEngine is an engine whose results link to its redirects, e.g. https://www.baidu.com/link?url=...
+// The redirects are followed up by the search after the response is parsed, through the client of engine,
+// and the links not resolved are kept as they are.
+type RedirectEngine interface {
+	Engine
+
+	// FollowUpRedirect builds the request of the redirect link of data, nil if the data does not link to a redirect.
+	FollowUpRedirect(opts *Options, d *result.Data) *network.Request
+}
+
+// ReverseImageEngine is an engine that searches the pages and similar images of an image, e.g. google lens.
+// The query of image url is passed through to the reverse search page of engine instead of searched as text.
+type ReverseImageEngine interface {
+	Engine
+
+	// ReverseImageURL builds the url of reverse search page of the image url.
+	ReverseImageURL(imageURL string, opts *Options) string
+}
+
+// WarmupEngine is an engine that needs to prepare before searching, e.g. pre-fetch a token.
+// Warmup is called at startup and refreshed on schedule, the engine is disabled if the first warmup failed.
+type WarmupEngine interface {
+	Engine
+
+	Warmup(ctx context.Context) error
+}
+
 var _engines = map[string]map[string]Engine{}
 
 // RegisterGlobalEngine registers a search engine for used.
@@ -48,6 +133,16 @@ func GetEnginesByCategory(category string) map[string]Engine {
 	return nil
 }
 
+// GetEngine gets an enable engine by name from any category, nil is returned if not found.
+func GetEngine(name string) Engine {
+	for _, es := range _engines {
+		if e, ok := es[name]; ok {
+			return e
+		}
+	}
+	return nil
+}
+
 func SetGlobalEngines(engines map[string]map[string]Engine) {
 	_engines = engines
 }
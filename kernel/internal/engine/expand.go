@@ -0,0 +1,53 @@
+package engine
+
+import (
+	"strings"
+)
+
+const (
+	// maxExpandedTerms is the maximum of terms expanded in a query, so that the query is not bloated.
+	maxExpandedTerms = 3
+	// maxSynonyms is the maximum of synonyms a term is expanded with.
+	maxSynonyms = 3
+)
+
+// ExpandQuery expands the terms of query with their synonyms in the dictionary by the OR syntax,
+// e.g. "js tutorial" -> "(js OR javascript) tutorial" with {"js": ["javascript"]}.
+// The terms are looked up case-insensitively, operators, quoted terms and unknown terms are untouched.
+func ExpandQuery(q string, dict map[string][]string) string {
+	if len(dict) == 0 {
+		return q
+	}
+
+	terms := strings.Fields(q)
+	expanded := 0
+	for i, term := range terms {
+		if expanded >= maxExpandedTerms {
+			break
+		}
+		if strings.ContainsAny(term, `:"()`) {
+			continue
+		}
+
+		synonyms := dict[strings.ToLower(term)]
+		if len(synonyms) == 0 {
+			continue
+		}
+
+		alternatives := []string{term}
+		for _, s := range synonyms[:min(len(synonyms), maxSynonyms)] {
+			// the synonyms of multiple words are matched as phrases.
+			if strings.Contains(s, " ") {
+				s = `"` + s + `"`
+			}
+			alternatives = append(alternatives, s)
+		}
+		terms[i] = "(" + strings.Join(alternatives, " OR ") + ")"
+		expanded++
+	}
+
+	if expanded == 0 {
+		return q
+	}
+	return strings.Join(terms, " ")
+}
@@ -0,0 +1,52 @@
+package engine
+
+import "testing"
+
+func TestExpandQuery(t *testing.T) {
+	dict := map[string][]string{
+		"js":  {"javascript"},
+		"k8s": {"kubernetes", "kube", "k8", "kubernetes engine"},
+		"db":  {"database"},
+		"pg":  {"postgres"},
+		"go":  {"golang"},
+	}
+	cases := map[string]string{
+		"js tutorial":         "(js OR javascript) tutorial",
+		"JS tutorial":         "(JS OR javascript) tutorial",
+		"k8s":                 "(k8s OR kubernetes OR kube OR k8)",
+		"unknown terms":       "unknown terms",
+		"js site:example.com": "(js OR javascript) site:example.com",
+		`"js" tutorial`:       `"js" tutorial`,
+		"(js OR node) db":     "(js OR node) (db OR database)",
+		"js db pg go":         "(js OR javascript) (db OR database) (pg OR postgres) go",
+		"":                    "",
+	}
+	for q, want := range cases {
+		if got := ExpandQuery(q, dict); got != want {
+			t.Errorf("ExpandQuery(%q) = %q, want %q", q, got, want)
+		}
+	}
+
+	if got := ExpandQuery("js  tutorial", nil); got != "js  tutorial" {
+		t.Errorf("the query is changed without dictionary: %q", got)
+	}
+}
+
+func TestExpandQueryPhrase(t *testing.T) {
+	got := ExpandQuery("gke", map[string][]string{"gke": {"google kubernetes engine"}})
+	if want := `(gke OR "google kubernetes engine")`; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestSupportsQueryExpansion(t *testing.T) {
+	if SupportsQueryExpansion(&plainEngine{}) {
+		t.Error("the engine without capabilities is expanded")
+	}
+	if SupportsQueryExpansion(&capableEngine{}) {
+		t.Error("the engine not declaring query expansion is expanded")
+	}
+	if !SupportsQueryExpansion(&capableEngine{caps: Capabilities{QueryExpansion: true}}) {
+		t.Error("the engine declaring query expansion is not expanded")
+	}
+}
@@ -0,0 +1,92 @@
+package engine
+
+import (
+	"math"
+	"strings"
+	"time"
+
+	"golang.org/x/text/language"
+	"golang.org/x/text/message"
+)
+
+// compactSuffixes contains the suffixes of thousand, million and billion for languages.
+var compactSuffixes = map[string][3]string{
+	"en": {"K", "M", "B"},
+	"de": {" Tsd.", " Mio.", " Mrd."},
+	"fr": {" k", " M", " Md"},
+	"es": {" mil", " M", " mil M"},
+	"ru": {" тыс.", " млн", " млрд"},
+}
+
+// dateLayouts contains the date layouts for languages.
+var dateLayouts = map[string]string{
+	"en": "Jan 2, 2006",
+	"de": "02.01.2006",
+	"fr": "02/01/2006",
+	"es": "02/01/2006",
+	"ru": "02.01.2006",
+	"zh": "2006-01-02",
+	"ja": "2006/01/02",
+}
+
+// parseLanguage parses the language, e.g. "de-DE" -> de.
+// English is returned if the language is unknown.
+func parseLanguage(lang string) (language.Tag, string) {
+	tag, err := language.Parse(strings.ReplaceAll(lang, "_", "-"))
+	if err != nil {
+		return language.English, "en"
+	}
+	base, _ := tag.Base()
+	return tag, base.String()
+}
+
+// FormatViews formats the count of views in a compact form according to the language.
+// e.g. 1200000 -> "1.2M" in en, 1200000 -> "1,2 Mio." in de.
+func FormatViews(n int64, lang string) string {
+	tag, base := parseLanguage(lang)
+	p := message.NewPrinter(tag)
+
+	suffixes, ok := compactSuffixes[base]
+	if !ok {
+		suffixes = compactSuffixes["en"]
+	}
+
+	// keep one decimal at most, e.g. 1.25M -> 1.3M, 1.0M -> 1M.
+	compact := func(v float64) string {
+		return p.Sprint(math.Round(v*10) / 10)
+	}
+
+	switch {
+	case n >= 1e9:
+		return compact(float64(n)/1e9) + suffixes[2]
+	case n >= 1e6:
+		return compact(float64(n)/1e6) + suffixes[1]
+	case n >= 1e4:
+		return compact(float64(n)/1e3) + suffixes[0]
+	default:
+		return p.Sprintf("%d", n)
+	}
+}
+
+// FormatDate formats the date according to the language.
+// e.g. "Jan 2, 2006" in en, "02.01.2006" in de.
+func FormatDate(t time.Time, lang string) string {
+	_, base := parseLanguage(lang)
+	layout, ok := dateLayouts[base]
+	if !ok {
+		layout = time.DateOnly
+	}
+	return t.Format(layout)
+}
+
+// DatedContent prefixes the content with the date formatted according to the language,
+// e.g. "Jun 1, 2024 - The new release of go" in en. The content is returned as is if the date is unknown.
+func DatedContent(t time.Time, content string, lang string) string {
+	if t.IsZero() {
+		return content
+	}
+	if content == "" {
+		return FormatDate(t, lang)
+	}
+	return FormatDate(t, lang) + " - " + content
+}
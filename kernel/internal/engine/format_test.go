@@ -0,0 +1,66 @@
+package engine
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFormatViews(t *testing.T) {
+	tests := []struct {
+		n    int64
+		lang string
+		want string
+	}{
+		{1200000, "en", "1.2M"},
+		{1200000, "de-DE", "1,2 Mio."},
+		{1000000, "en", "1M"},
+		{35400, "en", "35.4K"},
+		{2500000000, "en", "2.5B"},
+		{9999, "en", "9,999"},
+		{9999, "de", "9.999"},
+		{870, "fr", "870"},
+		{1200000, "unknown", "1.2M"},
+	}
+	for _, tt := range tests {
+		if got := FormatViews(tt.n, tt.lang); got != tt.want {
+			t.Errorf("FormatViews(%d, %s) = %q, want %q", tt.n, tt.lang, got, tt.want)
+		}
+	}
+}
+
+func TestFormatDate(t *testing.T) {
+	d := time.Date(2024, time.March, 5, 10, 0, 0, 0, time.UTC)
+	tests := map[string]string{
+		"en":    "Mar 5, 2024",
+		"de_DE": "05.03.2024",
+		"zh":    "2024-03-05",
+		"ko":    "2024-03-05",
+		"xx":    "Mar 5, 2024",
+	}
+	for lang, want := range tests {
+		if got := FormatDate(d, lang); got != want {
+			t.Errorf("FormatDate(%s) = %q, want %q", lang, got, want)
+		}
+	}
+}
+
+func TestDatedContent(t *testing.T) {
+	d := time.Date(2024, time.March, 5, 10, 0, 0, 0, time.UTC)
+	tests := map[string]struct {
+		date    time.Time
+		content string
+		lang    string
+		want    string
+	}{
+		"dated":          {date: d, content: "Go 1.22", lang: "en", want: "Mar 5, 2024 - Go 1.22"},
+		"dated in de":    {date: d, content: "Go 1.22", lang: "de", want: "05.03.2024 - Go 1.22"},
+		"without date":   {content: "Go 1.22", lang: "en", want: "Go 1.22"},
+		"date only":      {date: d, lang: "en", want: "Mar 5, 2024"},
+		"without either": {lang: "en", want: ""},
+	}
+	for name, tt := range tests {
+		if got := DatedContent(tt.date, tt.content, tt.lang); got != tt.want {
+			t.Errorf("%s: DatedContent() = %q, want %q", name, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,38 @@
+package engine
+
+import (
+	"net/url"
+	"path"
+	"slices"
+	"strings"
+)
+
+// imageExtensions are the extensions of image urls, e.g. https://example.com/cat.jpg.
+var imageExtensions = []string{".jpg", ".jpeg", ".png", ".gif", ".webp", ".bmp", ".svg", ".avif", ".tif", ".tiff"}
+
+// imageFormatParams are the query params of image urls without extension, e.g. https://example.com/img?format=png.
+var imageFormatParams = []string{"format", "fm", "ext"}
+
+// IsImageURL reports whether the query is a single http url of an image, detected by the extension of its path
+// or the format param of image services. The content type is not fetched, so that the detection is offline.
+func IsImageURL(q string) bool {
+	q = strings.TrimSpace(q)
+	if q == "" || strings.ContainsAny(q, " \t\n") {
+		return false
+	}
+	u, err := url.Parse(q)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return false
+	}
+
+	if slices.Contains(imageExtensions, strings.ToLower(path.Ext(u.Path))) {
+		return true
+	}
+	params := u.Query()
+	for _, name := range imageFormatParams {
+		if format := strings.ToLower(params.Get(name)); format != "" && slices.Contains(imageExtensions, "."+format) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,29 @@
+package engine
+
+import "testing"
+
+func TestIsImageURL(t *testing.T) {
+	cases := map[string]bool{
+		"https://example.com/cat.jpg":                 true,
+		"http://example.com/images/Cat.PNG":           true,
+		" https://example.com/cat.webp?size=large ":   true,
+		"https://images.example.com/photo?fm=jpg&w=1": true,
+		"https://example.com/img?format=PNG":          true,
+		"https://example.com/cat.jpg.html":            false,
+		"https://example.com/img?format=pdf":          false,
+		"https://example.com/cat":                     false,
+		"ftp://example.com/cat.jpg":                   false,
+		"example.com/cat.jpg":                         false,
+		"cat.jpg":                                     false,
+		"https:///cat.jpg":                            false,
+		"https://example.com/cat.jpg diagram":         false,
+		"":                                            false,
+	}
+	for q, want := range cases {
+		t.Run(q, func(t *testing.T) {
+			if got := IsImageURL(q); got != want {
+				t.Errorf("IsImageURL(%q) = %v, want %v", q, got, want)
+			}
+		})
+	}
+}
@@ -0,0 +1,53 @@
+package engine
+
+import (
+	"encoding/json"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// MetaString gets the value of key in the metadata unmarshalled from json as string.
+// Numbers and booleans are formatted, ok is false if the key is missing or the value is not a scalar.
+func MetaString(m map[string]interface{}, key string) (string, bool) {
+	switch v := m[key].(type) {
+	case string:
+		return v, true
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64), true
+	case json.Number:
+		return v.String(), true
+	case bool:
+		return strconv.FormatBool(v), true
+	}
+	return "", false
+}
+
+// MetaInt gets the value of key in the metadata unmarshalled from json as int.
+// Numeric strings are parsed and fractions are truncated, ok is false if the value is not a number.
+func MetaInt(m map[string]interface{}, key string) (int, bool) {
+	var f float64
+	switch v := m[key].(type) {
+	case float64:
+		f = v
+	case json.Number:
+		n, err := v.Float64()
+		if err != nil {
+			return 0, false
+		}
+		f = n
+	case string:
+		n, err := strconv.ParseFloat(strings.TrimSpace(v), 64)
+		if err != nil {
+			return 0, false
+		}
+		f = n
+	default:
+		return 0, false
+	}
+
+	if math.IsNaN(f) || math.IsInf(f, 0) || f > math.MaxInt || f < math.MinInt {
+		return 0, false
+	}
+	return int(f), true
+}
@@ -0,0 +1,72 @@
+package engine
+
+import (
+	"encoding/json"
+	"math"
+	"strings"
+	"testing"
+)
+
+func TestMetaString(t *testing.T) {
+	m := map[string]interface{}{
+		"title":    "Learn Go",
+		"duration": 754.0,
+		"live":     false,
+		"tags":     []interface{}{"go"},
+		"owner":    map[string]interface{}{"name": "go"},
+		"null":     nil,
+	}
+	cases := map[string]struct {
+		want string
+		ok   bool
+	}{
+		"title":    {"Learn Go", true},
+		"duration": {"754", true},
+		"live":     {"false", true},
+		"tags":     {"", false},
+		"owner":    {"", false},
+		"null":     {"", false},
+		"missing":  {"", false},
+	}
+	for key, c := range cases {
+		if got, ok := MetaString(m, key); got != c.want || ok != c.ok {
+			t.Errorf("MetaString(%s) = %q, %v, want %q, %v", key, got, ok, c.want, c.ok)
+		}
+	}
+}
+
+func TestMetaInt(t *testing.T) {
+	var m map[string]interface{}
+	d := json.NewDecoder(strings.NewReader(`{"views": 1200000, "ratio": 4.7, "count": " 35 ", "bad": "35k", "huge": 1e300, "live": true}`))
+	d.UseNumber()
+	if err := d.Decode(&m); err != nil {
+		t.Fatal(err)
+	}
+	m["float"] = 12.9
+	m["nan"] = math.NaN()
+
+	cases := map[string]struct {
+		want int
+		ok   bool
+	}{
+		"views":   {1200000, true},
+		"ratio":   {4, true},
+		"count":   {35, true},
+		"float":   {12, true},
+		"bad":     {0, false},
+		"huge":    {0, false},
+		"nan":     {0, false},
+		"live":    {0, false},
+		"missing": {0, false},
+	}
+	for key, c := range cases {
+		if got, ok := MetaInt(m, key); got != c.want || ok != c.ok {
+			t.Errorf("MetaInt(%s) = %d, %v, want %d, %v", key, got, ok, c.want, c.ok)
+		}
+	}
+
+	// the json numbers are formatted as string as well.
+	if s, ok := MetaString(m, "views"); s != "1200000" || !ok {
+		t.Errorf("MetaString(views) = %q, %v", s, ok)
+	}
+}
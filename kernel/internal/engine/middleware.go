@@ -0,0 +1,22 @@
+package engine
+
+import (
+	"context"
+
+	"github.com/zvirgilx/searxng-go/kernel/internal/result"
+)
+
+// Handler searches by an engine, it requests the engine and parses the response.
+type Handler func(ctx context.Context, opts *Options) (*result.Result, error)
+
+// Middleware decorates the search of an engine for cross-cutting concerns, e.g. metrics, caching and retries,
+// so that engines do not need to know about them. A middleware may short-circuit by not calling next.
+type Middleware func(e Engine, next Handler) Handler
+
+// Chain composes the middlewares around the handler of engine, the first middleware is the outermost.
+func Chain(e Engine, h Handler, mw ...Middleware) Handler {
+	for i := len(mw) - 1; i >= 0; i-- {
+		h = mw[i](e, h)
+	}
+	return h
+}
@@ -0,0 +1,61 @@
+package engine
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"github.com/zvirgilx/searxng-go/kernel/internal/result"
+)
+
+// recordMiddleware records the name when it is entered and left.
+func recordMiddleware(name string, calls *[]string) Middleware {
+	return func(e Engine, next Handler) Handler {
+		return func(ctx context.Context, opts *Options) (*result.Result, error) {
+			*calls = append(*calls, "enter "+name)
+			defer func() { *calls = append(*calls, "leave "+name) }()
+			return next(ctx, opts)
+		}
+	}
+}
+
+func TestChainOrder(t *testing.T) {
+	var calls []string
+	h := Chain(&plainEngine{}, func(ctx context.Context, opts *Options) (*result.Result, error) {
+		calls = append(calls, "engine")
+		return result.CreateResult("plain", opts.PageNo), nil
+	}, recordMiddleware("outer", &calls), recordMiddleware("inner", &calls))
+
+	if _, err := h(context.Background(), &Options{PageNo: 1}); err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"enter outer", "enter inner", "engine", "leave inner", "leave outer"}
+	if !slices.Equal(calls, want) {
+		t.Errorf("calls = %v, want %v", calls, want)
+	}
+}
+
+func TestChainShortCircuit(t *testing.T) {
+	cached := result.CreateResult("plain", 1)
+	cache := func(e Engine, next Handler) Handler {
+		return func(ctx context.Context, opts *Options) (*result.Result, error) {
+			if opts.Query == "cached" {
+				return cached, nil
+			}
+			return next(ctx, opts)
+		}
+	}
+
+	var searched int
+	h := Chain(&plainEngine{}, func(ctx context.Context, opts *Options) (*result.Result, error) {
+		searched++
+		return result.CreateResult("plain", opts.PageNo), nil
+	}, cache)
+
+	if res, _ := h(context.Background(), &Options{Query: "cached", PageNo: 1}); res != cached || searched != 0 {
+		t.Errorf("the engine is searched on a hit, searched %d times", searched)
+	}
+	if res, _ := h(context.Background(), &Options{Query: "go", PageNo: 1}); res == cached || searched != 1 {
+		t.Errorf("the engine is not searched on a miss, searched %d times", searched)
+	}
+}
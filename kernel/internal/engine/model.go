@@ -1,6 +1,9 @@
 package engine
 
 import (
+	"net/http"
+	"time"
+
 	"github.com/zvirgilx/searxng-go/kernel/internal/network"
 )
 
@@ -12,13 +15,73 @@ type Options struct {
 	TimeRange string
 	Locale    string
 	Category  string
+	SortBy    string
+
+	// LanguageSpecified reports whether the language of Locale is requested by the client,
+	// rather than detected from the query or derived from the client ip.
+	LanguageSpecified bool
+
+	// SafeSearch is the level of filtering adult content, e.g. SafeSearchModerate.
+	SafeSearch int
+
+	// Categories are searched at once, the engines of categories are united.
+	Categories []string
+
+	// Engines restricts the searched engines of categories to them, empty means all. They are canonical names.
+	Engines []string
+
+	// Operators are stripped from query for engines without native operators support,
+	// the results of these engines are filtered by them.
+	Operators Operators
+
+	// MaxAge forces refetching the cached results older than it, 0 means any cached results within ttl are used.
+	MaxAge time.Duration
+
+	// Tags keep the results with any of them, ExcludeTags drop the results with any of them.
+	Tags        []string
+	ExcludeTags []string
+
+	// MinResults is the count of results below which the fallback engines are searched.
+	MinResults int
+
+	// AutoCorrect reruns the search with the correction of query if the results are few.
+	AutoCorrect bool
+
+	// ThumbnailSize is the preferred size of thumbnails, e.g. ThumbnailSizeMedium, engines with multiple sizes honor it.
+	ThumbnailSize string
+
+	// Verbatim searches the exact query, engines disable their query rewriting, e.g. spelling correction.
+	Verbatim bool
+
+	// MaxResultsPerEngine is the maximum of results of each engine, 0 means unlimited.
+	MaxResultsPerEngine int
+	// MaxResults is the maximum of results of the search, 0 means unlimited.
+	MaxResults int
 
 	Request *network.Request
+
+	// CookieJar is the cookie jar of a search, it is only set for engines which implement SessionEngine.
+	CookieJar http.CookieJar
 }
 
+const (
+	SafeSearchNone     = 0
+	SafeSearchModerate = 1
+	SafeSearchStrict   = 2
+)
+
 type Config struct {
 	Enable bool            `mapstructure:"enable"`
 	Client *network.Config `mapstructure:"client"`
 
+	// Type creates the engine by a generic implementation instead of a registered engine, e.g. generic_html.
+	Type string `mapstructure:"type"`
+
+	// WarmupInterval is the interval of refreshing warmup, used by engines implement WarmupEngine.
+	WarmupInterval time.Duration `mapstructure:"warmup_interval"`
+
+	// MinQueryLen overrides the minimum length of query of engine capabilities, e.g. 3 for heavy apis.
+	MinQueryLen int `mapstructure:"min_query_len"`
+
 	Extra interface{} `mapstructure:"extra"`
 }
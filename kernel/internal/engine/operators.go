@@ -0,0 +1,124 @@
+package engine
+
+import (
+	"net/url"
+	"path"
+	"slices"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+const (
+	OperatorSite     = "site:"
+	OperatorFileType = "filetype:"
+	OperatorExclude  = "-"
+)
+
+// Operators are the advanced operators typed in query.
+type Operators struct {
+	Site     string // Site limits results to the host and its subdomains, e.g. site:example.com.
+	FileType string // FileType limits results to the file extension, e.g. filetype:pdf.
+
+	// Exclude drops results containing any of the terms in title, content or url, e.g. -javascript.
+	// The terms are lower case.
+	Exclude []string
+}
+
+// ParseOperators extracts the operators from query, the query without operators is returned.
+// The last one wins if an operator is typed several times, except the excluded terms which are all kept.
+func ParseOperators(query string) (string, Operators) {
+	var ops Operators
+	var words []string
+	for _, word := range strings.Fields(query) {
+		lower := strings.ToLower(word)
+		switch {
+		case strings.HasPrefix(lower, OperatorSite) && len(word) > len(OperatorSite):
+			ops.Site = strings.TrimPrefix(lower[len(OperatorSite):], "www.")
+		case strings.HasPrefix(lower, OperatorFileType) && len(word) > len(OperatorFileType):
+			ops.FileType = strings.TrimPrefix(lower[len(OperatorFileType):], ".")
+		case isExcludedTerm(lower):
+			ops.Exclude = append(ops.Exclude, strings.Trim(lower[len(OperatorExclude):], `"`))
+		default:
+			words = append(words, word)
+		}
+	}
+	return strings.Join(words, " "), ops
+}
+
+// isExcludedTerm reports whether the word is an excluded term, e.g. -javascript or -"java".
+// The words not starting with a letter after the dash are kept in query, e.g. -5 or --help.
+func isExcludedTerm(word string) bool {
+	term, ok := strings.CutPrefix(word, OperatorExclude)
+	if !ok {
+		return false
+	}
+	r, _ := utf8.DecodeRuneInString(strings.TrimPrefix(term, `"`))
+	return unicode.IsLetter(r)
+}
+
+func (o Operators) IsEmpty() bool {
+	return o.Site == "" && o.FileType == "" && len(o.Exclude) == 0
+}
+
+// Excluded reports whether any of the texts contains an excluded term as a whole word, e.g. the title, content and url of a result.
+// The terms are matched case-insensitively, so that -java does not exclude javascript.
+// A term of several words matches them in a row, e.g. -node.js matches "node js".
+func (o Operators) Excluded(texts ...string) bool {
+	if len(o.Exclude) == 0 {
+		return false
+	}
+	for _, text := range texts {
+		words := splitWords(text)
+		for _, term := range o.Exclude {
+			if containsWords(words, splitWords(term)) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// splitWords splits the text into lower case words of letters and digits.
+func splitWords(text string) []string {
+	return strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+// containsWords reports whether the words contain the terms in a row.
+func containsWords(words, terms []string) bool {
+	if len(terms) == 0 {
+		return false
+	}
+	for i := 0; i+len(terms) <= len(words); i++ {
+		if slices.Equal(words[i:i+len(terms)], terms) {
+			return true
+		}
+	}
+	return false
+}
+
+// Match reports whether the url satisfies the operators, it is used to post-filter results
+// of engines without native operators support.
+func (o Operators) Match(rawUrl string) bool {
+	if o.IsEmpty() {
+		return true
+	}
+
+	u, err := url.Parse(rawUrl)
+	if err != nil {
+		return false
+	}
+
+	if o.Site != "" {
+		host := strings.ToLower(u.Hostname())
+		if host != o.Site && !strings.HasSuffix(host, "."+o.Site) {
+			return false
+		}
+	}
+	if o.FileType != "" && !strings.EqualFold(path.Ext(u.Path), "."+o.FileType) {
+		return false
+	}
+	return true
+}
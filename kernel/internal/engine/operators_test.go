@@ -0,0 +1,113 @@
+package engine
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"github.com/zvirgilx/searxng-go/kernel/internal/result"
+)
+
+// plainEngine does not report its capabilities.
+type plainEngine struct{}
+
+func (e *plainEngine) Request(context.Context, *Options) error { return nil }
+
+func (e *plainEngine) Response(context.Context, *Options, []byte) (*result.Result, error) {
+	return nil, nil
+}
+
+func (e *plainEngine) GetName() string { return "plain" }
+
+func (e *plainEngine) ApplyConfig(Config) error { return nil }
+
+func TestParseOperators(t *testing.T) {
+	query, ops := ParseOperators(`golang site:www.Go.dev filetype:.PDF -Javascript -"java" -5 tips`)
+	if query != "golang -5 tips" {
+		t.Errorf("query = %q, want %q", query, "golang -5 tips")
+	}
+	if ops.Site != "go.dev" || ops.FileType != "pdf" {
+		t.Errorf("site = %q, filetype = %q, want go.dev and pdf", ops.Site, ops.FileType)
+	}
+	if want := []string{"javascript", "java"}; !slices.Equal(ops.Exclude, want) {
+		t.Errorf("exclude = %v, want %v", ops.Exclude, want)
+	}
+
+	// the operators without value are kept in query.
+	if query, ops := ParseOperators("site: filetype:"); query != "site: filetype:" || !ops.IsEmpty() {
+		t.Errorf("got %q, %+v", query, ops)
+	}
+}
+
+func TestOperatorsMatch(t *testing.T) {
+	ops := Operators{Site: "go.dev", FileType: "pdf"}
+	cases := map[string]bool{
+		"https://go.dev/doc/spec.pdf":         true,
+		"https://pkg.go.dev/doc/spec.PDF":     true,
+		"https://go.dev/doc/spec.html":        false,
+		"https://notgo.dev/doc/spec.pdf":      false,
+		"https://go.dev.example.com/spec.pdf": false,
+	}
+	for u, want := range cases {
+		if got := ops.Match(u); got != want {
+			t.Errorf("Match(%q) = %v, want %v", u, got, want)
+		}
+	}
+	if !(Operators{}).Match("://invalid") {
+		t.Error("the empty operators do not match every url")
+	}
+}
+
+func TestApplyCapabilitiesOperators(t *testing.T) {
+	// the engine supporting operators natively gets them in query.
+	native := &capableEngine{caps: Capabilities{Operators: true}}
+	opts := Options{Query: "go site:go.dev", PageNo: 1}
+	ApplyCapabilities(native, &opts)
+	if opts.Query != "go site:go.dev" || !opts.Operators.IsEmpty() {
+		t.Errorf("the operators of native engine are parsed: %+v", opts)
+	}
+
+	// the operators are stripped for the engines without native support, and engines without capabilities.
+	for _, e := range []Engine{&capableEngine{}, &plainEngine{}} {
+		opts := Options{Query: "go site:go.dev", PageNo: 1}
+		ApplyCapabilities(e, &opts)
+		if opts.Query != "go" || opts.Operators.Site != "go.dev" {
+			t.Errorf("%T: the operators are not parsed: %+v", e, opts)
+		}
+	}
+}
+
+func TestApplyCapabilitiesOperatorsOnly(t *testing.T) {
+	// the query of operators only is kept, rather than searched as an empty query.
+	opts := Options{Query: "site:go.dev", PageNo: 1}
+	ApplyCapabilities(&capableEngine{}, &opts)
+	if opts.Query != "site:go.dev" || opts.Operators.Site != "go.dev" {
+		t.Errorf("got %+v", opts)
+	}
+}
+
+func TestOperatorsExcluded(t *testing.T) {
+	cases := map[string]struct {
+		exclude []string
+		texts   []string
+		want    bool
+	}{
+		"word":               {exclude: []string{"java"}, texts: []string{"Learn Java in a day"}, want: true},
+		"part of word":       {exclude: []string{"java"}, texts: []string{"JavaScript tutorial"}},
+		"word in url":        {exclude: []string{"java"}, texts: []string{"Tutorial", "https://example.com/java/intro"}, want: true},
+		"part of url":        {exclude: []string{"java"}, texts: []string{"https://javascript.info"}},
+		"terms in a row":     {exclude: []string{"node.js"}, texts: []string{"Install Node.JS on linux"}, want: true},
+		"terms not in a row": {exclude: []string{"node.js"}, texts: []string{"node modules in js"}},
+		"any of terms":       {exclude: []string{"rust", "java"}, texts: []string{"go", "java"}, want: true},
+		"unicode":            {exclude: []string{"café"}, texts: []string{"Le Café de Flore"}, want: true},
+		"term without words": {exclude: []string{"-"}, texts: []string{"a - b"}},
+		"no terms":           {texts: []string{"java"}},
+	}
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			if got := (Operators{Exclude: c.exclude}).Excluded(c.texts...); got != c.want {
+				t.Errorf("Excluded(%q) of %v = %v, want %v", c.texts, c.exclude, got, c.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,13 @@
+package engine
+
+import "context"
+
+// parseCheckInterval is the count of items between the checks of cancellation in parse loops.
+const parseCheckInterval = 8
+
+// ParseCanceled reports whether a parse loop should stop at the i-th item since the context is done,
+// e.g. the deadline of search fires while parsing a large page. The results parsed so far are kept by the caller.
+// The context is checked every parseCheckInterval items to keep the loop cheap.
+func ParseCanceled(ctx context.Context, i int) bool {
+	return i%parseCheckInterval == 0 && ctx.Err() != nil
+}
@@ -0,0 +1,29 @@
+package engine
+
+import (
+	"context"
+	"testing"
+)
+
+func TestParseCanceled(t *testing.T) {
+	canceled, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	cases := map[string]struct {
+		ctx  context.Context
+		i    int
+		want bool
+	}{
+		"done at first item":   {ctx: canceled, i: 0, want: true},
+		"done at interval":     {ctx: canceled, i: parseCheckInterval * 2, want: true},
+		"done between checks":  {ctx: canceled, i: parseCheckInterval + 1},
+		"not done at interval": {ctx: context.Background(), i: parseCheckInterval},
+	}
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			if got := ParseCanceled(c.ctx, c.i); got != c.want {
+				t.Errorf("ParseCanceled(%d) = %v, want %v", c.i, got, c.want)
+			}
+		})
+	}
+}
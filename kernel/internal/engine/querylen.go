@@ -0,0 +1,45 @@
+package engine
+
+import (
+	"strings"
+	"sync"
+	"unicode/utf8"
+)
+
+var (
+	minQueryLenMu sync.RWMutex
+	// _minQueryLens are the minimums of query length configured for engines, they override the capabilities.
+	_minQueryLens = map[string]int{}
+)
+
+// SetMinQueryLens replaces the configured minimums of query length, the key is the engine name.
+func SetMinQueryLens(lens map[string]int) {
+	minQueryLenMu.Lock()
+	defer minQueryLenMu.Unlock()
+	_minQueryLens = lens
+}
+
+// MinQueryLen gets the minimum of query length of engine, the configured one takes precedence over the capability.
+func MinQueryLen(e Engine) int {
+	minQueryLenMu.RLock()
+	n, ok := _minQueryLens[e.GetName()]
+	minQueryLenMu.RUnlock()
+	if ok {
+		return n
+	}
+
+	if ce, ok := e.(CapableEngine); ok {
+		return ce.Capabilities().MinQueryLen
+	}
+	return 0
+}
+
+// AcceptsQuery reports whether the query is long enough to be requested from the engine.
+// The length is counted in characters without operators, the empty query of trending is always accepted.
+func AcceptsQuery(e Engine, query string) bool {
+	if query == "" {
+		return true
+	}
+	q, _ := ParseOperators(query)
+	return utf8.RuneCountInString(strings.TrimSpace(q)) >= MinQueryLen(e)
+}
@@ -0,0 +1,40 @@
+package engine
+
+import "testing"
+
+func TestAcceptsQuery(t *testing.T) {
+	capable := &capableEngine{caps: Capabilities{MinQueryLen: 3}}
+	cases := map[string]struct {
+		e     Engine
+		query string
+		want  bool
+	}{
+		"long enough":        {e: capable, query: "gol", want: true},
+		"too short":          {e: capable, query: "go"},
+		"counted in runes":   {e: capable, query: "日本語", want: true},
+		"spaces not counted": {e: capable, query: "  go  "},
+		"operators dropped":  {e: capable, query: "go site:go.dev"},
+		"empty of trending":  {e: capable, query: "", want: true},
+		"no minimum":         {e: &plainEngine{}, query: "g", want: true},
+	}
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			if got := AcceptsQuery(c.e, c.query); got != c.want {
+				t.Errorf("AcceptsQuery(%q) = %v, want %v", c.query, got, c.want)
+			}
+		})
+	}
+}
+
+func TestMinQueryLenConfigured(t *testing.T) {
+	SetMinQueryLens(map[string]int{"capable": 1, "plain": 4})
+	t.Cleanup(func() { SetMinQueryLens(map[string]int{}) })
+
+	// the configured minimum overrides the capability.
+	if n := MinQueryLen(&capableEngine{caps: Capabilities{MinQueryLen: 3}}); n != 1 {
+		t.Errorf("min query len = %d, want the configured 1", n)
+	}
+	if AcceptsQuery(&plainEngine{}, "gol") {
+		t.Error("the query shorter than the configured minimum is accepted")
+	}
+}
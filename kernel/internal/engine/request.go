@@ -0,0 +1,37 @@
+package engine
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrInvalidRequest means the engine built a request that can not be fetched.
+var ErrInvalidRequest = errors.New("invalid engine request")
+
+// NormalizeOptions clamps the params of options into the bounds engines rely on.
+// PageNo is at least 1, and at most maxPageNo if it is positive.
+func NormalizeOptions(opts *Options, maxPageNo int) {
+	if opts.PageNo < 1 {
+		opts.PageNo = 1
+	}
+	if maxPageNo > 0 && opts.PageNo > maxPageNo {
+		opts.PageNo = maxPageNo
+	}
+}
+
+// ValidateRequest ensures the request built by engine has a well-formed absolute url.
+// A nil request is valid, it means the engine skips this search.
+// The url is recorded to Options.Url when it is valid.
+func ValidateRequest(opts *Options) error {
+	if opts.Request == nil {
+		return nil
+	}
+
+	u := opts.Request.URL()
+	if u == nil || !u.IsAbs() || u.Host == "" {
+		return fmt.Errorf("%w: url %q is not an absolute url", ErrInvalidRequest, u)
+	}
+
+	opts.Url = u.String()
+	return nil
+}
@@ -0,0 +1,65 @@
+package engine
+
+import (
+	"errors"
+	"net/url"
+	"testing"
+
+	"github.com/zvirgilx/searxng-go/kernel/internal/network"
+)
+
+func TestValidateRequest(t *testing.T) {
+	client := network.DefaultClient()
+	base, _ := url.Parse("https://www.bing.com")
+	relative, _ := url.Parse("/search")
+
+	tests := []struct {
+		name    string
+		req     *network.Request
+		wantErr bool
+		wantUrl string
+	}{
+		{"nil request skips the search", nil, false, ""},
+		{"absolute url", client.Get().Base(base).Path("search").Param("q", "go"), false, "https://www.bing.com/search?q=go"},
+		{"empty url", client.Get(), true, ""},
+		{"relative url", client.Get().Base(relative).Param("q", "go"), true, ""},
+		{"path without base", client.Get().Path("search"), true, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := &Options{Request: tt.req}
+			err := ValidateRequest(opts)
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidRequest) {
+					t.Errorf("got err %v, want ErrInvalidRequest", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if opts.Url != tt.wantUrl {
+				t.Errorf("url = %q, want %q", opts.Url, tt.wantUrl)
+			}
+		})
+	}
+}
+
+func TestNormalizeOptions(t *testing.T) {
+	tests := []struct {
+		pageNo, maxPageNo, want int
+	}{
+		{0, 0, 1},
+		{-3, 10, 1},
+		{5, 0, 5},
+		{5, 10, 5},
+		{50, 10, 10},
+	}
+	for _, tt := range tests {
+		opts := &Options{PageNo: tt.pageNo}
+		NormalizeOptions(opts, tt.maxPageNo)
+		if opts.PageNo != tt.want {
+			t.Errorf("NormalizeOptions(page %d, max %d) = %d, want %d", tt.pageNo, tt.maxPageNo, opts.PageNo, tt.want)
+		}
+	}
+}
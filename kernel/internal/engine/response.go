@@ -0,0 +1,85 @@
+package engine
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"mime"
+	"net/http"
+)
+
+// ErrBlocked means the engine is blocked by upstream, e.g. a captcha or challenge page is responded.
+var ErrBlocked = errors.New("engine is blocked by upstream")
+
+// blockedMarkers are the markers of challenge pages responded in place of results.
+var blockedMarkers = [][]byte{[]byte("captcha"), []byte("challenge-form"), []byte("unusual traffic")}
+
+// BlockDetector is an engine which detects its own challenge pages precisely, e.g. by the selectors of its captcha page,
+// rather than by the generic markers, which may be in the results of a query as well.
+type BlockDetector interface {
+	Engine
+
+	// Blocked reports whether the body is a challenge page instead of results.
+	Blocked(body []byte) bool
+}
+
+// ValidateResponse ensures the content type of response is the one expected by the engine,
+// so that an error page is classified early instead of failing silently in Response.
+// The engine implementing BlockDetector detects its own challenge pages. For the other engines, an html response
+// in place of the expected content type is checked for the markers of challenge pages, e.g. a captcha page for a json api.
+// The responses of expected content type are never checked by the markers, which may be in the results of a query as well.
+// Engines not declaring the content type in capabilities are not validated, neither are the responses without content type.
+func ValidateResponse(e Engine, opts *Options, contentType string, body []byte) error {
+	bd, detector := e.(BlockDetector)
+	if detector && bd.Blocked(body) {
+		return fmt.Errorf("%w: challenge page of %s", ErrBlocked, e.GetName())
+	}
+
+	ce, ok := e.(CapableEngine)
+	if !ok {
+		return nil
+	}
+	expected := ce.Capabilities().ContentType
+	if expected == "" {
+		return nil
+	}
+	// challenged reports whether the response of unexpected media type is a challenge page by the markers.
+	challenged := func(mediaType string) bool {
+		return !detector && mediaType != expected && mediaType == "text/html" && containsAny(bytes.ToLower(body), blockedMarkers)
+	}
+
+	// the content type of response is unknown without the header, it is sniffed for the challenge pages only.
+	if contentType == "" {
+		if challenged(sniffMediaType(body)) {
+			return fmt.Errorf("%w: challenge page of %s", ErrBlocked, e.GetName())
+		}
+		return nil
+	}
+
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err == nil && mediaType == expected {
+		return nil
+	}
+	if err == nil && challenged(mediaType) {
+		return fmt.Errorf("%w: challenge page of %s", ErrBlocked, e.GetName())
+	}
+	return NewParseError(opts, fmt.Sprintf("unexpected content type %q, expected %q", contentType, expected), body)
+}
+
+// sniffMediaType gets the media type of body by sniffing, empty if it is unknown.
+func sniffMediaType(body []byte) string {
+	mediaType, _, err := mime.ParseMediaType(http.DetectContentType(body))
+	if err != nil {
+		return ""
+	}
+	return mediaType
+}
+
+func containsAny(b []byte, subs [][]byte) bool {
+	for _, sub := range subs {
+		if bytes.Contains(b, sub) {
+			return true
+		}
+	}
+	return false
+}
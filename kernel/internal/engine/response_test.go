@@ -0,0 +1,68 @@
+package engine
+
+import (
+	"errors"
+	"testing"
+)
+
+// typedEngine expects the responses of content type.
+type typedEngine struct {
+	plainEngine
+	contentType string
+}
+
+func (e *typedEngine) Capabilities() Capabilities {
+	return Capabilities{Categories: []string{CategoryGeneral}, ContentType: e.contentType}
+}
+
+// detectingEngine detects its challenge page by the marker of it.
+type detectingEngine struct {
+	typedEngine
+}
+
+func (e *detectingEngine) Blocked(body []byte) bool { return string(body) == "<html>#challenge</html>" }
+
+func TestValidateResponse(t *testing.T) {
+	jsonEngine := &typedEngine{contentType: "application/json"}
+	htmlEngine := &typedEngine{contentType: "text/html"}
+	captcha := []byte(`<html><body><form id="captcha">Please solve the CAPTCHA</form></body></html>`)
+
+	cases := map[string]struct {
+		e           Engine
+		contentType string
+		body        []byte
+		blocked     bool
+		parseErr    bool
+	}{
+		"expected":             {e: jsonEngine, contentType: "application/json; charset=utf-8", body: []byte(`{}`)},
+		"mismatch":             {e: jsonEngine, contentType: "text/plain", body: []byte("error"), parseErr: true},
+		"captcha for json":     {e: jsonEngine, contentType: "text/html", body: captcha, blocked: true},
+		"captcha without type": {e: jsonEngine, body: captcha, blocked: true},
+		// the results of expected content type may mention the markers, e.g. the results of query captcha.
+		"captcha in html":              {e: htmlEngine, contentType: "text/html; charset=utf-8", body: captcha},
+		"captcha in html without type": {e: htmlEngine, body: captcha},
+		"captcha of capless":           {e: &plainEngine{}, contentType: "text/html", body: captcha},
+		"text for json":                {e: jsonEngine, contentType: "text/plain", body: []byte("captcha"), parseErr: true},
+		"captcha in json":              {e: jsonEngine, contentType: "application/json", body: []byte(`{"title": "captcha"}`)},
+		"html for html":                {e: htmlEngine, contentType: "text/html", body: []byte("<html>results</html>")},
+		"unknown content type":         {e: jsonEngine, body: []byte(`{}`)},
+		"invalid content type":         {e: jsonEngine, contentType: "application/", body: []byte(`{}`), parseErr: true},
+		"not declared":                 {e: &typedEngine{}, contentType: "text/plain", body: []byte("ok")},
+		"blocked by detector":          {e: &detectingEngine{*htmlEngine}, contentType: "text/html", body: []byte("<html>#challenge</html>"), blocked: true},
+		"markers of detector":          {e: &detectingEngine{*htmlEngine}, contentType: "text/html", body: captcha},
+		"mismatch of detector":         {e: &detectingEngine{*jsonEngine}, contentType: "text/html", body: captcha, parseErr: true},
+	}
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			err := ValidateResponse(c.e, &Options{Query: "go"}, c.contentType, c.body)
+			switch {
+			case c.blocked && !errors.Is(err, ErrBlocked):
+				t.Errorf("got %v, want blocked", err)
+			case c.parseErr && !errors.Is(err, ErrParse):
+				t.Errorf("got %v, want a parse error", err)
+			case !c.blocked && !c.parseErr && err != nil:
+				t.Errorf("got %v, want no error", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,25 @@
+package engine
+
+const (
+	ThumbnailSizeSmall  = "small"
+	ThumbnailSizeMedium = "medium"
+	ThumbnailSizeLarge  = "large"
+)
+
+// IsThumbnailSize reports whether the size is a known thumbnail size.
+func IsThumbnailSize(size string) bool {
+	return size == ThumbnailSizeSmall || size == ThumbnailSizeMedium || size == ThumbnailSizeLarge
+}
+
+// ThumbnailToken picks the size token of engine for the preferred thumbnail size of options,
+// the medium one is picked if the size is not specified.
+func ThumbnailToken(opts *Options, small, medium, large string) string {
+	switch opts.ThumbnailSize {
+	case ThumbnailSizeSmall:
+		return small
+	case ThumbnailSizeLarge:
+		return large
+	default:
+		return medium
+	}
+}
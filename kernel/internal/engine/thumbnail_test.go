@@ -0,0 +1,31 @@
+package engine
+
+import "testing"
+
+func TestThumbnailToken(t *testing.T) {
+	cases := map[string]string{
+		ThumbnailSizeSmall:  "w185",
+		ThumbnailSizeMedium: "w342",
+		ThumbnailSizeLarge:  "w780",
+		"":                  "w342",
+		"huge":              "w342",
+	}
+	for size, want := range cases {
+		if got := ThumbnailToken(&Options{ThumbnailSize: size}, "w185", "w342", "w780"); got != want {
+			t.Errorf("ThumbnailToken(%q) = %q, want %q", size, got, want)
+		}
+	}
+}
+
+func TestIsThumbnailSize(t *testing.T) {
+	for _, size := range []string{ThumbnailSizeSmall, ThumbnailSizeMedium, ThumbnailSizeLarge} {
+		if !IsThumbnailSize(size) {
+			t.Errorf("%s is not a thumbnail size", size)
+		}
+	}
+	for _, size := range []string{"", "Large", "huge"} {
+		if IsThumbnailSize(size) {
+			t.Errorf("%q is a thumbnail size", size)
+		}
+	}
+}
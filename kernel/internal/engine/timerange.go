@@ -0,0 +1,30 @@
+package engine
+
+import "time"
+
+const (
+	TimeRangeDay   = "day"
+	TimeRangeWeek  = "week"
+	TimeRangeMonth = "month"
+	TimeRangeYear  = "year"
+)
+
+// TimeRangeBounds returns the bounds of time range which ends at now.
+// Engines can derive their native parameter from the bounds consistently,
+// e.g. the minutes of range for bing, or the start date for arXiv.
+// ok is false if the time range is unknown.
+func TimeRangeBounds(tr string, now time.Time) (start, end time.Time, ok bool) {
+	switch tr {
+	case TimeRangeDay:
+		start = now.AddDate(0, 0, -1)
+	case TimeRangeWeek:
+		start = now.AddDate(0, 0, -7)
+	case TimeRangeMonth:
+		start = now.AddDate(0, -1, 0)
+	case TimeRangeYear:
+		start = now.AddDate(-1, 0, 0)
+	default:
+		return time.Time{}, time.Time{}, false
+	}
+	return start, now, true
+}
@@ -0,0 +1,43 @@
+package engine
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTimeRangeBounds(t *testing.T) {
+	now := time.Date(2024, 3, 31, 10, 30, 0, 0, time.UTC)
+	tests := []struct {
+		tr    string
+		start time.Time
+	}{
+		{TimeRangeDay, time.Date(2024, 3, 30, 10, 30, 0, 0, time.UTC)},
+		{TimeRangeWeek, time.Date(2024, 3, 24, 10, 30, 0, 0, time.UTC)},
+		// the month before March 31 is normalized to March 2, as time.AddDate does.
+		{TimeRangeMonth, time.Date(2024, 3, 2, 10, 30, 0, 0, time.UTC)},
+		{TimeRangeYear, time.Date(2023, 3, 31, 10, 30, 0, 0, time.UTC)},
+	}
+	for _, tt := range tests {
+		start, end, ok := TimeRangeBounds(tt.tr, now)
+		if !ok || !start.Equal(tt.start) || !end.Equal(now) {
+			t.Errorf("TimeRangeBounds(%q) = %v, %v, %v, want %v, %v, true", tt.tr, start, end, ok, tt.start, now)
+		}
+	}
+
+	for _, tr := range []string{"", "decade"} {
+		if start, end, ok := TimeRangeBounds(tr, now); ok || !start.IsZero() || !end.IsZero() {
+			t.Errorf("TimeRangeBounds(%q) = %v, %v, %v, want zero bounds and false", tr, start, end, ok)
+		}
+	}
+}
+
+func TestTimeRangeBoundsMinutes(t *testing.T) {
+	// the engines encoding time range by minutes derive them from the bounds, e.g. bing videos.
+	now := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	for tr, want := range map[string]int{TimeRangeDay: 1440, TimeRangeWeek: 10080} {
+		start, end, _ := TimeRangeBounds(tr, now)
+		if got := int(end.Sub(start).Minutes()); got != want {
+			t.Errorf("minutes of %s = %d, want %d", tr, got, want)
+		}
+	}
+}
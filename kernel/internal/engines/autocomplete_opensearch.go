@@ -0,0 +1,137 @@
+package engines
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/url"
+	"strings"
+	"sync"
+
+	"github.com/mitchellh/mapstructure"
+	"github.com/zvirgilx/searxng-go/kernel/internal/complete"
+	"github.com/zvirgilx/searxng-go/kernel/internal/network"
+)
+
+const (
+	CompleterNameOpenSearch = "opensearch"
+)
+
+// openSearchCompleter completes the query by the opensearch suggestions endpoints of sites,
+// which respond the array of [query, [suggestions], [descriptions], [urls]], only the suggestions are used.
+type openSearchCompleter struct {
+	client *network.Client
+
+	urls []string
+}
+
+// OpenSearchCompleterConfig is the configuration of opensearch completer.
+//
+// The url templates support the placeholders {query} and {lang}, e.g. https://en.wikipedia.org/w/api.php?action=opensearch&search={query}.
+type OpenSearchCompleterConfig struct {
+	Urls   []string        `mapstructure:"urls"`   // Urls are the templates of suggestions urls, the suggestions of all urls are merged in order.
+	Client *network.Config `mapstructure:"client"` // Client is the config of http client.
+}
+
+func init() {
+	complete.RegisterCompleter(CompleterNameOpenSearch, &openSearchCompleter{client: network.DefaultClient()})
+}
+
+func (o *openSearchCompleter) Complete(ctx context.Context, q string, locale string) []complete.Result {
+	// the suggestions of sites are requested concurrently, then merged in the order of urls.
+	suggestions := make([][]string, len(o.urls))
+	var wg sync.WaitGroup
+	for i, u := range o.urls {
+		wg.Add(1)
+		go func(i int, u string) {
+			defer wg.Done()
+			suggestions[i] = o.suggest(ctx, u, q, locale)
+		}(i, u)
+	}
+	wg.Wait()
+
+	var results []complete.Result
+	seen := map[string]bool{}
+	for _, texts := range suggestions {
+		for _, text := range texts {
+			if seen[text] {
+				continue
+			}
+			seen[text] = true
+			results = append(results, complete.Result{Type: complete.TypeText, Text: text})
+		}
+	}
+	return results
+}
+
+// suggest requests the suggestions of an url template, nil is returned if failed.
+func (o *openSearchCompleter) suggest(ctx context.Context, tmpl string, q string, locale string) []string {
+	log := slog.With("func", "opensearch.Complete")
+
+	// the language of locale, e.g. en-US -> en.
+	lang, _, _ := strings.Cut(strings.ReplaceAll(locale, "_", "-"), "-")
+	u, err := url.Parse(strings.NewReplacer("{query}", url.QueryEscape(q), "{lang}", url.QueryEscape(lang)).Replace(tmpl))
+	if err != nil {
+		log.ErrorContext(ctx, "failed to parse opensearch url", slog.String("url", tmpl), slog.String("err", err.Error()))
+		return nil
+	}
+
+	// the query of url has been built by the template.
+	req := o.client.Get().Base(u).Path(u.Path).Header("Accept", "application/x-suggestions+json, application/json")
+	for k, vs := range u.Query() {
+		req.Param(k, vs...)
+	}
+
+	res := req.Do(ctx)
+	if res.Err != nil {
+		log.ErrorContext(ctx, "failed to request opensearch suggestions", slog.String("host", u.Host), slog.String("err", res.Err.Error()))
+		return nil
+	}
+
+	suggestions, err := parseOpenSearchSuggestions(res.Body)
+	if err != nil {
+		log.ErrorContext(ctx, "failed to parse opensearch suggestions", slog.String("host", u.Host), slog.String("err", err.Error()))
+		return nil
+	}
+	return suggestions
+}
+
+// parseOpenSearchSuggestions parses the suggestions of the array [query, [suggestions], ...].
+// An error is returned if the response is not the array, the items of suggestions which are not string are skipped.
+func parseOpenSearchSuggestions(body []byte) ([]string, error) {
+	var data []json.RawMessage
+	if err := json.Unmarshal(body, &data); err != nil {
+		return nil, err
+	}
+	if len(data) < 2 {
+		return nil, errors.New("opensearch suggestions too short")
+	}
+
+	var items []interface{}
+	if err := json.Unmarshal(data[1], &items); err != nil {
+		return nil, err
+	}
+
+	var suggestions []string
+	for _, item := range items {
+		if s, ok := item.(string); ok && strings.TrimSpace(s) != "" {
+			suggestions = append(suggestions, strings.TrimSpace(s))
+		}
+	}
+	return suggestions, nil
+}
+
+func (o *openSearchCompleter) ApplyConfig(extra interface{}) error {
+	var c *OpenSearchCompleterConfig
+	if err := mapstructure.Decode(extra, &c); err != nil {
+		return err
+	}
+	if c == nil || len(c.Urls) == 0 {
+		return errors.New("no urls of opensearch suggestions")
+	}
+
+	o.urls = c.Urls
+	o.client = network.NewClient(c.Client)
+	return nil
+}
@@ -0,0 +1,120 @@
+package engines
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/zvirgilx/searxng-go/kernel/internal/complete"
+)
+
+func TestParseOpenSearchSuggestions(t *testing.T) {
+	cases := map[string]struct {
+		body    string
+		want    []string
+		wantErr bool
+	}{
+		"suggestions":           {body: `["go",["golang"," go tour ","go"],["desc"],["https://go.dev"]]`, want: []string{"golang", "go tour", "go"}},
+		"without others":        {body: `["go",["golang"]]`, want: []string{"golang"}},
+		"non-string skipped":    {body: `["go",["golang",1,null,{"a":1}," "]]`, want: []string{"golang"}},
+		"empty suggestions":     {body: `["go",[]]`},
+		"too short":             {body: `["go"]`, wantErr: true},
+		"not array":             {body: `{"query":"go"}`, wantErr: true},
+		"suggestions not array": {body: `["go","golang"]`, wantErr: true},
+		"not json":              {body: `<html>`, wantErr: true},
+	}
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			got, err := parseOpenSearchSuggestions([]byte(c.body))
+			if (err != nil) != c.wantErr {
+				t.Fatalf("err = %v, want error %v", err, c.wantErr)
+			}
+			if !slices.Equal(got, c.want) {
+				t.Errorf("suggestions = %q, want %q", got, c.want)
+			}
+		})
+	}
+}
+
+func TestOpenSearchComplete(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		q, lang := r.URL.Query().Get("search"), r.URL.Query().Get("lang")
+		switch r.URL.Path {
+		case "/slow":
+			// the first url is the slowest, its suggestions are still merged first.
+			time.Sleep(50 * time.Millisecond)
+			w.Write([]byte(`["` + q + `",["` + q + ` ` + lang + `","` + q + ` tour"]]`))
+		case "/fast":
+			w.Write([]byte(`["` + q + `",["` + q + ` tour","` + q + ` playground"]]`))
+		case "/broken":
+			w.Write([]byte(`<html>`))
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}))
+	defer srv.Close()
+
+	o := &openSearchCompleter{}
+	err := o.ApplyConfig(map[string]interface{}{"urls": []string{
+		srv.URL + "/slow?search={query}&lang={lang}",
+		srv.URL + "/broken?search={query}",
+		srv.URL + "/fast?search={query}",
+		srv.URL + "/failed?search={query}",
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the suggestions are merged in the order of urls without duplicates, the failed urls are ignored.
+	var got []string
+	for _, r := range o.Complete(context.Background(), "go lang", "de_DE") {
+		if r.Type != complete.TypeText {
+			t.Errorf("type = %q, want text", r.Type)
+		}
+		got = append(got, r.Text)
+	}
+	if want := []string{"go lang de", "go lang tour", "go lang playground"}; !slices.Equal(got, want) {
+		t.Errorf("suggestions = %q, want %q", got, want)
+	}
+}
+
+func TestOpenSearchCompleteRepeatedParams(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`["go",["` + strings.Join(r.URL.Query()["ns"], ",") + `"]]`))
+	}))
+	defer srv.Close()
+
+	o := &openSearchCompleter{}
+	if err := o.ApplyConfig(map[string]interface{}{"urls": []string{srv.URL + "/?search={query}&ns=0&ns=14"}}); err != nil {
+		t.Fatal(err)
+	}
+
+	// every value of the repeated params is sent.
+	res := o.Complete(context.Background(), "go", "en")
+	if len(res) != 1 || res[0].Text != "0,14" {
+		t.Errorf("suggestions = %+v, want the values of repeated params", res)
+	}
+}
+
+func TestOpenSearchApplyConfig(t *testing.T) {
+	cases := map[string]struct {
+		extra   interface{}
+		wantErr bool
+	}{
+		"urls":    {extra: map[string]interface{}{"urls": []string{"https://en.wikipedia.org/w/api.php?search={query}"}}},
+		"no urls": {extra: map[string]interface{}{"urls": []string{}}, wantErr: true},
+		"nil":     {wantErr: true},
+		"invalid": {extra: map[string]interface{}{"urls": 1}, wantErr: true},
+	}
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			if err := (&openSearchCompleter{}).ApplyConfig(c.extra); (err != nil) != c.wantErr {
+				t.Errorf("err = %v, want error %v", err, c.wantErr)
+			}
+		})
+	}
+}
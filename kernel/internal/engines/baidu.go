@@ -0,0 +1,96 @@
+package engines
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/zvirgilx/searxng-go/kernel/internal/engine"
+	"github.com/zvirgilx/searxng-go/kernel/internal/network"
+	"github.com/zvirgilx/searxng-go/kernel/internal/result"
+)
+
+const (
+	EngineNameBaidu = "baidu"
+)
+
+type baidu struct {
+	client *network.Client
+}
+
+func init() {
+	engine.RegisterGlobalEngine(&baidu{client: network.DefaultClient()}, engine.CategoryGeneral)
+}
+
+func (b *baidu) Request(ctx context.Context, opts *engine.Options) error {
+	// example: https://www.baidu.com/s?wd=test&pn=10
+	base, _ := url.Parse("https://www.baidu.com")
+	opts.Request = b.client.Get().Base(base).Path("s").
+		Param("wd", opts.Query).
+		Param("pn", strconv.Itoa((opts.PageNo-1)*10))
+	return nil
+}
+
+func (b *baidu) Response(ctx context.Context, opts *engine.Options, resp []byte) (*result.Result, error) {
+	// baidu serves some responses in GBK, which are decoded to UTF-8 by the network layer.
+	doc, err := goquery.NewDocumentFromReader(bytes.NewReader(resp))
+	if err != nil {
+		return nil, errors.New("error parsing document")
+	}
+
+	res := result.CreateResult(EngineNameBaidu, opts.PageNo)
+	doc.Find("div.result").Each(func(i int, s *goquery.Selection) {
+		a := s.Find("h3 a").First()
+		title := strings.TrimSpace(a.Text())
+		link, _ := a.Attr("href")
+		if title == "" || link == "" {
+			return
+		}
+
+		// the attribute mu is the real url of result, otherwise the redirect link is followed up by the search.
+		if mu, ok := s.Attr("mu"); ok && mu != "" {
+			link = mu
+		}
+
+		content := strings.TrimSpace(s.Find(".c-abstract, [class^=content-right]").First().Text())
+
+		res.AppendData(&result.Data{
+			Engine:  EngineNameBaidu,
+			Title:   title,
+			Url:     link,
+			Content: content,
+			Query:   opts.Query,
+		})
+	})
+
+	return res, nil
+}
+
+// FollowUpRedirect requests the location of the baidu redirect link, the link is kept if it is not resolved.
+func (b *baidu) FollowUpRedirect(opts *engine.Options, d *result.Data) *network.Request {
+	u, err := url.Parse(d.Url)
+	if err != nil || u.Host != "www.baidu.com" || u.Path != "/link" || (u.Scheme != "http" && u.Scheme != "https") {
+		return nil
+	}
+
+	base := &url.URL{Scheme: u.Scheme, Host: u.Host}
+	r := b.client.Get().Method(http.MethodHead).Base(base).Path(u.Path)
+	for k, vs := range u.Query() {
+		r.Param(k, vs...)
+	}
+	return r
+}
+
+func (b *baidu) GetName() string {
+	return EngineNameBaidu
+}
+
+func (b *baidu) ApplyConfig(conf engine.Config) error {
+	b.client = network.NewClient(conf.Client)
+	return nil
+}
@@ -0,0 +1,75 @@
+package engines
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/zvirgilx/searxng-go/kernel/internal/engine"
+	"github.com/zvirgilx/searxng-go/kernel/internal/network"
+	"github.com/zvirgilx/searxng-go/kernel/internal/result"
+)
+
+func TestBaiduResponseGBK(t *testing.T) {
+	// the fixture is served in GBK with the charset declared by <meta> only, as baidu does for some pages.
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		w.Write(readFixture(t, "baidu/search_gbk.html"))
+	}))
+	defer srv.Close()
+
+	base, _ := url.Parse(srv.URL)
+	r := network.DefaultClient().Get().Base(base).Do(context.Background())
+	if r.Err != nil {
+		t.Fatal(r.Err)
+	}
+
+	opts := engine.Options{Query: "天气预报", PageNo: 1}
+	res, err := (&baidu{client: network.DefaultClient()}).Response(context.Background(), &opts, r.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertGolden(t, "baidu/search_gbk.golden.json", res)
+
+	data := res.GetData()
+	if len(data) != 3 {
+		t.Fatalf("got %d data, want 3, the link without title is skipped", len(data))
+	}
+	if data[0].Title != "中国天气网-天气预报" {
+		t.Errorf("title is not decoded to UTF-8: %q", data[0].Title)
+	}
+	// the real url of attribute mu is preferred, the redirect link without it is kept for the follow-up.
+	if data[0].Url != "https://www.weather.com.cn/" || !strings.HasPrefix(data[1].Url, "http://www.baidu.com/link?url=GhIjKl456") {
+		t.Errorf("unexpected urls %q, %q", data[0].Url, data[1].Url)
+	}
+}
+
+func TestBaiduFollowUpRedirect(t *testing.T) {
+	b := &baidu{client: network.DefaultClient()}
+	cases := map[string]struct {
+		url  string
+		want string
+	}{
+		"redirect link":   {url: "https://www.baidu.com/link?url=GhIjKl456&wd=&eqid=e1", want: "https://www.baidu.com/link?eqid=e1&url=GhIjKl456&wd="},
+		"http link":       {url: "http://www.baidu.com/link?url=a", want: "http://www.baidu.com/link?url=a"},
+		"real url":        {url: "https://www.weather.com.cn/"},
+		"other baidu url": {url: "https://www.baidu.com/s?wd=link"},
+	}
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			req := b.FollowUpRedirect(&engine.Options{}, &result.Data{Url: c.url})
+			if c.want == "" {
+				if req != nil {
+					t.Errorf("url %s is followed up by %s", c.url, req.URL())
+				}
+				return
+			}
+			if req == nil || req.URL().String() != c.want {
+				t.Fatalf("got request %v, want %s", req, c.want)
+			}
+		})
+	}
+}
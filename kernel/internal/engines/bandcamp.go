@@ -0,0 +1,102 @@
+package engines
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/zvirgilx/searxng-go/kernel/internal/engine"
+	"github.com/zvirgilx/searxng-go/kernel/internal/network"
+	"github.com/zvirgilx/searxng-go/kernel/internal/result"
+)
+
+const (
+	EngineNameBandcamp = "bandcamp"
+)
+
+type bandcamp struct {
+	client *network.Client
+}
+
+func init() {
+	engine.RegisterGlobalEngine(&bandcamp{client: network.DefaultClient()}, engine.CategoryMusic)
+}
+
+func (b *bandcamp) Request(ctx context.Context, opts *engine.Options) error {
+	// example: https://bandcamp.com/search?q=test&page=1
+	base, _ := url.Parse("https://bandcamp.com")
+	opts.Request = b.client.Get().Base(base).Path("search").
+		Param("q", opts.Query).
+		Param("page", strconv.Itoa(opts.PageNo))
+	return nil
+}
+
+func (b *bandcamp) Response(ctx context.Context, opts *engine.Options, resp []byte) (*result.Result, error) {
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(string(resp)))
+	if err != nil {
+		return nil, errors.New("error parsing document")
+	}
+
+	res := result.CreateResult(EngineNameBandcamp, opts.PageNo)
+	doc.Find("li.searchresult").Each(func(i int, s *goquery.Selection) {
+		title := strings.TrimSpace(s.Find("div.heading a").First().Text())
+		link := bandcampCleanUrl(strings.TrimSpace(s.Find("div.itemurl a").First().Text()))
+
+		// ignore result without title or link
+		if title == "" || link == "" {
+			return
+		}
+
+		// the item type is one of ARTIST, ALBUM, TRACK, etc.
+		itemType := strings.TrimSpace(s.Find("div.itemtype").First().Text())
+		subhead := strings.Join(strings.Fields(s.Find("div.subhead").First().Text()), " ")
+
+		var parts []string
+		if itemType != "" {
+			parts = append(parts, strings.ToLower(itemType))
+		}
+		if subhead != "" {
+			parts = append(parts, subhead)
+		}
+
+		// only albums and tracks have the length, e.g. "11 tracks, 46 minutes" or "length 3:25".
+		if length := strings.Join(strings.Fields(s.Find("div.length").First().Text()), " "); length != "" {
+			parts = append(parts, strings.TrimPrefix(length, "length "))
+		}
+
+		thumbnail, _ := s.Find("div.art img").First().Attr("src")
+
+		res.AppendData(&result.Data{
+			Engine:    EngineNameBandcamp,
+			Title:     title,
+			Url:       link,
+			Content:   strings.Join(parts, " - "),
+			Thumbnail: thumbnail,
+			Query:     opts.Query,
+		})
+	})
+
+	return res, nil
+}
+
+// bandcampCleanUrl removes the search tracking parameters from the item url.
+func bandcampCleanUrl(link string) string {
+	u, err := url.Parse(link)
+	if err != nil {
+		return link
+	}
+	u.RawQuery = ""
+	return u.String()
+}
+
+func (b *bandcamp) GetName() string {
+	return EngineNameBandcamp
+}
+
+func (b *bandcamp) ApplyConfig(conf engine.Config) error {
+	b.client = network.NewClient(conf.Client)
+	return nil
+}
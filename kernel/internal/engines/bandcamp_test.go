@@ -0,0 +1,27 @@
+package engines
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/zvirgilx/searxng-go/kernel/internal/engine"
+)
+
+func TestBandcampResponse(t *testing.T) {
+	res := parseFixture(t, &bandcamp{}, engine.Options{Query: "radiohead", PageNo: 1}, "bandcamp/search.html")
+	assertGolden(t, "bandcamp/search.golden.json", res)
+
+	data := res.GetData()
+	if len(data) != 3 {
+		t.Fatalf("got %d data, want 3, the item without title is skipped", len(data))
+	}
+	// the item types distinguish the artists from the albums and tracks.
+	for i, prefix := range []string{"artist - ", "album - ", "track - "} {
+		if !strings.HasPrefix(data[i].Content, prefix) {
+			t.Errorf("content of %s = %q, want prefix %q", data[i].Title, data[i].Content, prefix)
+		}
+	}
+	if strings.Contains(data[1].Url, "search_item_id") {
+		t.Errorf("tracking params are kept in %s", data[1].Url)
+	}
+}
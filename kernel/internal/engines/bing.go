@@ -0,0 +1,170 @@
+package engines
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/zvirgilx/searxng-go/kernel/internal/engine"
+	"github.com/zvirgilx/searxng-go/kernel/internal/network"
+	"github.com/zvirgilx/searxng-go/kernel/internal/result"
+	"github.com/zvirgilx/searxng-go/kernel/internal/util"
+)
+
+const (
+	EngineNameBing = "bing"
+)
+
+var (
+	// bing does not support year time range without a custom date range.
+	bingWebTimeRangeMap = map[string]string{
+		"day":   `ex1:"ez1"`,
+		"week":  `ex1:"ez2"`,
+		"month": `ex1:"ez3"`,
+	}
+)
+
+type bing struct {
+	client *network.Client
+}
+
+func init() {
+	engine.RegisterGlobalEngine(&bing{client: network.DefaultClient()}, engine.CategoryGeneral)
+	engine.RegisterAlias("b", EngineNameBing)
+}
+
+func (b *bing) Capabilities() engine.Capabilities {
+	return engine.Capabilities{
+		Categories: []string{engine.CategoryGeneral},
+		Paging:     true,
+		// the year time range is not supported, it is searched without time range.
+		TimeRange:      true,
+		Operators:      true,
+		Verbatim:       true,
+		QueryExpansion: true,
+	}
+}
+
+func (b *bing) Request(ctx context.Context, opts *engine.Options) error {
+	// example: https://www.bing.com/search?q=test&pq=test&first=11
+	base, _ := url.Parse("https://www.bing.com")
+	// bing has no verbatim param, the quoted terms are not rewritten, the operators are kept as typed.
+	q := opts.Query
+	if opts.Verbatim {
+		q = engine.VerbatimQuery(q)
+	}
+
+	req := b.client.Get().Base(base).Path("search").
+		Param("q", q).
+		Param("pq", q)
+
+	if opts.PageNo > 1 {
+		req.Param("first", strconv.Itoa((opts.PageNo-1)*10+1))
+	}
+
+	if f, ok := bingWebTimeRangeMap[opts.TimeRange]; ok {
+		req.Param("filters", f)
+	}
+
+	opts.Request = req
+	return nil
+}
+
+func (b *bing) Response(ctx context.Context, opts *engine.Options, resp []byte) (*result.Result, error) {
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(string(resp)))
+	if err != nil {
+		return nil, errors.New("error parsing document")
+	}
+
+	res := result.CreateResult(EngineNameBing, opts.PageNo)
+	doc.Find("ol#b_results > li.b_algo").Each(func(i int, s *goquery.Selection) {
+		a := s.Find("h2 a").First()
+		title := strings.TrimSpace(a.Text())
+		link, _ := a.Attr("href")
+		if title == "" || link == "" {
+			return
+		}
+
+		content := strings.TrimSpace(s.Find(".b_caption p").First().Text())
+
+		res.AppendData(&result.Data{
+			Engine:  EngineNameBing,
+			Title:   title,
+			Url:     bingUnwrapUrl(link),
+			Content: content,
+			Query:   opts.Query,
+		})
+	})
+
+	// e.g. Including results for <a>golang</a>
+	doc.Find("#sp_requery a").Each(func(i int, s *goquery.Selection) {
+		if c := strings.TrimSpace(s.Text()); c != "" {
+			res.Corrections = append(res.Corrections, c)
+		}
+	})
+
+	// the related searches are shown in the sidebar or at the bottom of page.
+	doc.Find("div.b_rs a, .b_rich #brsv3 a").Each(func(i int, s *goquery.Selection) {
+		if sug := strings.TrimSpace(s.Text()); sug != "" {
+			util.SetAdd(res.Suggestions, sug)
+		}
+	})
+
+	return res, nil
+}
+
+// bingUnwrapUrl gets the real url from the bing redirect url.
+// The real url is encoded by base64 in the parameter u with a prefix "a1",
+// e.g. https://www.bing.com/ck/a?!&&p=abc&u=a1aHR0cHM6Ly9leGFtcGxlLmNvbS8&ntb=1 -> https://example.com/
+func bingUnwrapUrl(link string) string {
+	if !strings.HasPrefix(link, "https://www.bing.com/ck/a?") {
+		return link
+	}
+
+	u, err := url.Parse(link)
+	if err != nil {
+		return link
+	}
+
+	encoded := strings.TrimPrefix(u.Query().Get("u"), "a1")
+	decoded, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(encoded, "="))
+	if err != nil {
+		return link
+	}
+	return string(decoded)
+}
+
+// bingBlockedSelectors match the captcha and challenge pages of bing, they are responded with 200 in place of results.
+var bingBlockedSelectors = []string{
+	"#b_captcha",
+	"form[action*='/challenge/verify']",
+	"iframe[src*='/turing/captcha']",
+}
+
+func (b *bing) Blocked(body []byte) bool {
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(string(body)))
+	if err != nil {
+		return false
+	}
+	return trySelectors(doc.Selection, bingBlockedSelectors...) != nil
+}
+
+// ReverseImageURL links to the visual search page of the image,
+// e.g. https://www.bing.com/images/search?view=detailv2&iss=sbi&q=imgurl:https://example.com/cat.jpg.
+func (b *bing) ReverseImageURL(imageURL string, opts *engine.Options) string {
+	params := url.Values{"view": {"detailv2"}, "iss": {"sbi"}, "q": {"imgurl:" + imageURL}}
+	return "https://www.bing.com/images/search?" + params.Encode()
+}
+
+func (b *bing) GetName() string {
+	return EngineNameBing
+}
+
+func (b *bing) ApplyConfig(conf engine.Config) error {
+	b.client = network.NewClient(conf.Client)
+	return nil
+}
@@ -0,0 +1,119 @@
+package engines
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"github.com/zvirgilx/searxng-go/kernel/internal/engine"
+	"github.com/zvirgilx/searxng-go/kernel/internal/network"
+	"github.com/zvirgilx/searxng-go/kernel/internal/util"
+)
+
+func TestBingResponse(t *testing.T) {
+	res := parseFixture(t, &bing{}, engine.Options{Query: "golang tutoral", PageNo: 1}, "bing/search.html")
+	assertGolden(t, "bing/search.golden.json", res)
+
+	if n := res.GetDataSize(); n != 3 {
+		t.Fatalf("got %d data, want 3", n)
+	}
+	if !slices.Equal(res.Corrections, []string{"golang tutorial"}) {
+		t.Errorf("corrections = %v", res.Corrections)
+	}
+}
+
+func TestBingRelatedSearches(t *testing.T) {
+	res := parseFixture(t, &bing{}, engine.Options{Query: "golang tutoral", PageNo: 1}, "bing/search.html")
+
+	// the related searches are in the order of page, the duplicated one of bottom block is dropped.
+	want := []string{"golang tutorial for beginners", "golang tutorial pdf", "golang tutorial w3schools", "learn go online"}
+	if got := util.SetToArray[string](res.Suggestions); !slices.Equal(got, want) {
+		t.Errorf("suggestions = %v, want %v", got, want)
+	}
+}
+
+func TestBingUnwrapUrl(t *testing.T) {
+	tests := []struct {
+		link string
+		want string
+	}{
+		{"https://www.bing.com/ck/a?!&&p=abc&u=a1aHR0cHM6Ly9leGFtcGxlLmNvbS8&ntb=1", "https://example.com/"},
+		{"https://example.com/page", "https://example.com/page"},
+		// the malformed redirect is kept.
+		{"https://www.bing.com/ck/a?!&&p=abc&u=a1!!&ntb=1", "https://www.bing.com/ck/a?!&&p=abc&u=a1!!&ntb=1"},
+	}
+	for _, tt := range tests {
+		if got := bingUnwrapUrl(tt.link); got != tt.want {
+			t.Errorf("bingUnwrapUrl(%q) = %q, want %q", tt.link, got, tt.want)
+		}
+	}
+}
+
+func TestBingRequestVerbatim(t *testing.T) {
+	cases := map[string]string{
+		"golang tutorial":                     `"golang" "tutorial"`,
+		`golang site:go.dev -java "for loop"`: `"golang" site:go.dev -java "for loop"`,
+		"golang filetype:pdf":                 `"golang" filetype:pdf`,
+	}
+	for query, want := range cases {
+		opts := engine.Options{Query: query, PageNo: 1, Verbatim: true}
+		if err := (&bing{client: network.DefaultClient()}).Request(context.Background(), &opts); err != nil {
+			t.Fatal(err)
+		}
+		q := opts.Request.URL().Query()
+		if q.Get("q") != want || q.Get("pq") != want {
+			t.Errorf("query of %q = %q, want %q", query, q.Get("q"), want)
+		}
+	}
+
+	// the query is sent as typed without verbatim.
+	opts := engine.Options{Query: "golang tutorial", PageNo: 1}
+	if err := (&bing{client: network.DefaultClient()}).Request(context.Background(), &opts); err != nil {
+		t.Fatal(err)
+	}
+	if q := opts.Request.URL().Query().Get("q"); q != "golang tutorial" {
+		t.Errorf("query = %q", q)
+	}
+}
+
+func TestBingCapabilities(t *testing.T) {
+	b := &bing{client: network.DefaultClient()}
+	opts := engine.Options{Query: "golang site:go.dev", PageNo: 2, TimeRange: engine.TimeRangeDay, Locale: "de-DE", SafeSearch: engine.SafeSearchStrict}
+	if !engine.ApplyCapabilities(b, &opts) {
+		t.Fatal("the next page of bing is skipped")
+	}
+	// bing does not send the locale or safe search, they are dropped.
+	if opts.Locale != "" || opts.SafeSearch != engine.SafeSearchNone {
+		t.Errorf("the unsupported params are kept: %+v", opts)
+	}
+	if opts.Query != "golang site:go.dev" || !opts.Operators.IsEmpty() {
+		t.Errorf("the operators are stripped: %q, %+v", opts.Query, opts.Operators)
+	}
+	if err := b.Request(context.Background(), &opts); err != nil {
+		t.Fatal(err)
+	}
+	q := opts.Request.URL().Query()
+	if q.Get("first") != "11" || q.Get("filters") != `ex1:"ez1"` || q.Get("q") != "golang site:go.dev" {
+		t.Errorf("unexpected request %s", opts.Request.URL())
+	}
+	if !engine.SupportsQueryExpansion(b) {
+		t.Error("the query of bing is not expanded")
+	}
+}
+
+func TestBingReverseImageURL(t *testing.T) {
+	got := (&bing{}).ReverseImageURL("https://example.com/cat.jpg?w=1&h=2", &engine.Options{})
+	if want := "https://www.bing.com/images/search?iss=sbi&q=imgurl%3Ahttps%3A%2F%2Fexample.com%2Fcat.jpg%3Fw%3D1%26h%3D2&view=detailv2"; got != want {
+		t.Errorf("reverse image url = %s, want %s", got, want)
+	}
+}
+
+func TestBingBlocked(t *testing.T) {
+	b := &bing{client: network.DefaultClient()}
+	if !b.Blocked(readFixture(t, "bing_videos/challenge.html")) {
+		t.Error("the challenge page is not detected")
+	}
+	if b.Blocked(readFixture(t, "bing/search.html")) {
+		t.Error("the results page is detected as a challenge page")
+	}
+}
@@ -1,19 +1,22 @@
 package engines
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
-	"errors"
 	"fmt"
 	"log/slog"
 	"net/url"
 	"regexp"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/zvirgilx/searxng-go/kernel/internal/network"
 
 	"github.com/PuerkitoBio/goquery"
+	"github.com/mitchellh/mapstructure"
+	"github.com/zvirgilx/searxng-go/kernel/internal/dom"
 	"github.com/zvirgilx/searxng-go/kernel/internal/engine"
 	"github.com/zvirgilx/searxng-go/kernel/internal/result"
 )
@@ -22,21 +25,39 @@ const (
 	EngineNameBingVideos = "bing_videos"
 )
 
-var (
-	bingTimeMap = map[string]int{
-		"day":   60 * 24,
-		"week":  60 * 24 * 7,
-		"month": 60 * 24 * 31,
-		"year":  60 * 24 * 365,
-	}
+const (
+	// bingVideoDefaultPageSize is the default count of videos per page.
+	bingVideoDefaultPageSize = 10
 )
 
 type bingVideo struct {
 	client *network.Client
+
+	pageSize int
+}
+
+type BingVideosConfig struct {
+	PageSize int `mapstructure:"page_size"` // PageSize is the count of videos per page, searxng uses 35.
 }
 
 func init() {
-	engine.RegisterGlobalEngine(&bingVideo{client: network.DefaultClient()}, engine.CategoryGeneral)
+	// each category has its own instance, so that the config of one category does not overwrite the other.
+	for _, category := range []string{engine.CategoryGeneral, engine.CategoryVideo} {
+		engine.RegisterGlobalEngine(&bingVideo{client: network.DefaultClient(), pageSize: bingVideoDefaultPageSize}, category)
+	}
+}
+
+func (e *bingVideo) Capabilities() engine.Capabilities {
+	return engine.Capabilities{
+		Categories:  []string{engine.CategoryGeneral, engine.CategoryVideo},
+		Paging:      true,
+		TimeRange:   true,
+		ContentType: "text/html",
+		// the thumbnails are sometimes protocol-relative or relative to bing.
+		BaseUrl: "https://www.bing.com",
+		// the markup of videos responded to mobile browsers differs.
+		UserAgentClass: network.UserAgentClassDesktop,
+	}
 }
 
 func (e *bingVideo) Request(ctx context.Context, opts *engine.Options) error {
@@ -45,84 +66,210 @@ func (e *bingVideo) Request(ctx context.Context, opts *engine.Options) error {
 	req := e.client.Get().Base(base).Path("videos/asyncv2").
 		Param("q", opts.Query).
 		Param("async", "content").
-		Param("first", strconv.Itoa((opts.PageNo-1)*10)).
-		Param("count", "10")
+		// the offset stride must equal to the count, otherwise results are skipped or overlapped.
+		// PageNo is normalized to at least 1 before the request, so the offset is never negative.
+		Param("first", strconv.Itoa((opts.PageNo-1)*e.pageSize)).
+		Param("count", strconv.Itoa(e.pageSize))
 
 	// example: one day (60 * 24 minutes) '&qft= filterui:videoage-lt1440&form=VRFLTR'
-	if opts.TimeRange != "" {
-		req.Param("form", "VRFLTR").Param("qft", fmt.Sprintf(" filterui:videoage-lt%v", bingTimeMap[opts.TimeRange]))
+	if start, end, ok := engine.TimeRangeBounds(opts.TimeRange, time.Now()); ok {
+		req.Param("form", "VRFLTR").Param("qft", fmt.Sprintf(" filterui:videoage-lt%d", int(end.Sub(start).Minutes())))
 	}
 
 	opts.Request = req
 	return nil
 }
 
-// match available html content.
-var bingVideoRespRegex = regexp.MustCompile(`(?s)<div class="dg_u".*`)
+func (e *bingVideo) Blocked(body []byte) bool {
+	doc, err := goquery.NewDocumentFromReader(bytes.NewReader(body))
+	if err != nil {
+		return false
+	}
+	return trySelectors(doc.Selection, bingBlockedSelectors...) != nil
+}
 
-// Sometimes the html of the first page does not as same format as others.
-// So it is compatible with the parsing of the first page.
-var bingVideoFirstHTMLRespRegex = regexp.MustCompile(`(?s)<div class="mc_fgvc_u.*`)
+var bingVideoLayouts = []htmlLayout{
+	// default layout
+	{container: "div[class=dg_u]", results: "div[class=dg_u] div[id^='mc_vtvc_video']"},
+	// Sometimes the html of the first page does not as same format as others.
+	// So it is compatible with the parsing of the first page.
+	{container: "div[class^=mc_fgvc_u]", results: "div[id^=mc_vtvc__]"},
+}
 
 func (e *bingVideo) Response(ctx context.Context, opts *engine.Options, resp []byte) (*result.Result, error) {
 	log := slog.With("func", "bing_videos.Response")
 
-	body := string(resp)
-
-	// default xpath selector
-	xPath := "div[class=dg_u] div[id^='mc_vtvc_video']"
-
-	matches := bingVideoRespRegex.FindStringSubmatch(body)
-	if len(matches) == 0 {
-		matches = bingVideoFirstHTMLRespRegex.FindStringSubmatch(body)
-		if len(matches) == 0 {
-			return nil, errors.New("failed to parse bing videos html")
-		}
-
-		// compatible xpath selector
-		xPath = "div[id^=mc_vtvc__]"
-	}
-
-	doc, err := goquery.NewDocumentFromReader(strings.NewReader(matches[0]))
+	doc, err := goquery.NewDocumentFromReader(bytes.NewReader(resp))
 	if err != nil {
-		log.ErrorContext(ctx, "err", err)
+		log.ErrorContext(ctx, "failed to parse bing videos html", slog.String("err", err.Error()))
 		return nil, err
 	}
 
+	videos, ok := tryLayouts(doc.Selection, bingVideoLayouts...)
+	if !ok {
+		return nil, engine.NewParseError(opts, "failed to match bing videos html", resp)
+	}
+
 	res := result.CreateResult(EngineNameBingVideos, opts.PageNo)
-	doc.Find(xPath).Each(func(i int, s *goquery.Selection) {
-		vrhData, exists := s.Find("div.vrhdata").Attr("vrhm")
-		if !exists {
-			return
+	lang, _, _ := strings.Cut(opts.Locale, "-")
+	skips := engine.Skips{}
+	total := 0
+	videos.EachWithBreak(func(i int, s *goquery.Selection) bool {
+		// stop parsing once the maximum of results is reached or the search is canceled.
+		if opts.MaxResultsPerEngine > 0 && res.GetDataSize() >= opts.MaxResultsPerEngine {
+			return false
+		}
+		if engine.ParseCanceled(ctx, i) {
+			return false
+		}
+		total++
+
+		vrhData := dom.Attr(s, "div.vrhdata", "vrhm", "")
+		if vrhData == "" {
+			skips.Add("missing vrhm metadata")
+			return true
 		}
 
 		var metadata map[string]interface{}
 		if err = json.Unmarshal([]byte(vrhData), &metadata); err != nil {
-			return
+			skips.Add("invalid vrhm metadata")
+			return true
+		}
+
+		title, _ := engine.MetaString(metadata, "vt")
+		link, _ := engine.MetaString(metadata, "murl")
+		if title == "" || link == "" {
+			skips.Add("missing title or url")
+			return true
+		}
+
+		metaBlock := s.Find("div.mc_vtvc_meta_block")
+		thumbnail := bingVideoThumbnail(s, metadata)
+
+		// e.g. <span class="meta_vc_content">1.2M views</span><span class="meta_pd_content">2 years ago</span>
+		views := parseViewCount(dom.Text(metaBlock, "span.meta_vc_content"))
+		publishedDate := parseRelativeTime(dom.Text(metaBlock, "span.meta_pd_content"), time.Now())
+		author := dom.Text(metaBlock, "div.mc_vtvc_meta_row_channel")
+
+		// the views of content are formatted by the language, the text of bing is kept if they are not parsed,
+		// e.g. "1.2M views · 2 years ago".
+		info := dom.Text(metaBlock, "span")
+		if views > 0 {
+			info = engine.FormatViews(views, lang) + " views"
+			if age := dom.Text(metaBlock, "span.meta_pd_content"); age != "" {
+				info += " · " + age
+			}
 		}
+		duration, _ := engine.MetaString(metadata, "du")
+		content := fmt.Sprintf("%s - %s", duration, info)
 
-		info := strings.TrimSpace(s.Find("div.mc_vtvc_meta_block span").Text())
-		content := fmt.Sprintf("%s - %s", metadata["du"], info)
-		thumbnail, _ := s.Find("div.mc_vtvc_th img").Attr("src")
-
-		res.AppendData(&result.Data{
-			Engine:    EngineNameBingVideos,
-			Title:     metadata["vt"].(string),
-			Url:       metadata["murl"].(string),
-			Thumbnail: thumbnail,
-			Content:   content,
-			Query:     opts.Query,
+		// bing sometimes repeats a video in the async stream.
+		// The position counts the videos appended only, and continues from the videos of previous pages.
+		res.AppendDataUnique(&result.Data{
+			Engine:        EngineNameBingVideos,
+			Title:         title,
+			Url:           link,
+			Thumbnail:     thumbnail,
+			Content:       content,
+			Views:         views,
+			Author:        author,
+			PublishedDate: publishedDate,
+			Tags:          []string{"video"},
+			Position:      (opts.PageNo-1)*e.pageSize + res.GetDataSize() + 1,
+			Query:         opts.Query,
 		})
+		return true
 	})
+	skips.Report(res, EngineNameBingVideos, total)
 
 	return res, nil
 }
 
+// bingVideoThumbnail gets the thumbnail by a fallback chain,
+// because bing sometimes lazy-loads the thumbnail and leaves a placeholder in src.
+// The chain is src, data-src, then the thumbnail in metadata.
+func bingVideoThumbnail(s *goquery.Selection, metadata map[string]interface{}) string {
+	for _, attr := range []string{"src", "data-src"} {
+		if v := dom.Attr(s, "div.mc_vtvc_th img", attr, ""); v != "" && !strings.HasPrefix(v, "data:") {
+			return v
+		}
+	}
+	for _, key := range []string{"turl", "thumbnail"} {
+		if v, ok := engine.MetaString(metadata, key); ok && v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+// viewCountRegex matches the count of views, e.g. "1.2M views", "35K views", "870 views".
+var viewCountRegex = regexp.MustCompile(`(?i)([\d.,]+)\s*([KMB]?)`)
+
+// parseViewCount parses the count of views, 0 is returned if failed.
+func parseViewCount(text string) int64 {
+	matches := viewCountRegex.FindStringSubmatch(text)
+	if len(matches) == 0 {
+		return 0
+	}
+
+	n, err := strconv.ParseFloat(strings.ReplaceAll(matches[1], ",", ""), 64)
+	if err != nil {
+		return 0
+	}
+
+	switch strings.ToUpper(matches[2]) {
+	case "K":
+		n *= 1e3
+	case "M":
+		n *= 1e6
+	case "B":
+		n *= 1e9
+	}
+	return int64(n)
+}
+
+// relativeTimeRegex matches the relative time, e.g. "2 years ago", "3 days ago".
+var relativeTimeRegex = regexp.MustCompile(`(\d+)\s*(minute|hour|day|week|month|year)s?\s+ago`)
+
+// parseRelativeTime parses the relative time base on now, zero time is returned if failed.
+func parseRelativeTime(text string, now time.Time) time.Time {
+	matches := relativeTimeRegex.FindStringSubmatch(strings.ToLower(text))
+	if len(matches) == 0 {
+		return time.Time{}
+	}
+
+	n, _ := strconv.Atoi(matches[1])
+	switch matches[2] {
+	case "minute":
+		return now.Add(-time.Duration(n) * time.Minute)
+	case "hour":
+		return now.Add(-time.Duration(n) * time.Hour)
+	case "day":
+		return now.AddDate(0, 0, -n)
+	case "week":
+		return now.AddDate(0, 0, -7*n)
+	case "month":
+		return now.AddDate(0, -n, 0)
+	default:
+		return now.AddDate(-n, 0, 0)
+	}
+}
+
 func (e *bingVideo) GetName() string {
 	return EngineNameBingVideos
 }
 
 func (e *bingVideo) ApplyConfig(conf engine.Config) error {
 	e.client = network.NewClient(conf.Client)
+
+	var c *BingVideosConfig
+	if err := mapstructure.Decode(conf.Extra, &c); err != nil {
+		return err
+	}
+
+	e.pageSize = bingVideoDefaultPageSize
+	if c != nil && c.PageSize > 0 {
+		e.pageSize = c.PageSize
+	}
 	return nil
 }
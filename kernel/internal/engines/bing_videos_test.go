@@ -0,0 +1,309 @@
+package engines
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/zvirgilx/searxng-go/kernel/internal/engine"
+)
+
+func TestBingVideosResponseDuplicated(t *testing.T) {
+	res := parseFixture(t, &bingVideo{}, engine.Options{Query: "golang", PageNo: 1}, "bing_videos/duplicated.html")
+
+	data := res.GetData()
+	if len(data) != 2 {
+		t.Fatalf("got %d videos, want 2, the repeated video is appended once", len(data))
+	}
+	if got, want := data[0].Url, "https://www.youtube.com/watch?v=C8LgvuEBraI"; got != want {
+		t.Errorf("url of repeated video = %s, want the first variant %s", got, want)
+	}
+	if got, want := data[1].Title, "Go Tutorial for Beginners"; got != want {
+		t.Errorf("title of second video = %s, want %s", got, want)
+	}
+}
+
+func TestBingVideosPositions(t *testing.T) {
+	cases := map[string]struct {
+		fixture string
+		pageNo  int
+		want    []int
+	}{
+		// the repeated and skipped videos are not counted.
+		"duplicated": {fixture: "bing_videos/duplicated.html", pageNo: 1, want: []int{1, 2}},
+		"skipped":    {fixture: "bing_videos/skipped.html", pageNo: 1, want: []int{1}},
+		// the positions continue from the videos of previous pages.
+		"next page": {fixture: "bing_videos/metadata.html", pageNo: 3, want: []int{21, 22, 23}},
+	}
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			res := parseFixture(t, &bingVideo{pageSize: bingVideoDefaultPageSize}, engine.Options{Query: "golang", PageNo: c.pageNo}, c.fixture)
+			var got []int
+			for _, d := range res.GetData() {
+				got = append(got, d.Position)
+			}
+			if !slices.Equal(got, c.want) {
+				t.Errorf("positions = %v, want %v", got, c.want)
+			}
+		})
+	}
+}
+
+func TestBingVideosThumbnailFallback(t *testing.T) {
+	res := parseFixture(t, &bingVideo{}, engine.Options{Query: "golang", PageNo: 1}, "bing_videos/lazy_thumbnails.html")
+
+	data := res.GetData()
+	if len(data) != 3 {
+		t.Fatalf("got %d videos, want 3", len(data))
+	}
+	// the placeholder data uri of src is skipped for the lazy-loaded data-src.
+	for i, want := range []string{
+		"https://tse1.mm.bing.net/th?id=OVP.lazy1",
+		"https://tse3.mm.bing.net/th?id=OVP.meta2",
+		"https://tse1.mm.bing.net/th?id=OVP.src3",
+	} {
+		if data[i].Thumbnail != want {
+			t.Errorf("thumbnail of %s = %s, want %s", data[i].Title, data[i].Thumbnail, want)
+		}
+	}
+}
+
+func TestBingVideosMetadata(t *testing.T) {
+	res := parseFixture(t, &bingVideo{}, engine.Options{Query: "golang", PageNo: 1}, "bing_videos/metadata.html")
+
+	data := res.GetData()
+	if len(data) != 3 {
+		t.Fatalf("got %d videos, want 3", len(data))
+	}
+	if data[0].Views != 1_200_000 || data[0].Author != "ThePrimeTime" {
+		t.Errorf("views = %d, author = %q, want 1200000, ThePrimeTime", data[0].Views, data[0].Author)
+	}
+	if data[1].Views != 35_000 || data[1].Author != "" {
+		t.Errorf("views = %d, author = %q, want 35000 without author", data[1].Views, data[1].Author)
+	}
+	if data[2].Views != 0 || !data[2].PublishedDate.IsZero() {
+		t.Errorf("unexpected metadata of video without spans %+v", data[2])
+	}
+
+	// the published date is parsed relative to the time of parsing.
+	if age := time.Since(data[0].PublishedDate); age < 2*365*24*time.Hour-48*time.Hour || age > 2*366*24*time.Hour {
+		t.Errorf("published date %v is not 2 years ago", data[0].PublishedDate)
+	}
+	// the content is kept for compatibility.
+	if !strings.HasPrefix(data[0].Content, "15:42 - ") {
+		t.Errorf("content = %q, want the duration first", data[0].Content)
+	}
+	// the views of content are formatted by the language.
+	if want := "15:42 - 1.2M views · 2 years ago"; data[0].Content != want {
+		t.Errorf("content = %q, want %q", data[0].Content, want)
+	}
+	res = parseFixture(t, &bingVideo{}, engine.Options{Query: "golang", PageNo: 1, Locale: "de-DE"}, "bing_videos/metadata.html")
+	if want := "15:42 - 1,2 Mio. views · 2 years ago"; res.GetData()[0].Content != want {
+		t.Errorf("content = %q, want %q", res.GetData()[0].Content, want)
+	}
+}
+
+func TestParseViewCount(t *testing.T) {
+	tests := map[string]int64{
+		"1.2M views":  1_200_000,
+		"35K views":   35_000,
+		"870 views":   870,
+		"1,234 views": 1234,
+		"2B views":    2_000_000_000,
+		"no views":    0,
+		"":            0,
+	}
+	for text, want := range tests {
+		if got := parseViewCount(text); got != want {
+			t.Errorf("parseViewCount(%q) = %d, want %d", text, got, want)
+		}
+	}
+}
+
+func TestParseRelativeTime(t *testing.T) {
+	now := time.Date(2024, 6, 15, 12, 0, 0, 0, time.UTC)
+	tests := map[string]time.Time{
+		"5 minutes ago": now.Add(-5 * time.Minute),
+		"1 hour ago":    now.Add(-time.Hour),
+		"3 days ago":    now.AddDate(0, 0, -3),
+		"2 weeks ago":   now.AddDate(0, 0, -14),
+		"1 month ago":   now.AddDate(0, -1, 0),
+		"2 years ago":   now.AddDate(-2, 0, 0),
+		"yesterday":     {},
+	}
+	for text, want := range tests {
+		if got := parseRelativeTime(text, now); !got.Equal(want) {
+			t.Errorf("parseRelativeTime(%q) = %v, want %v", text, got, want)
+		}
+	}
+}
+
+func TestBingVideosRequestPaging(t *testing.T) {
+	for _, pageSize := range []int{10, 35} {
+		e := &bingVideo{}
+		if err := e.ApplyConfig(engine.Config{Extra: map[string]interface{}{"page_size": pageSize}}); err != nil {
+			t.Fatal(err)
+		}
+
+		for pageNo := 1; pageNo <= 3; pageNo++ {
+			opts := engine.Options{Query: "golang", PageNo: pageNo}
+			if err := e.Request(context.Background(), &opts); err != nil {
+				t.Fatal(err)
+			}
+			q := opts.Request.URL().Query()
+			// the offset stride equals to the count, so that the pages neither skip nor overlap.
+			if first, count := q.Get("first"), q.Get("count"); first != strconv.Itoa((pageNo-1)*pageSize) || count != strconv.Itoa(pageSize) {
+				t.Errorf("page size %d, page %d: first = %s, count = %s", pageSize, pageNo, first, count)
+			}
+		}
+	}
+}
+
+func TestBingVideosDefaultPageSize(t *testing.T) {
+	e := &bingVideo{}
+	if err := e.ApplyConfig(engine.Config{}); err != nil {
+		t.Fatal(err)
+	}
+	if e.pageSize != bingVideoDefaultPageSize {
+		t.Errorf("page size = %d, want %d", e.pageSize, bingVideoDefaultPageSize)
+	}
+}
+
+func TestBingVideosCapabilities(t *testing.T) {
+	caps := (&bingVideo{}).Capabilities()
+	if !caps.Paging || !caps.TimeRange || caps.SafeSearch || caps.Language {
+		t.Errorf("unexpected capabilities %+v", caps)
+	}
+	if !slices.Equal(caps.Categories, []string{engine.CategoryGeneral, engine.CategoryVideo}) {
+		t.Errorf("categories = %v", caps.Categories)
+	}
+
+	// the safe search and locale are not sent to bing videos.
+	opts := engine.Options{Query: "golang", PageNo: 2, TimeRange: engine.TimeRangeDay, SafeSearch: engine.SafeSearchStrict, Locale: "de-DE"}
+	if !engine.ApplyCapabilities(&bingVideo{}, &opts) {
+		t.Fatal("bing videos is skipped")
+	}
+	if opts.TimeRange != engine.TimeRangeDay || opts.SafeSearch != engine.SafeSearchNone || opts.Locale != "" {
+		t.Errorf("unexpected options %+v", opts)
+	}
+}
+
+func TestBingVideosInstancePerCategory(t *testing.T) {
+	general := engine.GetEnginesByCategory(engine.CategoryGeneral)[EngineNameBingVideos]
+	video := engine.GetEnginesByCategory(engine.CategoryVideo)[EngineNameBingVideos]
+	if general == nil || video == nil {
+		t.Fatal("bing videos is not registered in both categories")
+	}
+	if general == video {
+		t.Fatal("the categories share the instance of bing videos")
+	}
+
+	// the config of one category does not overwrite the other.
+	if err := general.ApplyConfig(engine.Config{Extra: map[string]interface{}{"page_size": 35}}); err != nil {
+		t.Fatal(err)
+	}
+	if err := video.ApplyConfig(engine.Config{}); err != nil {
+		t.Fatal(err)
+	}
+	if p := general.(*bingVideo).pageSize; p != 35 {
+		t.Errorf("page size of general = %d, want 35", p)
+	}
+}
+
+func TestBingVideosMaxResultsPerEngine(t *testing.T) {
+	// the parsing stops once the maximum of results is reached.
+	opts := engine.Options{Query: "golang", PageNo: 1, MaxResultsPerEngine: 2}
+	res := parseFixture(t, &bingVideo{}, opts, "bing_videos/lazy_thumbnails.html")
+	if n := res.GetDataSize(); n != 2 {
+		t.Errorf("got %d videos, want 2", n)
+	}
+}
+
+func TestBingVideosFirstPageLayout(t *testing.T) {
+	res := parseFixture(t, &bingVideo{}, engine.Options{Query: "golang", PageNo: 1}, "bing_videos/first_page.html")
+	if n := res.GetDataSize(); n != 2 {
+		t.Fatalf("got %d videos of the first page layout, want 2", n)
+	}
+}
+
+func TestBingVideosEmptyPage(t *testing.T) {
+	// the page of known layout without videos has no results rather than failed.
+	res := parseFixture(t, &bingVideo{}, engine.Options{Query: "golang", PageNo: 5}, "bing_videos/empty.html")
+	if n := res.GetDataSize(); n != 0 {
+		t.Errorf("got %d videos, want 0", n)
+	}
+
+	opts := engine.Options{Query: "golang", PageNo: 1}
+	if _, err := (&bingVideo{}).Response(context.Background(), &opts, []byte("<html><body>unknown</body></html>")); !errors.Is(err, engine.ErrParse) {
+		t.Errorf("err = %v, want a parse error of unknown layout", err)
+	}
+}
+
+func TestBingVideosMistypedMetadata(t *testing.T) {
+	// the metadata of unexpected types are not asserted, the videos without title or url are skipped.
+	res := parseFixture(t, &bingVideo{}, engine.Options{Query: "golang", PageNo: 1}, "bing_videos/mistyped_metadata.html")
+
+	data := res.GetData()
+	if len(data) != 2 {
+		t.Fatalf("got %d videos, want 2", len(data))
+	}
+	if data[0].Title != "12345" || !strings.HasPrefix(data[0].Content, "754 - ") {
+		t.Errorf("the numeric metadata are not formatted: %+v", data[0])
+	}
+	if data[1].Title != "Go with typed metadata" || data[1].Url != "https://www.youtube.com/watch?v=typed" {
+		t.Errorf("unexpected %+v", data[1])
+	}
+}
+
+func TestBingVideosBlocked(t *testing.T) {
+	e := &bingVideo{}
+	if !e.Blocked(readFixture(t, "bing_videos/challenge.html")) {
+		t.Error("the challenge page is not detected")
+	}
+	for _, name := range []string{"bing_videos/first_page.html", "bing_videos/empty.html"} {
+		if e.Blocked(readFixture(t, name)) {
+			t.Errorf("the page %s is detected as a challenge page", name)
+		}
+	}
+
+	err := engine.ValidateResponse(e, &engine.Options{Query: "golang"}, "text/html; charset=utf-8", readFixture(t, "bing_videos/challenge.html"))
+	if !errors.Is(err, engine.ErrBlocked) {
+		t.Errorf("err = %v, want blocked", err)
+	}
+}
+
+func TestBingVideosStrictParse(t *testing.T) {
+	engine.InitDebugConfig(engine.DebugConfig{StrictParse: true})
+	t.Cleanup(func() { engine.InitDebugConfig(engine.DebugConfig{}) })
+
+	res := parseFixture(t, &bingVideo{}, engine.Options{Query: "golang", PageNo: 1}, "bing_videos/skipped.html")
+	if n := res.GetDataSize(); n != 1 {
+		t.Fatalf("got %d videos, want 1", n)
+	}
+	want := []string{
+		"bing_videos: skipped 1 of 5 items, invalid vrhm metadata",
+		"bing_videos: skipped 2 of 5 items, missing title or url",
+		"bing_videos: skipped 1 of 5 items, missing vrhm metadata",
+	}
+	if !slices.Equal(res.Warnings, want) {
+		t.Errorf("warnings = %q, want %q", res.Warnings, want)
+	}
+}
+
+func TestBingVideosResponseCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	// the canceled search stops parsing rather than failing.
+	res, err := (&bingVideo{}).Response(ctx, &engine.Options{Query: "golang", PageNo: 1}, readFixture(t, "bing_videos/lazy_thumbnails.html"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n := res.GetDataSize(); n != 0 {
+		t.Errorf("got %d videos parsed after canceled", n)
+	}
+}
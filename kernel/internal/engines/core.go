@@ -0,0 +1,141 @@
+package engines
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mitchellh/mapstructure"
+	"github.com/stretchr/objx"
+	"github.com/zvirgilx/searxng-go/kernel/internal/engine"
+	"github.com/zvirgilx/searxng-go/kernel/internal/network"
+	"github.com/zvirgilx/searxng-go/kernel/internal/result"
+)
+
+const (
+	EngineNameCore = "core"
+
+	coreApiUrl   = "https://api.core.ac.uk"
+	coreHrefBase = "https://core.ac.uk/works/%d"
+	corePageSize = 10
+)
+
+// core searches open-access papers aggregated by CORE.
+type core struct {
+	client *network.Client
+
+	apiKey string
+}
+
+type CoreConfig struct {
+	ApiKey string `mapstructure:"api_key"` // ApiKey is required by CORE api, the engine is disabled without it.
+}
+
+func init() {
+	engine.RegisterGlobalEngine(&core{client: network.DefaultClient()}, engine.CategoryScience)
+}
+
+func (c *core) Request(ctx context.Context, opts *engine.Options) error {
+	// example: https://api.core.ac.uk/v3/search/works?q=test&offset=0&limit=10
+	base, _ := url.Parse(coreApiUrl)
+	opts.Request = c.client.Get().Base(base).Path("v3/search/works").
+		Header("Authorization", "Bearer "+c.apiKey).
+		Param("q", opts.Query).
+		Param("offset", strconv.Itoa((opts.PageNo-1)*corePageSize)).
+		Param("limit", strconv.Itoa(corePageSize))
+	return nil
+}
+
+func (c *core) Response(ctx context.Context, opts *engine.Options, resp []byte) (*result.Result, error) {
+	log := slog.With("func", "core.Response")
+
+	m, err := objx.FromJSON(string(resp))
+	if err != nil {
+		log.ErrorContext(ctx, "failed to parse core response", slog.String("err", err.Error()))
+		return nil, err
+	}
+	if msg := m.Get("message").Str(); msg != "" {
+		return nil, fmt.Errorf("core error: %s", msg)
+	}
+
+	res := result.CreateResult(EngineNameCore, opts.PageNo)
+	m.Get("results").EachObjxMap(func(i int, v objx.Map) bool {
+		title := v.Get("title").Str()
+		if title == "" {
+			return true
+		}
+
+		// the download url is preferred, the page of CORE is the fallback.
+		link := v.Get("downloadUrl").Str()
+		if link == "" {
+			id := v.Get("id").Int()
+			if id == 0 {
+				return true
+			}
+			link = fmt.Sprintf(coreHrefBase, id)
+		}
+
+		var authors []string
+		v.Get("authors").EachObjxMap(func(i int, a objx.Map) bool {
+			if name := a.Get("name").Str(); name != "" {
+				authors = append(authors, name)
+			}
+			return true
+		})
+		author := strings.Join(authors, ", ")
+
+		var publishedDate time.Time
+		if year := v.Get("yearPublished").Int(); year > 0 {
+			publishedDate = time.Date(year, time.January, 1, 0, 0, 0, 0, time.UTC)
+		}
+
+		var parts []string
+		if author != "" {
+			parts = append(parts, author)
+		}
+		if !publishedDate.IsZero() {
+			parts = append(parts, strconv.Itoa(publishedDate.Year()))
+		}
+		if abstract := strings.Join(strings.Fields(v.Get("abstract").Str()), " "); abstract != "" {
+			parts = append(parts, abstract)
+		}
+
+		res.AppendData(&result.Data{
+			Engine:        EngineNameCore,
+			Title:         title,
+			Url:           link,
+			Content:       strings.Join(parts, " - "),
+			Author:        author,
+			PublishedDate: publishedDate,
+			Query:         opts.Query,
+		})
+		return true
+	})
+
+	return res, nil
+}
+
+func (c *core) GetName() string {
+	return EngineNameCore
+}
+
+func (c *core) ApplyConfig(conf engine.Config) error {
+	c.client = network.NewClient(conf.Client)
+
+	var cc *CoreConfig
+	if err := mapstructure.Decode(conf.Extra, &cc); err != nil {
+		return err
+	}
+
+	// the engine disables itself without an api key.
+	if cc == nil || cc.ApiKey == "" {
+		return errors.New("api key of core is required")
+	}
+	c.apiKey = cc.ApiKey
+	return nil
+}
@@ -0,0 +1,70 @@
+package engines
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/zvirgilx/searxng-go/kernel/internal/engine"
+	"github.com/zvirgilx/searxng-go/kernel/internal/network"
+)
+
+func newTestCore(t *testing.T) *core {
+	t.Helper()
+	e := &core{}
+	if err := e.ApplyConfig(engine.Config{Client: &network.Config{}, Extra: map[string]interface{}{"api_key": "key"}}); err != nil {
+		t.Fatal(err)
+	}
+	return e
+}
+
+func TestCoreResponse(t *testing.T) {
+	res := parseFixture(t, newTestCore(t), engine.Options{Query: "attention", PageNo: 1}, "core/search.json")
+	assertGolden(t, "core/search.golden.json", res)
+
+	data := res.GetData()
+	if len(data) != 2 {
+		t.Fatalf("got %d data, want 2, the works without title or link are skipped", len(data))
+	}
+	if data[0].Url != "https://core.ac.uk/download/82503421.pdf" || data[0].Author != "Vaswani, Ashish, Shazeer, Noam" || data[0].PublishedDate.Year() != 2017 {
+		t.Errorf("unexpected data %+v", data[0])
+	}
+	if !strings.HasSuffix(data[0].Content, "complex recurrent or convolutional neural networks.") {
+		t.Errorf("the spaces of abstract are not collapsed: %q", data[0].Content)
+	}
+	// the page of CORE is the fallback of download url.
+	if data[1].Url != "https://core.ac.uk/works/1553011" || !data[1].PublishedDate.IsZero() {
+		t.Errorf("unexpected data %+v", data[1])
+	}
+}
+
+func TestCoreResponseError(t *testing.T) {
+	_, err := newTestCore(t).Response(context.Background(), &engine.Options{PageNo: 1}, []byte(`{"message":"Invalid API key"}`))
+	if err == nil || !strings.Contains(err.Error(), "Invalid API key") {
+		t.Errorf("err = %v", err)
+	}
+}
+
+func TestCoreRequestOffset(t *testing.T) {
+	e := newTestCore(t)
+	for page, offset := range map[int]string{1: "0", 2: "10", 5: "40"} {
+		opts := engine.Options{Query: "attention", PageNo: page}
+		if err := e.Request(context.Background(), &opts); err != nil {
+			t.Fatal(err)
+		}
+		q := opts.Request.URL().Query()
+		if q.Get("offset") != offset || q.Get("limit") != "10" || q.Get("q") != "attention" {
+			t.Errorf("page %d: unexpected request %s", page, opts.Request.URL())
+		}
+		// the key is sent in the header rather than the url.
+		if q.Has("api_key") || strings.Contains(opts.Request.URL().String(), "key") {
+			t.Errorf("page %d: the api key is in url %s", page, opts.Request.URL())
+		}
+	}
+}
+
+func TestCoreRequiresApiKey(t *testing.T) {
+	if err := (&core{}).ApplyConfig(engine.Config{Client: &network.Config{}}); err == nil {
+		t.Error("the engine is enabled without api key")
+	}
+}
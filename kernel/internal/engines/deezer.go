@@ -0,0 +1,101 @@
+package engines
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/stretchr/objx"
+	"github.com/zvirgilx/searxng-go/kernel/internal/engine"
+	"github.com/zvirgilx/searxng-go/kernel/internal/network"
+	"github.com/zvirgilx/searxng-go/kernel/internal/result"
+)
+
+const (
+	EngineNameDeezer = "deezer"
+
+	deezerApiUrl   = "https://api.deezer.com"
+	deezerPageSize = 25
+)
+
+type deezer struct {
+	client *network.Client
+}
+
+func init() {
+	engine.RegisterGlobalEngine(&deezer{client: network.DefaultClient()}, engine.CategoryMusic)
+}
+
+func (d *deezer) Request(ctx context.Context, opts *engine.Options) error {
+	// example: https://api.deezer.com/search?q=test&index=0&limit=25
+	base, _ := url.Parse(deezerApiUrl)
+	opts.Request = d.client.Get().Base(base).Path("search").
+		Param("q", opts.Query).
+		Param("index", strconv.Itoa((opts.PageNo-1)*deezerPageSize)).
+		Param("limit", strconv.Itoa(deezerPageSize))
+	return nil
+}
+
+func (d *deezer) Response(ctx context.Context, opts *engine.Options, resp []byte) (*result.Result, error) {
+	log := slog.With("func", "deezer.Response")
+
+	m, err := objx.FromJSON(string(resp))
+	if err != nil {
+		log.ErrorContext(ctx, "failed to parse deezer response", slog.String("err", err.Error()))
+		return nil, err
+	}
+	// e.g. {"error":{"type":"Exception","message":"Quota limit exceeded","code":4}}
+	if msg := m.Get("error.message").Str(); msg != "" {
+		return nil, fmt.Errorf("deezer error: %s", msg)
+	}
+
+	res := result.CreateResult(EngineNameDeezer, opts.PageNo)
+	m.Get("data").EachObjxMap(func(i int, v objx.Map) bool {
+		title := v.Get("title").Str()
+		link := v.Get("link").Str()
+		if title == "" || link == "" {
+			return true
+		}
+
+		artist := v.Get("artist.name").Str()
+		album := v.Get("album.title").Str()
+		duration := v.Get("duration").Int()
+
+		var parts []string
+		for _, p := range []string{artist, album} {
+			if p != "" {
+				parts = append(parts, p)
+			}
+		}
+		if duration > 0 {
+			parts = append(parts, fmt.Sprintf("%d:%02d", duration/60, duration%60))
+		}
+
+		res.AppendData(&result.Data{
+			Engine:          EngineNameDeezer,
+			Title:           title,
+			Url:             link,
+			Content:         strings.Join(parts, " - "),
+			Thumbnail:       v.Get("album." + engine.ThumbnailToken(opts, "cover_small", "cover_medium", "cover_big")).Str(),
+			Author:          artist,
+			DurationSeconds: duration,
+			PreviewUrl:      v.Get("preview").Str(),
+			Query:           opts.Query,
+		})
+		return true
+	})
+
+	return res, nil
+}
+
+func (d *deezer) GetName() string {
+	return EngineNameDeezer
+}
+
+func (d *deezer) ApplyConfig(conf engine.Config) error {
+	d.client = network.NewClient(conf.Client)
+	return nil
+}
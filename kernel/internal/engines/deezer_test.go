@@ -0,0 +1,76 @@
+package engines
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/zvirgilx/searxng-go/kernel/internal/engine"
+	"github.com/zvirgilx/searxng-go/kernel/internal/network"
+)
+
+func newTestDeezer(t *testing.T) *deezer {
+	t.Helper()
+	e := &deezer{}
+	if err := e.ApplyConfig(engine.Config{Client: &network.Config{}}); err != nil {
+		t.Fatal(err)
+	}
+	return e
+}
+
+func TestDeezerResponse(t *testing.T) {
+	res := parseFixture(t, newTestDeezer(t), engine.Options{Query: "daft punk", PageNo: 1}, "deezer/search.json")
+	assertGolden(t, "deezer/search.golden.json", res)
+
+	data := res.GetData()
+	if len(data) != 2 {
+		t.Fatalf("got %d data, want 2, the track without title is skipped", len(data))
+	}
+	if data[0].DurationSeconds != 224 || data[0].Content != "Daft Punk - Discovery - 3:44" {
+		t.Errorf("duration = %d, content = %q", data[0].DurationSeconds, data[0].Content)
+	}
+	if data[0].Author != "Daft Punk" || !strings.HasSuffix(data[0].PreviewUrl, ".mp3") {
+		t.Errorf("author = %q, preview = %q", data[0].Author, data[0].PreviewUrl)
+	}
+	// the unknown duration and empty album are left out.
+	if data[1].DurationSeconds != 0 || data[1].Content != "Daft Punk" {
+		t.Errorf("duration = %d, content = %q", data[1].DurationSeconds, data[1].Content)
+	}
+}
+
+func TestDeezerResponseError(t *testing.T) {
+	_, err := newTestDeezer(t).Response(context.Background(), &engine.Options{PageNo: 1},
+		[]byte(`{"error":{"type":"Exception","message":"Quota limit exceeded","code":4}}`))
+	if err == nil || !strings.Contains(err.Error(), "Quota limit exceeded") {
+		t.Errorf("err = %v", err)
+	}
+}
+
+func TestDeezerRequestPaging(t *testing.T) {
+	e := newTestDeezer(t)
+	for page, index := range map[int]string{1: "0", 2: "25", 3: "50"} {
+		opts := engine.Options{Query: "daft punk", PageNo: page}
+		if err := e.Request(context.Background(), &opts); err != nil {
+			t.Fatal(err)
+		}
+		q := opts.Request.URL().Query()
+		if q.Get("index") != index || q.Get("limit") != "25" || q.Get("q") != "daft punk" {
+			t.Errorf("page %d: unexpected request %s", page, opts.Request.URL())
+		}
+	}
+}
+
+func TestDeezerThumbnailSize(t *testing.T) {
+	cases := map[string]string{
+		engine.ThumbnailSizeSmall: "/56x56-",
+		"":                        "/250x250-",
+		engine.ThumbnailSizeLarge: "/500x500-",
+	}
+	for size, want := range cases {
+		opts := engine.Options{Query: "daft punk", PageNo: 1, ThumbnailSize: size}
+		res := parseFixture(t, newTestDeezer(t), opts, "deezer/search.json")
+		if got := res.GetData()[0].Thumbnail; !strings.Contains(got, want) {
+			t.Errorf("thumbnail of size %q = %s, want the size %s", size, got, want)
+		}
+	}
+}
@@ -0,0 +1,143 @@
+package engines
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mitchellh/mapstructure"
+	"github.com/stretchr/objx"
+	"github.com/zvirgilx/searxng-go/kernel/internal/engine"
+	"github.com/zvirgilx/searxng-go/kernel/internal/network"
+	"github.com/zvirgilx/searxng-go/kernel/internal/result"
+)
+
+const (
+	EngineNameDiscourse = "discourse"
+)
+
+// discourse searches the topics of a discourse forum by its search api,
+// the excerpt of topic is the blurb of its first matched post.
+type discourse struct {
+	client *network.Client
+
+	baseUrl     string
+	apiKey      string
+	apiUsername string
+}
+
+type DiscourseConfig struct {
+	BaseUrl     string `mapstructure:"base_url"`     // BaseUrl is the url of discourse forum, e.g. https://meta.discourse.org.
+	ApiKey      string `mapstructure:"api_key"`      // ApiKey is the optional api key, private forums require it.
+	ApiUsername string `mapstructure:"api_username"` // ApiUsername is the user of api key.
+}
+
+func init() {
+	engine.RegisterGlobalEngine(&discourse{client: network.DefaultClient(), baseUrl: "https://meta.discourse.org"}, engine.CategorySocial)
+}
+
+func (d *discourse) Capabilities() engine.Capabilities {
+	return engine.Capabilities{
+		Categories:  []string{engine.CategorySocial},
+		Paging:      true,
+		ContentType: "application/json",
+	}
+}
+
+func (d *discourse) Request(ctx context.Context, opts *engine.Options) error {
+	base, err := url.Parse(d.baseUrl)
+	if err != nil {
+		return err
+	}
+
+	// example: https://meta.discourse.org/search.json?q=test&page=1
+	req := d.client.Get().Base(base).Path("search.json").
+		Param("q", opts.Query).
+		Param("page", strconv.Itoa(opts.PageNo)).
+		Header("Accept", "application/json")
+	if d.apiKey != "" {
+		req.Header("Api-Key", d.apiKey).Header("Api-Username", d.apiUsername)
+	}
+
+	opts.Request = req
+	return nil
+}
+
+func (d *discourse) Response(ctx context.Context, opts *engine.Options, resp []byte) (*result.Result, error) {
+	log := slog.With("func", "discourse.Response")
+
+	m, err := objx.FromJSON(string(resp))
+	if err != nil {
+		log.ErrorContext(ctx, "failed to parse discourse response", slog.String("err", err.Error()))
+		return nil, err
+	}
+
+	// the posts are ordered by relevance, the first post of each topic is its excerpt.
+	blurbs := map[int]string{}
+	authors := map[int]string{}
+	m.Get("posts").EachObjxMap(func(i int, v objx.Map) bool {
+		topicId := v.Get("topic_id").Int()
+		if _, ok := blurbs[topicId]; !ok {
+			blurbs[topicId] = strings.TrimSpace(v.Get("blurb").Str())
+			authors[topicId] = v.Get("username").Str()
+		}
+		return true
+	})
+
+	res := result.CreateResult(EngineNameDiscourse, opts.PageNo)
+	m.Get("topics").EachObjxMap(func(i int, v objx.Map) bool {
+		id := v.Get("id").Int()
+		title := v.Get("fancy_title").Str(v.Get("title").Str())
+		if id == 0 || title == "" {
+			return true
+		}
+
+		// e.g. https://meta.discourse.org/t/some-topic/12345, the topics are found by id whatever the slug is.
+		slug := v.Get("slug").Str()
+		if slug == "" {
+			slug = "topic"
+		}
+		link := fmt.Sprintf("%s/t/%s/%d", strings.TrimSuffix(d.baseUrl, "/"), slug, id)
+		publishedDate, _ := time.Parse(time.RFC3339, v.Get("created_at").Str())
+
+		res.AppendData(&result.Data{
+			Engine:        EngineNameDiscourse,
+			Title:         htmlToText(title),
+			Url:           link,
+			Content:       blurbs[id],
+			Author:        authors[id],
+			PublishedDate: publishedDate,
+			Query:         opts.Query,
+		})
+		return true
+	})
+
+	return res, nil
+}
+
+func (d *discourse) GetName() string {
+	return EngineNameDiscourse
+}
+
+func (d *discourse) ApplyConfig(conf engine.Config) error {
+	d.client = network.NewClient(conf.Client)
+
+	var c *DiscourseConfig
+	if err := mapstructure.Decode(conf.Extra, &c); err != nil {
+		return err
+	}
+	if c == nil {
+		return nil
+	}
+
+	if c.BaseUrl != "" {
+		d.baseUrl = c.BaseUrl
+	}
+	d.apiKey = c.ApiKey
+	d.apiUsername = c.ApiUsername
+	return nil
+}
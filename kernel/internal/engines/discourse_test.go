@@ -0,0 +1,103 @@
+package engines
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/zvirgilx/searxng-go/kernel/internal/engine"
+	"github.com/zvirgilx/searxng-go/kernel/internal/network"
+)
+
+func newTestDiscourse(t *testing.T, extra map[string]interface{}) *discourse {
+	t.Helper()
+	d := &discourse{baseUrl: "https://meta.discourse.org"}
+	if err := d.ApplyConfig(engine.Config{Client: &network.Config{}, Extra: extra}); err != nil {
+		t.Fatal(err)
+	}
+	return d
+}
+
+func TestDiscourseResponse(t *testing.T) {
+	res := parseFixture(t, newTestDiscourse(t, nil), engine.Options{Query: "go", PageNo: 1}, "discourse/search.json")
+	assertGolden(t, "discourse/search.golden.json", res)
+
+	data := res.GetData()
+	if len(data) != 2 {
+		t.Fatalf("got %d data, want 2, the topics without id or title are skipped", len(data))
+	}
+	// the fancy title is unescaped, the blurb of first post of topic is its content.
+	if data[0].Title != "How to install go & set <GOPATH>" || data[0].Url != "https://meta.discourse.org/t/how-to-install-go/12345" {
+		t.Errorf("title = %q, url = %q", data[0].Title, data[0].Url)
+	}
+	if data[0].Content != "The best way to install go on ubuntu is the official tarball." || data[0].Author != "gopher" {
+		t.Errorf("content = %q, author = %q", data[0].Content, data[0].Author)
+	}
+	if want := time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC); !data[0].PublishedDate.Equal(want) {
+		t.Errorf("published date = %v, want %v", data[0].PublishedDate, want)
+	}
+	// the title is used without fancy title, the topic without slug is linked by id, the invalid date is left out.
+	if data[1].Title != "Generics in go" || data[1].Url != "https://meta.discourse.org/t/topic/23456" || !data[1].PublishedDate.IsZero() {
+		t.Errorf("unexpected data %+v", data[1])
+	}
+}
+
+func TestDiscourseResponseInvalid(t *testing.T) {
+	if _, err := newTestDiscourse(t, nil).Response(context.Background(), &engine.Options{PageNo: 1}, []byte("<html>")); err == nil {
+		t.Error("the invalid json is parsed")
+	}
+}
+
+func TestDiscourseRequest(t *testing.T) {
+	var header http.Header
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		header = r.Header.Clone()
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{}`))
+	}))
+	defer srv.Close()
+
+	d := newTestDiscourse(t, map[string]interface{}{"base_url": srv.URL + "/", "api_key": "secret", "api_username": "system"})
+	opts := engine.Options{Query: "go", PageNo: 2}
+	if err := d.Request(context.Background(), &opts); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := opts.Request.URL().String(), srv.URL+"/search.json?page=2&q=go"; got != want {
+		t.Errorf("url = %s, want %s", got, want)
+	}
+	if r := opts.Request.Do(context.Background()); r.Err != nil {
+		t.Fatal(r.Err)
+	}
+	if header.Get("Api-Key") != "secret" || header.Get("Api-Username") != "system" || header.Get("Accept") != "application/json" {
+		t.Errorf("unexpected headers %v", header)
+	}
+
+	// the trailing slash of base url is trimmed from the links of topics.
+	res := parseFixture(t, d, engine.Options{Query: "go", PageNo: 1}, "discourse/search.json")
+	if got, want := res.GetData()[0].Url, srv.URL+"/t/how-to-install-go/12345"; got != want {
+		t.Errorf("url = %s, want %s", got, want)
+	}
+}
+
+func TestDiscourseRequestPublic(t *testing.T) {
+	var header http.Header
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		header = r.Header.Clone()
+		w.Write([]byte(`{}`))
+	}))
+	defer srv.Close()
+
+	d := newTestDiscourse(t, map[string]interface{}{"base_url": srv.URL})
+	opts := engine.Options{Query: "go", PageNo: 1}
+	if err := d.Request(context.Background(), &opts); err != nil {
+		t.Fatal(err)
+	}
+	if r := opts.Request.Do(context.Background()); r.Err != nil {
+		t.Fatal(r.Err)
+	}
+	if _, ok := header["Api-Key"]; ok {
+		t.Errorf("the api key is sent without config: %v", header)
+	}
+}
@@ -0,0 +1,119 @@
+package engines
+
+import (
+	"context"
+	"log/slog"
+	"net/url"
+	"strings"
+
+	"github.com/stretchr/objx"
+	"github.com/zvirgilx/searxng-go/kernel/internal/engine"
+	"github.com/zvirgilx/searxng-go/kernel/internal/network"
+	"github.com/zvirgilx/searxng-go/kernel/internal/result"
+)
+
+const (
+	EngineNameDuckDuckGoAnswer = "duckduckgo_answer"
+
+	duckDuckGoBaseUrl = "https://duckduckgo.com"
+	duckDuckGoApiUrl  = "https://api.duckduckgo.com"
+
+	// duckDuckGoMaxRelated is the count of related topics shown in the infobox.
+	duckDuckGoMaxRelated = 5
+)
+
+// duckDuckGoAnswer gets the instant answer of query by the duckduckgo instant answer api,
+// the answer and definition are answers, and the abstract is the infobox.
+type duckDuckGoAnswer struct {
+	client *network.Client
+}
+
+func init() {
+	engine.RegisterGlobalEngine(&duckDuckGoAnswer{client: network.DefaultClient()}, engine.CategoryGeneral)
+	engine.RegisterAlias("ddg", EngineNameDuckDuckGoAnswer)
+}
+
+func (d *duckDuckGoAnswer) Capabilities() engine.Capabilities {
+	return engine.Capabilities{
+		Categories: []string{engine.CategoryGeneral},
+	}
+}
+
+func (d *duckDuckGoAnswer) Request(ctx context.Context, opts *engine.Options) error {
+	// example: https://api.duckduckgo.com/?q=test&format=json&no_html=1&skip_disambig=1&no_redirect=1
+	base, _ := url.Parse(duckDuckGoApiUrl)
+	opts.Request = d.client.Get().Base(base).Path("/").
+		Param("q", opts.Query).
+		Param("format", "json").
+		Param("no_html", "1").
+		Param("skip_disambig", "1").
+		Param("no_redirect", "1")
+	return nil
+}
+
+func (d *duckDuckGoAnswer) Response(ctx context.Context, opts *engine.Options, resp []byte) (*result.Result, error) {
+	log := slog.With("func", "duckduckgo_answer.Response")
+
+	m, err := objx.FromJSON(string(resp))
+	if err != nil {
+		log.ErrorContext(ctx, "failed to parse duckduckgo answer response", slog.String("err", err.Error()))
+		return nil, err
+	}
+
+	res := result.CreateResult(EngineNameDuckDuckGoAnswer, opts.PageNo)
+
+	// the answer of calculators is a number.
+	if answer := strings.TrimSpace(m.Get("Answer").String()); answer != "" {
+		res.Answers = append(res.Answers, result.Answer{Answer: answer, Engine: EngineNameDuckDuckGoAnswer})
+	}
+	if definition := strings.TrimSpace(m.Get("Definition").Str()); definition != "" {
+		res.Answers = append(res.Answers, result.Answer{
+			Answer: definition,
+			Url:    m.Get("DefinitionURL").Str(),
+			Engine: EngineNameDuckDuckGoAnswer,
+		})
+	}
+
+	if abstract := strings.TrimSpace(m.Get("AbstractText").Str()); abstract != "" {
+		link := m.Get("AbstractURL").Str()
+		urlList := []map[string]string{{"title": m.Get("AbstractSource").Str(), "url": link}}
+		m.Get("RelatedTopics").EachObjxMap(func(i int, v objx.Map) bool {
+			// the grouped topics have no url, they are skipped.
+			if u := v.Get("FirstURL").Str(); u != "" {
+				urlList = append(urlList, map[string]string{"title": v.Get("Text").Str(), "url": u})
+			}
+			return len(urlList) <= duckDuckGoMaxRelated
+		})
+
+		res.InfoBox = &result.InfoBox{
+			Title:   m.Get("Heading").Str(),
+			Content: abstract,
+			ImgSrc:  duckDuckGoImage(m.Get("Image").Str()),
+			Url:     link,
+			UrlList: urlList,
+		}
+	}
+
+	// queries without instant answer get nothing.
+	if len(res.Answers) == 0 && res.InfoBox == nil {
+		return nil, nil
+	}
+	return res, nil
+}
+
+// duckDuckGoImage resolves the image of abstract, which is relative to duckduckgo, e.g. /i/golang.png.
+func duckDuckGoImage(img string) string {
+	if img == "" || strings.HasPrefix(img, "http") {
+		return img
+	}
+	return duckDuckGoBaseUrl + img
+}
+
+func (d *duckDuckGoAnswer) GetName() string {
+	return EngineNameDuckDuckGoAnswer
+}
+
+func (d *duckDuckGoAnswer) ApplyConfig(conf engine.Config) error {
+	d.client = network.NewClient(conf.Client)
+	return nil
+}
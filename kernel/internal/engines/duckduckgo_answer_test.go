@@ -0,0 +1,80 @@
+package engines
+
+import (
+	"context"
+	"testing"
+
+	"github.com/zvirgilx/searxng-go/kernel/internal/engine"
+	"github.com/zvirgilx/searxng-go/kernel/internal/network"
+)
+
+func newTestDuckDuckGoAnswer(t *testing.T) *duckDuckGoAnswer {
+	t.Helper()
+	e := &duckDuckGoAnswer{}
+	if err := e.ApplyConfig(engine.Config{Client: &network.Config{}}); err != nil {
+		t.Fatal(err)
+	}
+	return e
+}
+
+func TestDuckDuckGoAnswerResponse(t *testing.T) {
+	res := parseFixture(t, newTestDuckDuckGoAnswer(t), engine.Options{Query: "golang", PageNo: 1}, "duckduckgo_answer/golang.json")
+
+	if len(res.Answers) != 1 {
+		t.Fatalf("got %d answers, want the definition", len(res.Answers))
+	}
+	if a := res.Answers[0]; a.Answer != "A programming language created at Google." || a.Url != "https://en.wiktionary.org/wiki/Go" {
+		t.Errorf("unexpected definition %+v", a)
+	}
+
+	box := res.InfoBox
+	if box == nil {
+		t.Fatal("the abstract is not the infobox")
+	}
+	if box.Title != "Go (programming language)" || box.Url != "https://en.wikipedia.org/wiki/Go_(programming_language)" {
+		t.Errorf("title = %q, url = %q", box.Title, box.Url)
+	}
+	if box.ImgSrc != "https://duckduckgo.com/i/a3b2f5a8.png" {
+		t.Errorf("the relative image is not resolved, got %q", box.ImgSrc)
+	}
+	// the source and the related topics up to the max, the grouped topic is skipped.
+	if len(box.UrlList) != duckDuckGoMaxRelated+1 {
+		t.Fatalf("got %d urls, want %d", len(box.UrlList), duckDuckGoMaxRelated+1)
+	}
+	if box.UrlList[0]["title"] != "Wikipedia" || box.UrlList[2]["url"] != "https://duckduckgo.com/Ken_Thompson" {
+		t.Errorf("unexpected urls %v", box.UrlList)
+	}
+}
+
+func TestDuckDuckGoAnswerCalculator(t *testing.T) {
+	res, err := newTestDuckDuckGoAnswer(t).Response(context.Background(), &engine.Options{PageNo: 1},
+		[]byte(`{"Answer": 42, "AnswerType": "calc", "AbstractText": "", "RelatedTopics": []}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res == nil || len(res.Answers) != 1 || res.Answers[0].Answer != "42" {
+		t.Fatalf("the numeric answer is not kept, got %+v", res)
+	}
+	if res.InfoBox != nil {
+		t.Errorf("got infobox %+v without abstract", res.InfoBox)
+	}
+}
+
+func TestDuckDuckGoAnswerEmpty(t *testing.T) {
+	res, err := newTestDuckDuckGoAnswer(t).Response(context.Background(), &engine.Options{PageNo: 1},
+		[]byte(`{"Answer": "", "Definition": "", "AbstractText": "", "RelatedTopics": []}`))
+	if err != nil || res != nil {
+		t.Errorf("got %+v, %v, want nothing without instant answer", res, err)
+	}
+}
+
+func TestDuckDuckGoAnswerRequest(t *testing.T) {
+	opts := engine.Options{Query: "1+1", PageNo: 1}
+	if err := newTestDuckDuckGoAnswer(t).Request(context.Background(), &opts); err != nil {
+		t.Fatal(err)
+	}
+	q := opts.Request.URL().Query()
+	if q.Get("q") != "1+1" || q.Get("format") != "json" || q.Get("no_html") != "1" {
+		t.Errorf("unexpected request %s", opts.Request.URL())
+	}
+}
@@ -66,7 +66,7 @@ func (e *elasticSearch) Response(ctx context.Context, opts *engine.Options, resp
 	log.DebugContext(ctx, "response", "resp", string(resp))
 	m, err := objx.FromJSON(string(resp))
 	if err != nil {
-		log.ErrorContext(ctx, "err", err)
+		log.ErrorContext(ctx, "failed to parse elastic search response", slog.String("err", err.Error()))
 		return nil, err
 	}
 
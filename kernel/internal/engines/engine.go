@@ -1,32 +1,116 @@
 package engines
 
 import (
+	"context"
 	"log/slog"
+	"time"
 
 	"github.com/zvirgilx/searxng-go/kernel/internal/engine"
+	"github.com/zvirgilx/searxng-go/kernel/internal/network"
+	"github.com/zvirgilx/searxng-go/kernel/internal/util"
 )
 
+// genericEngines create engines by configuration only, the key is the type of engine config.
+var genericEngines = map[string]func(name string) engine.Engine{}
+
+func registerGenericEngine(engineType string, create func(name string) engine.Engine) {
+	genericEngines[engineType] = create
+}
+
 func InitConfiguration(configuration map[string]map[string]engine.Config) {
 	configuredEngines := map[string]map[string]engine.Engine{}
+	warmed := map[engine.Engine]error{}
+	minQueryLens := map[string]int{}
 
 	for category, configMap := range configuration {
 		engines := engine.GetEnginesByCategory(category)
-		if len(engines) == 0 {
-			continue
-		}
 		for name, conf := range configMap {
 			if !conf.Enable {
 				continue
 			}
-			if e, ok := engines[name]; ok {
-				if err := e.ApplyConfig(conf); err != nil {
-					slog.Error("failed to init configuration", slog.String("engineName", name), slog.String("error", err.Error()))
+
+			e, ok := engines[name]
+			if conf.Type != "" {
+				var create func(name string) engine.Engine
+				if create, ok = genericEngines[conf.Type]; !ok {
+					slog.Warn("unknown engine type", slog.String("engineName", name), slog.String("type", conf.Type))
 					continue
 				}
-				engine.RegisterTo(configuredEngines, e, category)
+				e = create(name)
+			}
+			if !ok {
+				continue
+			}
+
+			// the engine name is the key of proxy assignment, so that the session of engine stays on one proxy.
+			client := network.Config{}
+			if conf.Client != nil {
+				client = *conf.Client
+			}
+			client.PoolKey = name
+			// the preferred user agents of engine are used unless configured.
+			if ce, ok := e.(engine.CapableEngine); ok && client.UserAgentClass == "" {
+				client.UserAgentClass = ce.Capabilities().UserAgentClass
+			}
+			if client.UserAgentClass != "" && !network.IsUserAgentClass(client.UserAgentClass) {
+				slog.Warn("unknown user agent class", slog.String("engineName", name), slog.String("class", client.UserAgentClass))
+			}
+			conf.Client = &client
+
+			if err := e.ApplyConfig(conf); err != nil {
+				slog.Error("failed to init configuration", slog.String("engineName", name), slog.String("error", err.Error()))
+				continue
+			}
+
+			// the engine is disabled if warmup failed.
+			if err := warmup(e, conf.WarmupInterval, warmed); err != nil {
+				slog.Error("failed to warmup engine", slog.String("engineName", name), slog.String("error", err.Error()))
+				continue
 			}
+			if conf.MinQueryLen > 0 {
+				minQueryLens[name] = conf.MinQueryLen
+			}
+			engine.RegisterTo(configuredEngines, e, category)
 		}
 	}
 
+	engine.SetMinQueryLens(minQueryLens)
 	engine.SetGlobalEngines(configuredEngines)
 }
+
+// warmupTimeout is the timeout of each warmup.
+const warmupTimeout = 10 * time.Second
+
+// warmup calls the warmup of engine at startup, then refreshes it on schedule if interval is set.
+// An engine registered in multiple categories is warmed up only once, the result is recorded in warmed.
+func warmup(e engine.Engine, interval time.Duration, warmed map[engine.Engine]error) error {
+	we, ok := e.(engine.WarmupEngine)
+	if !ok {
+		return nil
+	}
+	if err, ok := warmed[e]; ok {
+		return err
+	}
+
+	doWarmup := func() error {
+		ctx, cancel := context.WithTimeout(context.Background(), warmupTimeout)
+		defer cancel()
+		return we.Warmup(ctx)
+	}
+
+	err := doWarmup()
+	warmed[e] = err
+	if err != nil || interval <= 0 {
+		return err
+	}
+
+	go func() {
+		defer util.RecoverFromPanic()
+		for range time.Tick(interval) {
+			if err := doWarmup(); err != nil {
+				slog.Warn("failed to refresh engine warmup", slog.String("engineName", e.GetName()), slog.String("error", err.Error()))
+			}
+		}
+	}()
+	return nil
+}
@@ -0,0 +1,133 @@
+package engines
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/zvirgilx/searxng-go/kernel/internal/engine"
+	"github.com/zvirgilx/searxng-go/kernel/internal/network"
+	"github.com/zvirgilx/searxng-go/kernel/internal/result"
+)
+
+// categories are the categories of registered engines restored after the tests replacing them.
+var categories = []string{
+	engine.CategoryGeneral, engine.CategoryVideo, engine.CategoryMusic, engine.CategoryImage, engine.CategoryNews,
+	engine.CategorySocial, engine.CategoryScience, engine.CategoryBooks, engine.CategoryMovies, engine.CategoryIT,
+}
+
+// withGlobalEngines replaces the global engines during the test.
+func withGlobalEngines(t *testing.T, engines map[string]map[string]engine.Engine) {
+	t.Helper()
+	saved := map[string]map[string]engine.Engine{}
+	for _, c := range categories {
+		if es := engine.GetEnginesByCategory(c); es != nil {
+			saved[c] = es
+		}
+	}
+	engine.SetGlobalEngines(engines)
+	t.Cleanup(func() { engine.SetGlobalEngines(saved) })
+}
+
+// tokenEngine fetches the token by warmup, which is sent by the subsequent requests.
+type tokenEngine struct {
+	name  string
+	err   error
+	token string
+	calls int
+}
+
+func (e *tokenEngine) Warmup(ctx context.Context) error {
+	e.calls++
+	if e.err != nil {
+		return e.err
+	}
+	e.token = "token-1"
+	return nil
+}
+
+func (e *tokenEngine) Request(ctx context.Context, opts *engine.Options) error {
+	opts.Request = network.DefaultClient().Get().Path("/search").Param("q", opts.Query).Param("token", e.token)
+	return nil
+}
+
+func (e *tokenEngine) Response(context.Context, *engine.Options, []byte) (*result.Result, error) {
+	return nil, nil
+}
+
+func (e *tokenEngine) GetName() string { return e.name }
+
+func (e *tokenEngine) ApplyConfig(engine.Config) error { return nil }
+
+func TestInitConfigurationWarmup(t *testing.T) {
+	ok := &tokenEngine{name: "ok"}
+	failed := &tokenEngine{name: "failed", err: errors.New("token page unavailable")}
+	withGlobalEngines(t, map[string]map[string]engine.Engine{
+		engine.CategoryGeneral: {"ok": ok, "failed": failed},
+		engine.CategoryVideo:   {"ok": ok},
+	})
+
+	InitConfiguration(map[string]map[string]engine.Config{
+		engine.CategoryGeneral: {"ok": {Enable: true}, "failed": {Enable: true}},
+		engine.CategoryVideo:   {"ok": {Enable: true}},
+	})
+
+	// the engine registered in several categories is warmed up once.
+	if ok.calls != 1 || failed.calls != 1 {
+		t.Errorf("warmup calls = %d, %d, want 1", ok.calls, failed.calls)
+	}
+
+	// the token of warmup is used by the subsequent request.
+	opts := engine.Options{Query: "go", PageNo: 1}
+	if err := ok.Request(context.Background(), &opts); err != nil {
+		t.Fatal(err)
+	}
+	if token := opts.Request.URL().Query().Get("token"); token != "token-1" {
+		t.Errorf("token = %q, want the token of warmup", token)
+	}
+
+	// the engine failed to warmup is disabled, the others are unaffected.
+	general := engine.GetEnginesByCategory(engine.CategoryGeneral)
+	if general["ok"] == nil || general["failed"] != nil {
+		t.Errorf("engines of general = %v, want ok only", general)
+	}
+	if engine.GetEnginesByCategory(engine.CategoryVideo)["ok"] == nil {
+		t.Error("the warmed engine is not enabled in video")
+	}
+}
+
+// clientEngine records the client config applied to it, its capabilities prefer the mobile user agents.
+type clientEngine struct {
+	tokenEngine
+	client *network.Config
+}
+
+func (e *clientEngine) Capabilities() engine.Capabilities {
+	return engine.Capabilities{UserAgentClass: network.UserAgentClassMobile}
+}
+
+func (e *clientEngine) ApplyConfig(conf engine.Config) error {
+	e.client = conf.Client
+	return nil
+}
+
+func TestInitConfigurationUserAgentClass(t *testing.T) {
+	cases := map[string]struct {
+		client *network.Config
+		want   string
+	}{
+		"preferred by engine": {want: network.UserAgentClassMobile},
+		"configured":          {client: &network.Config{UserAgentClass: network.UserAgentClassDesktop}, want: network.UserAgentClassDesktop},
+	}
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := &clientEngine{tokenEngine: tokenEngine{name: "client"}}
+			withGlobalEngines(t, map[string]map[string]engine.Engine{engine.CategoryGeneral: {"client": e}})
+
+			InitConfiguration(map[string]map[string]engine.Config{engine.CategoryGeneral: {"client": {Enable: true, Client: c.client}}})
+			if e.client == nil || e.client.UserAgentClass != c.want || e.client.PoolKey != "client" {
+				t.Errorf("client config = %+v, want user agent class %s", e.client, c.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,165 @@
+package engines
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/mitchellh/mapstructure"
+	"github.com/zvirgilx/searxng-go/kernel/internal/engine"
+	"github.com/zvirgilx/searxng-go/kernel/internal/network"
+	"github.com/zvirgilx/searxng-go/kernel/internal/result"
+)
+
+const (
+	EngineTypeGenericHTML = "generic_html"
+)
+
+// genericHTML is an engine which scrapes html results by the configured selectors,
+// so that a simple engine can be added by configuration without code.
+type genericHTML struct {
+	client *network.Client
+
+	name string
+	conf *GenericHTMLConfig
+}
+
+// GenericHTMLConfig is the configuration of generic html engine.
+//
+// The url template supports placeholders:
+//   - {query}: the escaped query.
+//   - {pageno}: the page number, starts from 1.
+//   - {offset}: the offset of results, equals to (pageno - 1) * page_size.
+type GenericHTMLConfig struct {
+	Url               string `mapstructure:"url"`                // Url is the template of search url, e.g. https://example.com/search?q={query}&page={pageno}.
+	PageSize          int    `mapstructure:"page_size"`          // PageSize is the number of results per page, used by {offset}.
+	Results           string `mapstructure:"results"`            // Results is the selector of result container.
+	Title             string `mapstructure:"title"`              // Title is the sub-selector of result title.
+	Link              string `mapstructure:"link"`               // Link is the sub-selector of result url.
+	LinkAttr          string `mapstructure:"link_attr"`          // LinkAttr is the attribute of link which contains the url, default is href.
+	Content           string `mapstructure:"content"`            // Content is the sub-selector of result content.
+	Thumbnail         string `mapstructure:"thumbnail"`          // Thumbnail is the sub-selector of result thumbnail.
+	ThumbnailAttr     string `mapstructure:"thumbnail_attr"`     // ThumbnailAttr is the attribute of thumbnail which contains the url, default is src.
+	DisablePagination bool   `mapstructure:"disable_pagination"` // DisablePagination means only the first page is requested.
+}
+
+func init() {
+	registerGenericEngine(EngineTypeGenericHTML, func(name string) engine.Engine {
+		return &genericHTML{client: network.DefaultClient(), name: name}
+	})
+}
+
+func (g *genericHTML) Request(ctx context.Context, opts *engine.Options) error {
+	if g.conf.DisablePagination && opts.PageNo > 1 {
+		return nil
+	}
+
+	u, err := url.Parse(expandUrlTemplate(g.conf.Url, opts.Query, opts.PageNo, g.conf.PageSize))
+	if err != nil {
+		return err
+	}
+
+	// the query of url has been built by the template.
+	req := g.client.Get().Base(u).Path(u.Path)
+	for k, vs := range u.Query() {
+		req.Param(k, vs...)
+	}
+	opts.Request = req
+	return nil
+}
+
+func (g *genericHTML) Response(ctx context.Context, opts *engine.Options, resp []byte) (*result.Result, error) {
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(string(resp)))
+	if err != nil {
+		return nil, errors.New("error parsing document")
+	}
+
+	base, _ := url.Parse(g.conf.Url)
+
+	res := result.CreateResult(g.name, opts.PageNo)
+	doc.Find(g.conf.Results).EachWithBreak(func(i int, s *goquery.Selection) bool {
+		// the configured pages may be large, the results parsed before canceled are kept.
+		if engine.ParseCanceled(ctx, i) {
+			return false
+		}
+
+		title := strings.TrimSpace(s.Find(g.conf.Title).First().Text())
+		link, _ := s.Find(g.conf.Link).First().Attr(g.conf.LinkAttr)
+		if title == "" || link == "" {
+			return true
+		}
+
+		var content, thumbnail string
+		if g.conf.Content != "" {
+			content = strings.TrimSpace(s.Find(g.conf.Content).First().Text())
+		}
+		if g.conf.Thumbnail != "" {
+			thumbnail, _ = s.Find(g.conf.Thumbnail).First().Attr(g.conf.ThumbnailAttr)
+		}
+
+		res.AppendData(&result.Data{
+			Engine:    g.name,
+			Title:     title,
+			Url:       resolveUrl(base, link),
+			Content:   content,
+			Thumbnail: resolveUrl(base, thumbnail),
+			Query:     opts.Query,
+		})
+		return true
+	})
+
+	return res, nil
+}
+
+// expandUrlTemplate replaces the placeholders of url template.
+func expandUrlTemplate(tmpl string, query string, pageNo int, pageSize int) string {
+	return strings.NewReplacer(
+		"{query}", url.QueryEscape(query),
+		"{pageno}", strconv.Itoa(pageNo),
+		"{offset}", strconv.Itoa((pageNo-1)*pageSize),
+	).Replace(tmpl)
+}
+
+// resolveUrl resolves the relative url against the base url.
+func resolveUrl(base *url.URL, ref string) string {
+	if base == nil || ref == "" {
+		return ref
+	}
+	u, err := base.Parse(ref)
+	if err != nil {
+		return ref
+	}
+	return u.String()
+}
+
+func (g *genericHTML) GetName() string {
+	return g.name
+}
+
+func (g *genericHTML) ApplyConfig(conf engine.Config) error {
+	g.client = network.NewClient(conf.Client)
+
+	var c *GenericHTMLConfig
+	if err := mapstructure.Decode(conf.Extra, &c); err != nil {
+		return err
+	}
+	if c == nil || c.Url == "" || c.Results == "" || c.Title == "" || c.Link == "" {
+		return errors.New("url, results, title and link of generic html engine are required")
+	}
+
+	if c.PageSize == 0 {
+		c.PageSize = 10
+	}
+	if c.LinkAttr == "" {
+		c.LinkAttr = "href"
+	}
+	if c.ThumbnailAttr == "" {
+		c.ThumbnailAttr = "src"
+	}
+
+	g.conf = c
+	return nil
+}
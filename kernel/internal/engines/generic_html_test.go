@@ -0,0 +1,162 @@
+package engines
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/zvirgilx/searxng-go/kernel/internal/engine"
+	"github.com/zvirgilx/searxng-go/kernel/internal/network"
+)
+
+// genericVideosConfig reproduces a simplified bing videos page by selectors.
+var genericVideosConfig = map[string]interface{}{
+	"url":       "https://videos.example.com/search?q={query}&first={offset}",
+	"page_size": 35,
+	"results":   "div.dg_u",
+	"title":     ".mc_vtvc_title",
+	"link":      "a.mc_vtvc_link",
+	"content":   ".mc_vtvc_meta_row",
+	"thumbnail": "img.rms_img",
+}
+
+func newGenericHTML(t *testing.T, extra map[string]interface{}) *genericHTML {
+	t.Helper()
+	g := &genericHTML{name: "videos"}
+	if err := g.ApplyConfig(engine.Config{Client: &network.Config{}, Extra: extra}); err != nil {
+		t.Fatal(err)
+	}
+	return g
+}
+
+func TestGenericHTMLRequest(t *testing.T) {
+	g := newGenericHTML(t, genericVideosConfig)
+
+	opts := engine.Options{Query: "funny cats", PageNo: 3}
+	if err := g.Request(context.Background(), &opts); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := opts.Request.URL().String(), "https://videos.example.com/search?first=70&q=funny+cats"; got != want {
+		t.Errorf("url = %s, want %s", got, want)
+	}
+}
+
+func TestGenericHTMLRequestRepeatedParams(t *testing.T) {
+	extra := map[string]interface{}{}
+	for k, v := range genericVideosConfig {
+		extra[k] = v
+	}
+	extra["url"] = "https://videos.example.com/search?q={query}&filter=hd&filter=long"
+	g := newGenericHTML(t, extra)
+
+	// every value of the repeated params is sent.
+	opts := engine.Options{Query: "cats", PageNo: 1}
+	if err := g.Request(context.Background(), &opts); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := opts.Request.URL().String(), "https://videos.example.com/search?filter=hd&filter=long&q=cats"; got != want {
+		t.Errorf("url = %s, want %s", got, want)
+	}
+}
+
+func TestGenericHTMLDisablePagination(t *testing.T) {
+	extra := map[string]interface{}{"disable_pagination": true}
+	for k, v := range genericVideosConfig {
+		extra[k] = v
+	}
+	g := newGenericHTML(t, extra)
+
+	opts := engine.Options{Query: "cats", PageNo: 2}
+	if err := g.Request(context.Background(), &opts); err != nil {
+		t.Fatal(err)
+	}
+	if opts.Request != nil {
+		t.Errorf("the second page is requested with pagination disabled")
+	}
+}
+
+func TestGenericHTMLResponse(t *testing.T) {
+	g := newGenericHTML(t, genericVideosConfig)
+	res := parseFixture(t, g, engine.Options{Query: "cats", PageNo: 1}, "generic_html/videos.html")
+
+	data := res.GetData()
+	if len(data) != 2 {
+		t.Fatalf("got %d data, want 2, the result without title is skipped", len(data))
+	}
+	// the relative urls are resolved against the url template.
+	if data[0].Url != "https://videos.example.com/videos/watch?v=cat1" || data[0].Thumbnail != "https://videos.example.com/th?id=cat1" {
+		t.Errorf("unexpected urls of %+v", data[0])
+	}
+	if data[0].Title != "Funny cats compilation" || data[0].Content != "1.2M views · 3 years ago" {
+		t.Errorf("unexpected title or content of %+v", data[0])
+	}
+	if data[1].Engine != "videos" || data[1].Content != "" {
+		t.Errorf("unexpected %+v", data[1])
+	}
+}
+
+func TestGenericHTMLThumbnailAttr(t *testing.T) {
+	extra := map[string]interface{}{"thumbnail_attr": "data-src-hq"}
+	for k, v := range genericVideosConfig {
+		extra[k] = v
+	}
+	g := newGenericHTML(t, extra)
+	res := parseFixture(t, g, engine.Options{Query: "cats", PageNo: 1}, "generic_html/videos.html")
+
+	if got := res.GetData()[1].Thumbnail; got != "https://img.example.com/sleep.jpg" {
+		t.Errorf("thumbnail = %q", got)
+	}
+}
+
+func TestGenericHTMLConfigRequired(t *testing.T) {
+	g := &genericHTML{name: "broken"}
+	if err := g.ApplyConfig(engine.Config{Extra: map[string]interface{}{"url": "https://example.com/?q={query}"}}); err == nil {
+		t.Error("the config without selectors is applied")
+	}
+}
+
+// countdownContext is done after its error is checked n times, e.g. the deadline fires while parsing.
+type countdownContext struct {
+	context.Context
+	n int
+}
+
+func (c *countdownContext) Err() error {
+	if c.n <= 0 {
+		return context.DeadlineExceeded
+	}
+	c.n--
+	return nil
+}
+
+func TestGenericHTMLResponseCanceled(t *testing.T) {
+	var page strings.Builder
+	page.WriteString(`<html><body>`)
+	for i := 0; i < 20; i++ {
+		fmt.Fprintf(&page, `<div class="dg_u"><a class="mc_vtvc_link" href="/v/%d"></a><div class="mc_vtvc_title">video %d</div></div>`, i, i)
+	}
+	page.WriteString(`</body></html>`)
+
+	cases := map[string]struct {
+		checks int
+		want   int
+	}{
+		"not canceled":         {checks: 100, want: 20},
+		"canceled before":      {checks: 0, want: 0},
+		"canceled in the loop": {checks: 2, want: 16},
+	}
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			ctx := &countdownContext{Context: context.Background(), n: c.checks}
+			res, err := newGenericHTML(t, genericVideosConfig).Response(ctx, &engine.Options{Query: "cats", PageNo: 1}, []byte(page.String()))
+			if err != nil {
+				t.Fatal(err)
+			}
+			// the results parsed before canceled are kept.
+			if n := res.GetDataSize(); n != c.want {
+				t.Errorf("got %d data, want %d", n, c.want)
+			}
+		})
+	}
+}
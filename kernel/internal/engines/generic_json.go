@@ -0,0 +1,184 @@
+package engines
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mitchellh/mapstructure"
+	"github.com/stretchr/objx"
+	"github.com/zvirgilx/searxng-go/kernel/internal/engine"
+	"github.com/zvirgilx/searxng-go/kernel/internal/network"
+	"github.com/zvirgilx/searxng-go/kernel/internal/result"
+)
+
+const (
+	EngineTypeGenericJSON = "generic_json"
+)
+
+// genericJSONRootKey wraps the response of api whose root is the results array.
+const genericJSONRootKey = "results"
+
+// genericJSONPublishedLayouts are the layouts tried to parse the published date without published_layout.
+var genericJSONPublishedLayouts = []string{time.RFC3339, time.DateTime, time.DateOnly}
+
+// genericJSON is an engine which extracts results from a json api by the configured paths,
+// so that a simple api-backed engine can be added by configuration without code.
+type genericJSON struct {
+	client *network.Client
+
+	name string
+	conf *GenericJSONConfig
+}
+
+// GenericJSONConfig is the configuration of generic json engine.
+//
+// The url template supports the same placeholders as the generic html engine: {query}, {pageno} and {offset}.
+// Paths are separated by dot, e.g. meta.results, and the paths of fields are relative to each result.
+type GenericJSONConfig struct {
+	Url               string `mapstructure:"url"`                // Url is the template of search url, e.g. https://example.com/api/search?q={query}&page={pageno}.
+	PageSize          int    `mapstructure:"page_size"`          // PageSize is the number of results per page, used by {offset}.
+	Results           string `mapstructure:"results"`            // Results is the path of results array, empty means the root is the array.
+	Title             string `mapstructure:"title"`              // Title is the path of result title.
+	Link              string `mapstructure:"link"`               // Link is the path of result url.
+	Content           string `mapstructure:"content"`            // Content is the path of result content.
+	Thumbnail         string `mapstructure:"thumbnail"`          // Thumbnail is the path of result thumbnail.
+	Published         string `mapstructure:"published"`          // Published is the path of published date, a date string or unix seconds.
+	PublishedLayout   string `mapstructure:"published_layout"`   // PublishedLayout is the go layout of published date, RFC3339 and dates are tried by default.
+	DisablePagination bool   `mapstructure:"disable_pagination"` // DisablePagination means only the first page is requested.
+}
+
+func init() {
+	registerGenericEngine(EngineTypeGenericJSON, func(name string) engine.Engine {
+		return &genericJSON{client: network.DefaultClient(), name: name}
+	})
+}
+
+func (g *genericJSON) Capabilities() engine.Capabilities {
+	return engine.Capabilities{
+		Paging:      g.conf == nil || !g.conf.DisablePagination,
+		ContentType: "application/json",
+	}
+}
+
+func (g *genericJSON) Request(ctx context.Context, opts *engine.Options) error {
+	if g.conf.DisablePagination && opts.PageNo > 1 {
+		return nil
+	}
+
+	u, err := url.Parse(expandUrlTemplate(g.conf.Url, opts.Query, opts.PageNo, g.conf.PageSize))
+	if err != nil {
+		return err
+	}
+
+	// the query of url has been built by the template.
+	req := g.client.Get().Base(u).Path(u.Path).Header("Accept", "application/json")
+	for k, vs := range u.Query() {
+		req.Param(k, vs...)
+	}
+	opts.Request = req
+	return nil
+}
+
+func (g *genericJSON) Response(ctx context.Context, opts *engine.Options, resp []byte) (*result.Result, error) {
+	log := slog.With("func", "generic_json.Response")
+
+	body := string(resp)
+	resultsPath := g.conf.Results
+	if resultsPath == "" {
+		body = `{"` + genericJSONRootKey + `":` + body + `}`
+		resultsPath = genericJSONRootKey
+	}
+
+	m, err := objx.FromJSON(body)
+	if err != nil {
+		log.ErrorContext(ctx, "failed to parse generic json response", slog.String("engine", g.name), slog.String("err", err.Error()))
+		return nil, err
+	}
+
+	base, _ := url.Parse(g.conf.Url)
+
+	res := result.CreateResult(g.name, opts.PageNo)
+	m.Get(resultsPath).EachObjxMap(func(i int, v objx.Map) bool {
+		title := genericJSONField(v, g.conf.Title)
+		link := genericJSONField(v, g.conf.Link)
+		// results without title or url are skipped, other fields are optional.
+		if title == "" || link == "" {
+			return true
+		}
+
+		res.AppendData(&result.Data{
+			Engine:        g.name,
+			Title:         title,
+			Url:           resolveUrl(base, link),
+			Content:       genericJSONField(v, g.conf.Content),
+			Thumbnail:     resolveUrl(base, genericJSONField(v, g.conf.Thumbnail)),
+			PublishedDate: g.published(v),
+			Query:         opts.Query,
+		})
+		return true
+	})
+
+	return res, nil
+}
+
+// genericJSONField gets the field of path as string, empty is returned if the path is empty, missing or not a scalar.
+func genericJSONField(v objx.Map, path string) string {
+	if path == "" {
+		return ""
+	}
+	value := v.Get(path)
+	if value.IsObjxMap() || value.IsMSI() || value.IsInterSlice() {
+		return ""
+	}
+	return strings.TrimSpace(value.String())
+}
+
+// published parses the published date of result, zero time is returned if failed.
+func (g *genericJSON) published(v objx.Map) time.Time {
+	s := genericJSONField(v, g.conf.Published)
+	if s == "" {
+		return time.Time{}
+	}
+	if sec, err := strconv.ParseInt(s, 10, 64); err == nil {
+		return time.Unix(sec, 0).UTC()
+	}
+
+	layouts := genericJSONPublishedLayouts
+	if g.conf.PublishedLayout != "" {
+		layouts = []string{g.conf.PublishedLayout}
+	}
+	for _, layout := range layouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t
+		}
+	}
+	return time.Time{}
+}
+
+func (g *genericJSON) GetName() string {
+	return g.name
+}
+
+func (g *genericJSON) ApplyConfig(conf engine.Config) error {
+	g.client = network.NewClient(conf.Client)
+
+	var c *GenericJSONConfig
+	if err := mapstructure.Decode(conf.Extra, &c); err != nil {
+		return err
+	}
+	if c == nil || c.Url == "" || c.Title == "" || c.Link == "" {
+		return errors.New("url, title and link of generic json engine are required")
+	}
+
+	if c.PageSize == 0 {
+		c.PageSize = 10
+	}
+
+	g.conf = c
+	return nil
+}
@@ -0,0 +1,145 @@
+package engines
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/zvirgilx/searxng-go/kernel/internal/engine"
+	"github.com/zvirgilx/searxng-go/kernel/internal/network"
+)
+
+// genericBooksConfig extracts the books of a nested json api by paths.
+var genericBooksConfig = map[string]interface{}{
+	"url":       "https://books.example.com/api/search?q={query}&page={pageno}",
+	"results":   "meta.results",
+	"title":     "name",
+	"link":      "links.html",
+	"content":   "summary",
+	"thumbnail": "cover.small",
+	"published": "published_at",
+}
+
+func newGenericJSON(t *testing.T, extra map[string]interface{}) *genericJSON {
+	t.Helper()
+	g := &genericJSON{name: "books"}
+	if err := g.ApplyConfig(engine.Config{Client: &network.Config{}, Extra: extra}); err != nil {
+		t.Fatal(err)
+	}
+	return g
+}
+
+func TestGenericJSONApplyConfig(t *testing.T) {
+	for _, extra := range []map[string]interface{}{
+		nil,
+		{"title": "name", "link": "url"},
+		{"url": "https://books.example.com/api?q={query}", "link": "url"},
+		{"url": "https://books.example.com/api?q={query}", "title": "name"},
+	} {
+		g := &genericJSON{name: "books"}
+		if err := g.ApplyConfig(engine.Config{Client: &network.Config{}, Extra: extra}); err == nil {
+			t.Errorf("the config without required fields is accepted: %v", extra)
+		}
+	}
+
+	g := newGenericJSON(t, genericBooksConfig)
+	if g.conf.PageSize != 10 {
+		t.Errorf("default page size = %d, want 10", g.conf.PageSize)
+	}
+}
+
+func TestGenericJSONRequest(t *testing.T) {
+	g := newGenericJSON(t, genericBooksConfig)
+
+	opts := engine.Options{Query: "go books", PageNo: 2}
+	if err := g.Request(context.Background(), &opts); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := opts.Request.URL().String(), "https://books.example.com/api/search?page=2&q=go+books"; got != want {
+		t.Errorf("url = %s, want %s", got, want)
+	}
+
+	extra := map[string]interface{}{"disable_pagination": true}
+	for k, v := range genericBooksConfig {
+		extra[k] = v
+	}
+	g = newGenericJSON(t, extra)
+	if g.Capabilities().Paging {
+		t.Error("paging is supported with pagination disabled")
+	}
+	opts = engine.Options{Query: "go books", PageNo: 2}
+	if err := g.Request(context.Background(), &opts); err != nil {
+		t.Fatal(err)
+	}
+	if opts.Request != nil {
+		t.Error("the second page is requested with pagination disabled")
+	}
+}
+
+func TestGenericJSONRequestRepeatedParams(t *testing.T) {
+	extra := map[string]interface{}{}
+	for k, v := range genericBooksConfig {
+		extra[k] = v
+	}
+	extra["url"] = "https://books.example.com/api/search?q={query}&fq=lang:en&fq=type:book"
+	g := newGenericJSON(t, extra)
+
+	// every value of the repeated params is sent.
+	opts := engine.Options{Query: "go", PageNo: 1}
+	if err := g.Request(context.Background(), &opts); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := opts.Request.URL().String(), "https://books.example.com/api/search?fq=lang%3Aen&fq=type%3Abook&q=go"; got != want {
+		t.Errorf("url = %s, want %s", got, want)
+	}
+}
+
+func TestGenericJSONResponse(t *testing.T) {
+	g := newGenericJSON(t, genericBooksConfig)
+	res := parseFixture(t, g, engine.Options{Query: "go", PageNo: 1}, "generic_json/nested.json")
+
+	data := res.GetData()
+	if len(data) != 2 {
+		t.Fatalf("got %d data, want 2, the results without title or url are skipped", len(data))
+	}
+	// the relative urls are resolved against the url template, the content is trimmed.
+	if data[0].Url != "https://books.example.com/books/learning-go" || data[0].Thumbnail != "https://img.example.com/learning-go.jpg" {
+		t.Errorf("unexpected urls of %+v", data[0])
+	}
+	if data[0].Content != "An idiomatic approach to real-world Go programming." {
+		t.Errorf("content = %q", data[0].Content)
+	}
+	if !data[0].PublishedDate.Equal(time.Date(2024, 1, 9, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("published date = %v", data[0].PublishedDate)
+	}
+	// the object is not a content, the unix seconds are a published date.
+	if data[1].Content != "" || !data[1].PublishedDate.Equal(time.Date(2015, 10, 30, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("unexpected %+v", data[1])
+	}
+}
+
+func TestGenericJSONRootArray(t *testing.T) {
+	g := newGenericJSON(t, map[string]interface{}{
+		"url":              "https://docs.example.com/api?q={query}",
+		"title":            "title",
+		"link":             "url",
+		"published":        "date",
+		"published_layout": "02/01/2006",
+	})
+	res := parseFixture(t, g, engine.Options{Query: "go", PageNo: 1}, "generic_json/root.json")
+
+	data := res.GetData()
+	if len(data) != 2 {
+		t.Fatalf("got %d data, want 2", len(data))
+	}
+	if data[0].Title != "Go by Example" || !data[0].PublishedDate.Equal(time.Date(2017, 7, 19, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("unexpected %+v", data[0])
+	}
+	if !data[1].PublishedDate.IsZero() {
+		t.Errorf("the invalid date is parsed: %v", data[1].PublishedDate)
+	}
+
+	if _, err := g.Response(context.Background(), &engine.Options{PageNo: 1}, []byte("<html>error</html>")); err == nil {
+		t.Error("the invalid json is parsed")
+	}
+}
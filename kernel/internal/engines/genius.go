@@ -0,0 +1,198 @@
+package engines
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/mitchellh/mapstructure"
+	"github.com/stretchr/objx"
+	"github.com/zvirgilx/searxng-go/kernel/internal/engine"
+	"github.com/zvirgilx/searxng-go/kernel/internal/network"
+	"github.com/zvirgilx/searxng-go/kernel/internal/result"
+)
+
+const (
+	EngineNameGenius = "genius"
+
+	geniusApiUrl   = "https://api.genius.com"
+	geniusPageSize = 10
+
+	// geniusMaxExcerpt is the maximum runes of excerpt in the infobox,
+	// only a short excerpt is shown with the source link, the full lyrics are never stored or emitted.
+	geniusMaxExcerpt = 200
+)
+
+// genius searches songs by Genius api, the infobox of the top song is followed up with structured metadata.
+type genius struct {
+	client *network.Client
+
+	token string
+}
+
+type GeniusConfig struct {
+	Token string `mapstructure:"token"` // Token is the client access token required by Genius api, the engine is disabled without it.
+}
+
+func init() {
+	engine.RegisterGlobalEngine(&genius{client: network.DefaultClient()}, engine.CategoryMusic)
+}
+
+func (g *genius) Request(ctx context.Context, opts *engine.Options) error {
+	// example: https://api.genius.com/search?q=test&page=1&per_page=10
+	base, _ := url.Parse(geniusApiUrl)
+	opts.Request = g.client.Get().Base(base).Path("search").
+		Header("Authorization", "Bearer "+g.token).
+		Param("q", opts.Query).
+		Param("page", strconv.Itoa(opts.PageNo)).
+		Param("per_page", strconv.Itoa(geniusPageSize))
+	return nil
+}
+
+func (g *genius) Response(ctx context.Context, opts *engine.Options, resp []byte) (*result.Result, error) {
+	log := slog.With("func", "genius.Response")
+
+	m, err := objx.FromJSON(string(resp))
+	if err != nil {
+		log.ErrorContext(ctx, "failed to parse genius response", slog.String("err", err.Error()))
+		return nil, err
+	}
+	if msg := m.Get("meta.message").Str(); msg != "" {
+		return nil, fmt.Errorf("genius error: %s", msg)
+	}
+
+	res := result.CreateResult(EngineNameGenius, opts.PageNo)
+	m.Get("response.hits").EachObjxMap(func(i int, v objx.Map) bool {
+		if v.Get("type").Str() != "song" {
+			return true
+		}
+
+		song := v.Get("result").ObjxMap()
+		title := song.Get("full_title").Str()
+		link := song.Get("url").Str()
+		if title == "" || link == "" {
+			return true
+		}
+
+		res.AppendData(&result.Data{
+			Engine:    EngineNameGenius,
+			Title:     title,
+			Url:       link,
+			Content:   song.Get("release_date_for_display").Str(),
+			Thumbnail: song.Get("song_art_image_thumbnail_url").Str(),
+			Author:    song.Get("primary_artist.name").Str(),
+			Query:     opts.Query,
+		})
+		return true
+	})
+
+	return res, nil
+}
+
+// geniusTopSong gets the id of the first song of search response, 0 is returned if none is found.
+func geniusTopSong(resp []byte) int {
+	m, err := objx.FromJSON(string(resp))
+	if err != nil {
+		return 0
+	}
+	var id int
+	m.Get("response.hits").EachObjxMap(func(i int, v objx.Map) bool {
+		song := v.Get("result").ObjxMap()
+		if v.Get("type").Str() != "song" || song.Get("full_title").Str() == "" || song.Get("url").Str() == "" {
+			return true
+		}
+		id = song.Get("id").Int()
+		return false
+	})
+	return id
+}
+
+// FollowUp requests the top song on the first page for the infobox.
+func (g *genius) FollowUp(ctx context.Context, opts *engine.Options, res *result.Result, resp []byte) error {
+	if opts.PageNo != 1 {
+		return nil
+	}
+	id := geniusTopSong(resp)
+	if id == 0 {
+		return nil
+	}
+
+	// example: https://api.genius.com/songs/378195?text_format=plain
+	base, _ := url.Parse(geniusApiUrl)
+	opts.Request = g.client.Get().Base(base).Path(fmt.Sprintf("songs/%d", id)).
+		Header("Authorization", "Bearer "+g.token).
+		Param("text_format", "plain")
+	return nil
+}
+
+// FollowUpResponse sets the infobox of song with title, artist, album, release year and a short excerpt,
+// the results are kept without it if failed.
+func (g *genius) FollowUpResponse(ctx context.Context, opts *engine.Options, res *result.Result, resp []byte) error {
+	m, err := objx.FromJSON(string(resp))
+	if err != nil || m.Get("response.song.title").Str() == "" {
+		slog.WarnContext(ctx, "failed to get genius infobox", slog.String("func", "genius.FollowUpResponse"))
+		return nil
+	}
+	song := m.Get("response.song").ObjxMap()
+
+	var lines []string
+	if artist := song.Get("primary_artist.name").Str(); artist != "" {
+		lines = append(lines, "Artist: "+artist)
+	}
+	if album := song.Get("album.name").Str(); album != "" {
+		lines = append(lines, "Album: "+album)
+	}
+	if year := song.Get("release_date_components.year").Int(); year > 0 {
+		lines = append(lines, "Released: "+strconv.Itoa(year))
+	}
+	if excerpt := geniusExcerpt(song.Get("description.plain").Str()); excerpt != "" {
+		lines = append(lines, excerpt)
+	}
+
+	link := song.Get("url").Str()
+	res.InfoBox = &result.InfoBox{
+		Title:   song.Get("title").Str(),
+		Content: strings.Join(lines, "\n"),
+		ImgSrc:  song.Get("song_art_image_url").Str(),
+		Url:     link,
+		UrlList: []map[string]string{{"title": "Source: Genius", "url": link}},
+	}
+	return nil
+}
+
+// geniusExcerpt collapses the whitespaces of text and caps it to geniusMaxExcerpt runes.
+func geniusExcerpt(text string) string {
+	text = strings.Join(strings.Fields(text), " ")
+	// genius returns "?" for songs without description.
+	if text == "?" {
+		return ""
+	}
+	if runes := []rune(text); len(runes) > geniusMaxExcerpt {
+		return strings.TrimSpace(string(runes[:geniusMaxExcerpt])) + "…"
+	}
+	return text
+}
+
+func (g *genius) GetName() string {
+	return EngineNameGenius
+}
+
+func (g *genius) ApplyConfig(conf engine.Config) error {
+	g.client = network.NewClient(conf.Client)
+
+	var c *GeniusConfig
+	if err := mapstructure.Decode(conf.Extra, &c); err != nil {
+		return err
+	}
+
+	// the engine disables itself without a token.
+	if c == nil || c.Token == "" {
+		return errors.New("token of genius is required")
+	}
+	g.token = c.Token
+	return nil
+}
@@ -0,0 +1,110 @@
+package engines
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/zvirgilx/searxng-go/kernel/internal/engine"
+	"github.com/zvirgilx/searxng-go/kernel/internal/network"
+)
+
+func newTestGenius(t *testing.T) *genius {
+	t.Helper()
+	e := &genius{}
+	if err := e.ApplyConfig(engine.Config{Client: &network.Config{}, Extra: map[string]interface{}{"token": "secret"}}); err != nil {
+		t.Fatal(err)
+	}
+	return e
+}
+
+func TestGeniusResponse(t *testing.T) {
+	res := parseFixture(t, newTestGenius(t), engine.Options{Query: "bohemian rhapsody", PageNo: 1}, "genius/search.json")
+	assertGolden(t, "genius/search.golden.json", res)
+
+	// the hits other than songs and the songs without title are skipped.
+	data := res.GetData()
+	if len(data) != 2 {
+		t.Fatalf("got %d data, want 2", len(data))
+	}
+	if data[0].Author != "Queen" || data[0].Content != "October 31, 1975" {
+		t.Errorf("unexpected data %+v", data[0])
+	}
+}
+
+func TestGeniusInfoBox(t *testing.T) {
+	e := newTestGenius(t)
+	opts := engine.Options{Query: "bohemian rhapsody", PageNo: 1}
+	res := parseFixture(t, e, opts, "genius/search.json")
+
+	// the top song is followed up.
+	if err := e.FollowUp(context.Background(), &opts, res, readFixture(t, "genius/search.json")); err != nil {
+		t.Fatal(err)
+	}
+	if opts.Request == nil || opts.Request.URL().String() != "https://api.genius.com/songs/378195?text_format=plain" {
+		t.Fatalf("the top song is not requested")
+	}
+	if err := e.FollowUpResponse(context.Background(), &opts, res, readFixture(t, "genius/song.json")); err != nil {
+		t.Fatal(err)
+	}
+
+	box := res.InfoBox
+	if box == nil || box.Title != "Bohemian Rhapsody" || box.ImgSrc != "https://images.genius.com/bohemian.1000x1000x1.png" {
+		t.Fatalf("unexpected infobox %+v", box)
+	}
+	lines := strings.Split(box.Content, "\n")
+	if len(lines) != 4 || lines[0] != "Artist: Queen" || lines[1] != "Album: A Night at the Opera" || lines[2] != "Released: 1975" {
+		t.Fatalf("content = %q", box.Content)
+	}
+	// the excerpt is capped with the whitespaces collapsed.
+	excerpt := lines[3]
+	if n := utf8.RuneCountInString(excerpt); n > geniusMaxExcerpt+1 || !strings.HasSuffix(excerpt, "…") || strings.Contains(excerpt, "  ") {
+		t.Errorf("excerpt of %d runes = %q", n, excerpt)
+	}
+	if len(box.UrlList) != 1 || box.UrlList[0]["title"] != "Source: Genius" || box.UrlList[0]["url"] != "https://genius.com/Queen-bohemian-rhapsody-lyrics" {
+		t.Errorf("url list = %v", box.UrlList)
+	}
+}
+
+func TestGeniusInfoBoxSkipped(t *testing.T) {
+	e := newTestGenius(t)
+
+	// no song is followed up beyond the first page or without songs.
+	for _, c := range []struct {
+		page int
+		resp string
+	}{{2, string(readFixture(t, "genius/search.json"))}, {1, `{"meta":{"status":200},"response":{"hits":[]}}`}} {
+		opts := engine.Options{Query: "bohemian rhapsody", PageNo: c.page}
+		res := parseFixture(t, e, opts, "genius/search.json")
+		if err := e.FollowUp(context.Background(), &opts, res, []byte(c.resp)); err != nil || opts.Request != nil {
+			t.Errorf("page %d: the song is requested, err %v", c.page, err)
+		}
+	}
+
+	// the results are kept without infobox if the song is not found.
+	opts := engine.Options{Query: "bohemian rhapsody", PageNo: 1}
+	res := parseFixture(t, e, opts, "genius/search.json")
+	if err := e.FollowUpResponse(context.Background(), &opts, res, []byte(`{"meta":{"status":404,"message":"Not found"}}`)); err != nil || res.InfoBox != nil {
+		t.Errorf("infobox %+v, err %v", res.InfoBox, err)
+	}
+}
+
+func TestGeniusExcerpt(t *testing.T) {
+	if got := geniusExcerpt(" ? "); got != "" {
+		t.Errorf("the placeholder description is kept: %q", got)
+	}
+	if got := geniusExcerpt("short\n  text"); got != "short text" {
+		t.Errorf("got %q", got)
+	}
+	long := strings.Repeat("é", geniusMaxExcerpt+50)
+	if got := geniusExcerpt(long); utf8.RuneCountInString(got) != geniusMaxExcerpt+1 {
+		t.Errorf("got %d runes, want %d with the ellipsis", utf8.RuneCountInString(got), geniusMaxExcerpt+1)
+	}
+}
+
+func TestGeniusRequiresToken(t *testing.T) {
+	if err := (&genius{}).ApplyConfig(engine.Config{Client: &network.Config{}}); err == nil {
+		t.Error("the engine is enabled without token")
+	}
+}
@@ -0,0 +1,128 @@
+package engines
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mitchellh/mapstructure"
+	"github.com/stretchr/objx"
+	"github.com/zvirgilx/searxng-go/kernel/internal/engine"
+	"github.com/zvirgilx/searxng-go/kernel/internal/network"
+	"github.com/zvirgilx/searxng-go/kernel/internal/result"
+)
+
+const (
+	EngineNameGithub = "github"
+
+	githubApiUrl   = "https://api.github.com"
+	githubPageSize = 20
+)
+
+// github searches the repositories of github by its search api.
+type github struct {
+	client *network.Client
+
+	token string
+}
+
+type GithubConfig struct {
+	Token string `mapstructure:"token"` // Token is the optional personal access token, it raises the rate limit of api.
+}
+
+func init() {
+	engine.RegisterGlobalEngine(&github{client: network.DefaultClient()}, engine.CategoryIT)
+}
+
+func (g *github) Capabilities() engine.Capabilities {
+	return engine.Capabilities{
+		Categories:  []string{engine.CategoryIT},
+		Paging:      true,
+		ContentType: "application/json",
+	}
+}
+
+func (g *github) Request(ctx context.Context, opts *engine.Options) error {
+	// example: https://api.github.com/search/repositories?q=test&page=1&per_page=20
+	base, _ := url.Parse(githubApiUrl)
+	req := g.client.Get().Base(base).Path("search/repositories").
+		Param("q", opts.Query).
+		Param("page", strconv.Itoa(opts.PageNo)).
+		Param("per_page", strconv.Itoa(githubPageSize)).
+		Header("Accept", "application/vnd.github+json")
+	if g.token != "" {
+		req.Header("Authorization", "Bearer "+g.token)
+	}
+
+	opts.Request = req
+	return nil
+}
+
+func (g *github) Response(ctx context.Context, opts *engine.Options, resp []byte) (*result.Result, error) {
+	log := slog.With("func", "github.Response")
+
+	m, err := objx.FromJSON(string(resp))
+	if err != nil {
+		log.ErrorContext(ctx, "failed to parse github response", slog.String("err", err.Error()))
+		return nil, err
+	}
+	// e.g. {"message":"API rate limit exceeded for 1.2.3.4.","documentation_url":"..."}
+	if msg := m.Get("message").Str(); msg != "" && !m.Has("items") {
+		return nil, fmt.Errorf("github error: %s", msg)
+	}
+
+	res := result.CreateResult(EngineNameGithub, opts.PageNo)
+	m.Get("items").EachObjxMap(func(i int, v objx.Map) bool {
+		title := v.Get("full_name").Str()
+		link := v.Get("html_url").Str()
+		if title == "" || link == "" {
+			return true
+		}
+
+		var parts []string
+		if desc := strings.TrimSpace(v.Get("description").Str()); desc != "" {
+			parts = append(parts, desc)
+		}
+		if lang := v.Get("language").Str(); lang != "" {
+			parts = append(parts, lang)
+		}
+		parts = append(parts, fmt.Sprintf("%d stars", v.Get("stargazers_count").Int()))
+		updatedDate, _ := time.Parse(time.RFC3339, v.Get("updated_at").Str())
+
+		res.AppendData(&result.Data{
+			Engine:        EngineNameGithub,
+			Title:         title,
+			Url:           link,
+			Content:       strings.Join(parts, " - "),
+			Thumbnail:     v.Get("owner.avatar_url").Str(),
+			Author:        v.Get("owner.login").Str(),
+			PublishedDate: updatedDate,
+			Query:         opts.Query,
+		})
+		return true
+	})
+
+	return res, nil
+}
+
+func (g *github) GetName() string {
+	return EngineNameGithub
+}
+
+func (g *github) ApplyConfig(conf engine.Config) error {
+	g.client = network.NewClient(conf.Client)
+
+	var c *GithubConfig
+	if err := mapstructure.Decode(conf.Extra, &c); err != nil {
+		return err
+	}
+	if c == nil {
+		return nil
+	}
+	g.token = c.Token
+	return nil
+}
@@ -0,0 +1,88 @@
+package engines
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/zvirgilx/searxng-go/kernel/internal/engine"
+	"github.com/zvirgilx/searxng-go/kernel/internal/network"
+)
+
+func newTestGithub(t *testing.T, extra map[string]interface{}) *github {
+	t.Helper()
+	g := &github{}
+	if err := g.ApplyConfig(engine.Config{Client: &network.Config{}, Extra: extra}); err != nil {
+		t.Fatal(err)
+	}
+	return g
+}
+
+func TestGithubResponse(t *testing.T) {
+	res := parseFixture(t, newTestGithub(t, nil), engine.Options{Query: "cobra", PageNo: 1}, "github/search.json")
+	assertGolden(t, "github/search.golden.json", res)
+
+	data := res.GetData()
+	if len(data) != 2 {
+		t.Fatalf("got %d data, want 2, the repositories without name are skipped", len(data))
+	}
+	if data[0].Title != "spf13/cobra" || data[0].Url != "https://github.com/spf13/cobra" || data[0].Author != "spf13" {
+		t.Errorf("unexpected data %+v", data[0])
+	}
+	if want := "A Commander for modern Go CLI interactions - Go - 38000 stars"; data[0].Content != want {
+		t.Errorf("content = %q, want %q", data[0].Content, want)
+	}
+	if want := time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC); !data[0].PublishedDate.Equal(want) {
+		t.Errorf("published date = %v, want %v", data[0].PublishedDate, want)
+	}
+	// the missing description and language are left out, and so is the invalid date.
+	if data[1].Content != "0 stars" || !data[1].PublishedDate.IsZero() {
+		t.Errorf("unexpected data %+v", data[1])
+	}
+}
+
+func TestGithubResponseError(t *testing.T) {
+	body := []byte(`{"message":"API rate limit exceeded for 1.2.3.4.","documentation_url":"https://docs.github.com"}`)
+	if _, err := newTestGithub(t, nil).Response(context.Background(), &engine.Options{PageNo: 1}, body); err == nil {
+		t.Error("the error of api is not returned")
+	}
+}
+
+func TestGithubRequest(t *testing.T) {
+	var header http.Header
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		header = r.Header.Clone()
+		w.Write([]byte(`{"items":[]}`))
+	}))
+	defer srv.Close()
+
+	tests := map[string]struct {
+		extra map[string]interface{}
+		auth  string
+	}{
+		"public": {},
+		"token":  {extra: map[string]interface{}{"token": "secret"}, auth: "Bearer secret"},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			opts := engine.Options{Query: "cobra", PageNo: 2}
+			if err := newTestGithub(t, tt.extra).Request(context.Background(), &opts); err != nil {
+				t.Fatal(err)
+			}
+			if got, want := opts.Request.URL().String(), "https://api.github.com/search/repositories?page=2&per_page=20&q=cobra"; got != want {
+				t.Errorf("url = %s, want %s", got, want)
+			}
+
+			base, _ := url.Parse(srv.URL)
+			if r := opts.Request.Base(base).Do(context.Background()); r.Err != nil {
+				t.Fatal(r.Err)
+			}
+			if got := header.Get("Authorization"); got != tt.auth {
+				t.Errorf("authorization = %q, want %q", got, tt.auth)
+			}
+		})
+	}
+}
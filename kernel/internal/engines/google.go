@@ -39,6 +39,20 @@ type google struct {
 func init() {
 	complete.RegisterCompleter(EngineNameGoogle, &google{client: network.DefaultClient()})
 	engine.RegisterGlobalEngine(&google{client: network.DefaultClient()}, engine.CategoryGeneral)
+	engine.RegisterAlias("g", EngineNameGoogle)
+}
+
+func (g *google) Capabilities() engine.Capabilities {
+	return engine.Capabilities{
+		Categories: []string{engine.CategoryGeneral},
+		Paging:     true,
+		TimeRange:  true,
+		// the locale is sent as the interface language and the restrict of language and country.
+		Language:       true,
+		Operators:      true,
+		Verbatim:       true,
+		QueryExpansion: true,
+	}
 }
 
 func (g *google) Request(ctx context.Context, opts *engine.Options) error {
@@ -60,8 +74,18 @@ func (g *google) Request(ctx context.Context, opts *engine.Options) error {
 			Param("cr", param["cr"])
 	}
 
+	// e.g. tbs=qdr:d,li:1, li:1 is the verbatim search, the query is sent as typed.
+	var tbs []string
 	if t, ok := googleTimeRangeMap[opts.TimeRange]; ok {
-		r.Param("tbs", "qdr:"+t)
+		tbs = append(tbs, "qdr:"+t)
+	}
+	if opts.Verbatim {
+		tbs = append(tbs, "li:1")
+		// nfpr=1 disables "showing results for" the corrected query.
+		r.Param("nfpr", "1")
+	}
+	if len(tbs) > 0 {
+		r.Param("tbs", strings.Join(tbs, ","))
 	}
 
 	r.Header("User-Agent", "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/101.0.4951.54 Safari/537.36")
@@ -110,9 +134,31 @@ func (g *google) Response(ctx context.Context, opts *engine.Options, bytes []byt
 		util.SetAdd(res.Suggestions, sug)
 	})
 
+	// e.g. Did you mean: <a class="gL9Hy">golang</a>
+	doc.Find("#taw a.gL9Hy").Each(func(i int, s *goquery.Selection) {
+		if c := strings.TrimSpace(s.Text()); c != "" {
+			res.Corrections = append(res.Corrections, c)
+		}
+	})
+
 	return res, nil
 }
 
+// googleBlockedSelectors match the captcha page of google, it is redirected to from the search in place of results,
+// e.g. https://www.google.com/sorry/index?continue=...
+var googleBlockedSelectors = []string{
+	"form#captcha-form",
+	"form[action*='/sorry/index']",
+}
+
+func (g *google) Blocked(body []byte) bool {
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(string(body)))
+	if err != nil {
+		return false
+	}
+	return trySelectors(doc.Selection, googleBlockedSelectors...) != nil
+}
+
 func (g *google) Complete(ctx context.Context, q string, locale string) []complete.Result {
 	log := slog.With("func", "google.Complete")
 
@@ -131,18 +177,18 @@ func (g *google) Complete(ctx context.Context, q string, locale string) []comple
 
 	res := req.Do(ctx)
 	if res.Err != nil {
-		log.ErrorContext(ctx, "err", err)
+		log.ErrorContext(ctx, "failed to request google complete", slog.String("err", res.Err.Error()))
 		return nil
 	}
 	var data []interface{}
 	err = json.Unmarshal(res.Body, &data)
 	if err != nil {
-		log.ErrorContext(ctx, "err", err)
+		log.ErrorContext(ctx, "failed to parse google complete response", slog.String("err", err.Error()))
 		return nil
 	}
 
 	if len(data) < 2 {
-		log.ErrorContext(ctx, "err", "resp too short")
+		log.ErrorContext(ctx, "google complete response too short")
 		return nil
 	}
 	var results []complete.Result
@@ -196,6 +242,16 @@ func GetGoogleInfo(params map[string]string) map[string]interface{} {
 	return info
 }
 
+// ReverseImageURL links to the google lens page of the image,
+// e.g. https://lens.google.com/uploadbyurl?url=https%3A%2F%2Fexample.com%2Fcat.jpg&hl=en.
+func (g *google) ReverseImageURL(imageURL string, opts *engine.Options) string {
+	params := url.Values{"url": {imageURL}}
+	if lang, _, _ := strings.Cut(opts.Locale, "-"); lang != "" {
+		params.Set("hl", lang)
+	}
+	return "https://lens.google.com/uploadbyurl?" + params.Encode()
+}
+
 func (g *google) GetName() string {
 	return EngineNameGoogle
 }
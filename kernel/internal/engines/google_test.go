@@ -0,0 +1,108 @@
+package engines
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/zvirgilx/searxng-go/kernel/internal/engine"
+	"github.com/zvirgilx/searxng-go/kernel/internal/engines/traits"
+	"github.com/zvirgilx/searxng-go/kernel/internal/network"
+)
+
+func TestGoogleRequestVerbatim(t *testing.T) {
+	// the subdomain and language params of google are from its traits.
+	if err := traits.InitTraits(); err != nil {
+		t.Fatal(err)
+	}
+
+	cases := []struct {
+		opts engine.Options
+		tbs  string
+		nfpr string
+	}{
+		{opts: engine.Options{Query: `golang site:go.dev "for loop"`, PageNo: 1, Verbatim: true}, tbs: "li:1", nfpr: "1"},
+		{opts: engine.Options{Query: `golang site:go.dev "for loop"`, PageNo: 1, Verbatim: true, TimeRange: engine.TimeRangeDay}, tbs: "qdr:d,li:1", nfpr: "1"},
+		{opts: engine.Options{Query: `golang site:go.dev "for loop"`, PageNo: 1}},
+	}
+	for _, c := range cases {
+		opts := c.opts
+		if err := (&google{client: network.DefaultClient()}).Request(context.Background(), &opts); err != nil {
+			t.Fatal(err)
+		}
+		q := opts.Request.URL().Query()
+		// the verbatim search is by the params of google, the query is sent as typed.
+		if q.Get("q") != c.opts.Query {
+			t.Errorf("query = %q, want %q", q.Get("q"), c.opts.Query)
+		}
+		if q.Get("tbs") != c.tbs || q.Get("nfpr") != c.nfpr {
+			t.Errorf("verbatim %v, time range %q: tbs = %q, nfpr = %q", c.opts.Verbatim, c.opts.TimeRange, q.Get("tbs"), q.Get("nfpr"))
+		}
+	}
+}
+
+func TestGoogleCapabilities(t *testing.T) {
+	if err := traits.InitTraits(); err != nil {
+		t.Fatal(err)
+	}
+
+	// the params declared by capabilities are kept and sent by the request.
+	g := &google{client: network.DefaultClient()}
+	opts := engine.Options{Query: "golang site:go.dev", PageNo: 2, TimeRange: engine.TimeRangeWeek, Locale: "de-DE", Verbatim: true}
+	if !engine.ApplyCapabilities(g, &opts) {
+		t.Fatal("the next page of google is skipped")
+	}
+	if opts.Query != "golang site:go.dev" || !opts.Operators.IsEmpty() {
+		t.Errorf("the operators are stripped: %q, %+v", opts.Query, opts.Operators)
+	}
+	if err := g.Request(context.Background(), &opts); err != nil {
+		t.Fatal(err)
+	}
+	q := opts.Request.URL().Query()
+	if q.Get("start") != "10" || q.Get("tbs") != "qdr:w,li:1" || !strings.HasPrefix(q.Get("hl"), "de") {
+		t.Errorf("unexpected request %s", opts.Request.URL())
+	}
+	if !engine.SupportsQueryExpansion(g) {
+		t.Error("the query of google is not expanded")
+	}
+}
+
+func TestGoogleReverseImageURL(t *testing.T) {
+	cases := map[string]struct {
+		locale string
+		want   string
+	}{
+		"locale":    {locale: "de-DE", want: "https://lens.google.com/uploadbyurl?hl=de&url=https%3A%2F%2Fexample.com%2Fcat.jpg"},
+		"no locale": {want: "https://lens.google.com/uploadbyurl?url=https%3A%2F%2Fexample.com%2Fcat.jpg"},
+	}
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := (&google{}).ReverseImageURL("https://example.com/cat.jpg", &engine.Options{Locale: c.locale})
+			if got != c.want {
+				t.Errorf("reverse image url = %s, want %s", got, c.want)
+			}
+		})
+	}
+}
+
+func TestGoogleBlocked(t *testing.T) {
+	g := &google{client: network.DefaultClient()}
+	sorry := readFixture(t, "google/sorry.html")
+	if !g.Blocked(sorry) {
+		t.Error("the captcha page is not detected")
+	}
+	if err := engine.ValidateResponse(g, &engine.Options{Query: "golang"}, "text/html; charset=UTF-8", sorry); !errors.Is(err, engine.ErrBlocked) {
+		t.Errorf("err = %v, want blocked", err)
+	}
+
+	// the results of query captcha mention the markers of challenge pages, but they are not blocked.
+	results := readFixture(t, "google/captcha_results.html")
+	if err := engine.ValidateResponse(g, &engine.Options{Query: "captcha"}, "text/html; charset=UTF-8", results); err != nil {
+		t.Errorf("err = %v, want the results page not blocked", err)
+	}
+	res := parseFixture(t, g, engine.Options{Query: "captcha", PageNo: 1}, "google/captcha_results.html")
+	if n := res.GetDataSize(); n != 2 {
+		t.Errorf("got %d data, want 2", n)
+	}
+}
@@ -0,0 +1,58 @@
+package engines
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/zvirgilx/searxng-go/kernel/internal/engine"
+	"github.com/zvirgilx/searxng-go/kernel/internal/result"
+)
+
+// update rewrites the golden files by the parsed data, e.g. go test ./internal/engines -run Bandcamp -update.
+var update = flag.Bool("update", false, "update the golden files of engine tests")
+
+// readFixture reads the fixture of engine tests under testdata.
+func readFixture(t *testing.T, name string) []byte {
+	t.Helper()
+	b, err := os.ReadFile(filepath.Join("testdata", name))
+	if err != nil {
+		t.Fatalf("read fixture %s: %v", name, err)
+	}
+	return b
+}
+
+// parseFixture parses the fixture by the response of engine.
+func parseFixture(t *testing.T, e engine.Engine, opts engine.Options, name string) *result.Result {
+	t.Helper()
+	res, err := e.Response(context.Background(), &opts, readFixture(t, name))
+	if err != nil {
+		t.Fatalf("parse fixture %s: %v", name, err)
+	}
+	return res
+}
+
+// assertGolden compares the data of result with the golden file, the golden file is rewritten with -update.
+func assertGolden(t *testing.T, name string, res *result.Result) {
+	t.Helper()
+	got, err := json.MarshalIndent(res.GetData(), "", "  ")
+	if err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join("testdata", name)
+	if *update {
+		if err := os.WriteFile(path, append(got, '\n'), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("read golden %s: %v", name, err)
+	}
+	if string(append(got, '\n')) != string(want) {
+		t.Errorf("data of %s differ from golden:\ngot:\n%s\nwant:\n%s", name, got, want)
+	}
+}
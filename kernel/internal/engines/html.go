@@ -0,0 +1,36 @@
+package engines
+
+import (
+	"github.com/PuerkitoBio/goquery"
+)
+
+// trySelectors finds the results by an ordered list of fallback selectors,
+// the first selector which matches anything is used.
+// It reduces breakage when the upstream tweaks the markup.
+// It returns nil if no selector matches.
+func trySelectors(s *goquery.Selection, selectors ...string) *goquery.Selection {
+	for _, selector := range selectors {
+		if found := s.Find(selector); found.Length() > 0 {
+			return found
+		}
+	}
+	return nil
+}
+
+// htmlLayout is a layout of results page, the results are matched by the selector if the container is in the page.
+type htmlLayout struct {
+	container string
+	results   string
+}
+
+// tryLayouts finds the results by the first layout whose container is in the page,
+// so that a page of known layout without results is empty rather than unparsable.
+// It returns false if no layout matches.
+func tryLayouts(s *goquery.Selection, layouts ...htmlLayout) (*goquery.Selection, bool) {
+	for _, l := range layouts {
+		if s.Find(l.container).Length() > 0 {
+			return s.Find(l.results), true
+		}
+	}
+	return nil, false
+}
@@ -0,0 +1,49 @@
+package engines
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+func newTestDocument(t *testing.T, html string) *goquery.Document {
+	t.Helper()
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(html))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return doc
+}
+
+func TestTrySelectors(t *testing.T) {
+	doc := newTestDocument(t, `<ul class="v2"><li>a</li><li>b</li></ul>`)
+
+	// the second selector is used when the first matches nothing.
+	found := trySelectors(doc.Selection, "ul.v1 li", "ul.v2 li")
+	if found == nil || found.Length() != 2 {
+		t.Fatalf("the fallback selector is not used")
+	}
+	if trySelectors(doc.Selection, "ol li", "table td") != nil {
+		t.Error("the selectors matching nothing found results")
+	}
+}
+
+func TestTryLayouts(t *testing.T) {
+	layouts := []htmlLayout{{container: "ul.v1", results: "ul.v1 li"}, {container: "ul.v2", results: "ul.v2 li"}}
+
+	found, ok := tryLayouts(newTestDocument(t, `<ul class="v2"><li>a</li></ul>`).Selection, layouts...)
+	if !ok || found.Length() != 1 {
+		t.Errorf("the second layout is not used")
+	}
+
+	// the layout of container without results is matched, rather than falling through.
+	found, ok = tryLayouts(newTestDocument(t, `<ul class="v1"></ul><ul class="v2"><li>a</li></ul>`).Selection, layouts...)
+	if !ok || found.Length() != 0 {
+		t.Errorf("the first layout without results is not used")
+	}
+
+	if _, ok = tryLayouts(newTestDocument(t, `<p>unknown</p>`).Selection, layouts...); ok {
+		t.Error("the unknown layout is matched")
+	}
+}
@@ -31,7 +31,10 @@ type imdb struct {
 }
 
 func init() {
-	engine.RegisterGlobalEngine(&imdb{client: network.DefaultClient()}, engine.CategoryGeneral)
+	// each category has its own instance, so that the config of one category does not overwrite the other.
+	for _, category := range []string{engine.CategoryGeneral, engine.CategoryMovies} {
+		engine.RegisterGlobalEngine(&imdb{client: network.DefaultClient()}, category)
+	}
 }
 
 func (i *imdb) Request(ctx context.Context, opts *engine.Options) error {
@@ -63,7 +66,7 @@ func (i *imdb) Response(ctx context.Context, opts *engine.Options, resp []byte)
 	log.DebugContext(ctx, "response", "resp", string(resp))
 	m, err := objx.FromJSON(string(resp))
 	if err != nil {
-		log.ErrorContext(ctx, "err", err)
+		log.ErrorContext(ctx, "failed to parse imdb response", slog.String("err", err.Error()))
 		return nil, err
 	}
 	res := result.CreateResult(EngineNameIMDB, opts.PageNo)
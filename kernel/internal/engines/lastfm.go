@@ -0,0 +1,277 @@
+package engines
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/mitchellh/mapstructure"
+	"github.com/stretchr/objx"
+	"github.com/zvirgilx/searxng-go/kernel/internal/engine"
+	"github.com/zvirgilx/searxng-go/kernel/internal/network"
+	"github.com/zvirgilx/searxng-go/kernel/internal/result"
+)
+
+const (
+	EngineNameLastFM = "lastfm"
+
+	lastFMApiUrl   = "https://ws.audioscrobbler.com"
+	lastFMPageSize = 10
+
+	// the count of tags and similar artists shown in the infobox.
+	lastFMMaxTags    = 5
+	lastFMMaxSimilar = 5
+
+	// lastFMCacheTTL is the expiration of cached artist info, the bio of artist rarely changes.
+	lastFMCacheTTL = time.Hour
+)
+
+// lastFM searches artists by last.fm api,
+// the infobox of the top artist is followed up by artist.getInfo and cached per artist.
+type lastFM struct {
+	client *network.Client
+
+	apiKey string
+
+	mu    sync.Mutex
+	cache map[string]lastFMCacheEntry
+}
+
+type lastFMCacheEntry struct {
+	infoBox *result.InfoBox
+	expires time.Time
+}
+
+type LastFMConfig struct {
+	ApiKey string `mapstructure:"api_key"` // ApiKey is required by last.fm api, the engine is disabled without it.
+}
+
+func init() {
+	engine.RegisterGlobalEngine(&lastFM{client: network.DefaultClient(), cache: map[string]lastFMCacheEntry{}}, engine.CategoryMusic)
+}
+
+func (l *lastFM) Capabilities() engine.Capabilities {
+	return engine.Capabilities{
+		Categories: []string{engine.CategoryMusic},
+		Paging:     true,
+		Language:   true,
+	}
+}
+
+// lastFMArtist gets the artist name from query, e.g. "artist radiohead" -> "radiohead".
+func lastFMArtist(query string) string {
+	q := strings.TrimSpace(query)
+	if len(q) > len("artist ") && strings.EqualFold(q[:len("artist ")], "artist ") {
+		return strings.TrimSpace(q[len("artist "):])
+	}
+	return q
+}
+
+func (l *lastFM) Request(ctx context.Context, opts *engine.Options) error {
+	// example: https://ws.audioscrobbler.com/2.0/?method=artist.search&artist=test&api_key=key&format=json&page=1&limit=10
+	base, _ := url.Parse(lastFMApiUrl)
+	opts.Request = l.client.Get().Base(base).Path("2.0/").
+		Param("method", "artist.search").
+		Param("artist", lastFMArtist(opts.Query)).
+		Param("api_key", l.apiKey).
+		Param("format", "json").
+		Param("page", strconv.Itoa(opts.PageNo)).
+		Param("limit", strconv.Itoa(lastFMPageSize))
+	return nil
+}
+
+func (l *lastFM) Response(ctx context.Context, opts *engine.Options, resp []byte) (*result.Result, error) {
+	log := slog.With("func", "lastFM.Response")
+
+	m, err := objx.FromJSON(string(resp))
+	if err != nil {
+		log.ErrorContext(ctx, "failed to parse lastfm response", slog.String("err", err.Error()))
+		return nil, err
+	}
+	if msg := m.Get("message").Str(); msg != "" {
+		return nil, fmt.Errorf("lastfm error: %s", msg)
+	}
+
+	lang, _, _ := strings.Cut(opts.Locale, "-")
+
+	res := result.CreateResult(EngineNameLastFM, opts.PageNo)
+	m.Get("results.artistmatches.artist").EachObjxMap(func(i int, v objx.Map) bool {
+		name := v.Get("name").Str()
+		link := v.Get("url").Str()
+		if name == "" || link == "" {
+			return true
+		}
+
+		var content string
+		if listeners, err := strconv.ParseInt(v.Get("listeners").Str(), 10, 64); err == nil {
+			content = engine.FormatViews(listeners, lang) + " listeners"
+		}
+
+		res.AppendData(&result.Data{
+			Engine:    EngineNameLastFM,
+			Title:     name,
+			Url:       link,
+			Content:   content,
+			Thumbnail: lastFMImage(v, engine.ThumbnailToken(opts, "medium", "large", "extralarge")),
+			Query:     opts.Query,
+		})
+		return true
+	})
+
+	return res, nil
+}
+
+// lastFMTopArtist gets the name of the first artist of result, empty if none is found.
+func lastFMTopArtist(res *result.Result) string {
+	if data := res.GetData(); len(data) > 0 {
+		return data[0].Title
+	}
+	return ""
+}
+
+func lastFMCacheKey(artist, lang string) string {
+	return strings.ToLower(artist) + "|" + lang
+}
+
+// FollowUp requests the info of the top artist on the first page, the cached info is used without requesting.
+func (l *lastFM) FollowUp(ctx context.Context, opts *engine.Options, res *result.Result, resp []byte) error {
+	artist := lastFMTopArtist(res)
+	if artist == "" || opts.PageNo != 1 {
+		return nil
+	}
+	lang, _, _ := strings.Cut(opts.Locale, "-")
+
+	l.mu.Lock()
+	entry, ok := l.cache[lastFMCacheKey(artist, lang)]
+	l.mu.Unlock()
+	if ok && time.Now().Before(entry.expires) {
+		res.InfoBox = entry.infoBox
+		return nil
+	}
+
+	// example: https://ws.audioscrobbler.com/2.0/?method=artist.getinfo&artist=test&api_key=key&format=json&lang=en
+	base, _ := url.Parse(lastFMApiUrl)
+	req := l.client.Get().Base(base).Path("2.0/").
+		Param("method", "artist.getinfo").
+		Param("artist", artist).
+		Param("api_key", l.apiKey).
+		Param("format", "json")
+	if lang != "" {
+		req.Param("lang", lang)
+	}
+
+	opts.Request = req
+	return nil
+}
+
+// FollowUpResponse sets the infobox of the top artist and caches it, the results are kept without it if failed.
+func (l *lastFM) FollowUpResponse(ctx context.Context, opts *engine.Options, res *result.Result, resp []byte) error {
+	lang, _, _ := strings.Cut(opts.Locale, "-")
+	infoBox, err := lastFMInfoBox(resp, lang)
+	if err != nil {
+		slog.WarnContext(ctx, "failed to get lastfm infobox", slog.String("func", "lastFM.FollowUpResponse"), slog.String("err", err.Error()))
+		return nil
+	}
+
+	l.mu.Lock()
+	l.cache[lastFMCacheKey(lastFMTopArtist(res), lang)] = lastFMCacheEntry{infoBox: infoBox, expires: time.Now().Add(lastFMCacheTTL)}
+	l.mu.Unlock()
+
+	res.InfoBox = infoBox
+	return nil
+}
+
+// lastFMImage gets the image of artist in the size, e.g. small, medium, large, extralarge.
+// The largest image is returned if the size is missing, last.fm lists images from small to large.
+func lastFMImage(v objx.Map, size string) string {
+	var img string
+	v.Get("image").EachObjxMap(func(i int, image objx.Map) bool {
+		if src := image.Get("#text").Str(); src != "" {
+			img = src
+			if image.Get("size").Str() == size {
+				return false
+			}
+		}
+		return true
+	})
+	return img
+}
+
+// lastFMInfoBox parses the info of artist with bio summary, top tags, similar artists and stats.
+func lastFMInfoBox(resp []byte, lang string) (*result.InfoBox, error) {
+	m, err := objx.FromJSON(string(resp))
+	if err != nil {
+		return nil, err
+	}
+	a := m.Get("artist").ObjxMap()
+	if a.Get("name").Str() == "" {
+		return nil, errors.New("artist not found in lastfm")
+	}
+
+	var lines []string
+	// the summary ends with a link "Read more on Last.fm".
+	if summary := htmlToText(a.Get("bio.summary").Str()); summary != "" {
+		lines = append(lines, strings.TrimSpace(strings.TrimSuffix(summary, "Read more on Last.fm")))
+	}
+
+	listeners, _ := strconv.ParseInt(a.Get("stats.listeners").Str(), 10, 64)
+	playCount, _ := strconv.ParseInt(a.Get("stats.playcount").Str(), 10, 64)
+	if listeners > 0 || playCount > 0 {
+		lines = append(lines, fmt.Sprintf("Listeners: %s, Scrobbles: %s", engine.FormatViews(listeners, lang), engine.FormatViews(playCount, lang)))
+	}
+
+	var tags []string
+	a.Get("tags.tag").EachObjxMap(func(i int, v objx.Map) bool {
+		tags = append(tags, v.Get("name").Str())
+		return len(tags) < lastFMMaxTags
+	})
+	if len(tags) > 0 {
+		lines = append(lines, "Tags: "+strings.Join(tags, ", "))
+	}
+
+	link := a.Get("url").Str()
+	urlList := []map[string]string{{"title": "Last.fm", "url": link}}
+	var similar []string
+	a.Get("similar.artist").EachObjxMap(func(i int, v objx.Map) bool {
+		similar = append(similar, v.Get("name").Str())
+		urlList = append(urlList, map[string]string{"title": v.Get("name").Str(), "url": v.Get("url").Str()})
+		return len(similar) < lastFMMaxSimilar
+	})
+	if len(similar) > 0 {
+		lines = append(lines, "Similar: "+strings.Join(similar, ", "))
+	}
+
+	return &result.InfoBox{
+		Title:   a.Get("name").Str(),
+		Content: strings.Join(lines, "\n"),
+		ImgSrc:  lastFMImage(a, "extralarge"),
+		Url:     link,
+		UrlList: urlList,
+	}, nil
+}
+
+func (l *lastFM) GetName() string {
+	return EngineNameLastFM
+}
+
+func (l *lastFM) ApplyConfig(conf engine.Config) error {
+	l.client = network.NewClient(conf.Client)
+
+	var c *LastFMConfig
+	if err := mapstructure.Decode(conf.Extra, &c); err != nil {
+		return err
+	}
+
+	// the engine disables itself without an api key.
+	if c == nil || c.ApiKey == "" {
+		return errors.New("api key of lastfm is required")
+	}
+	l.apiKey = c.ApiKey
+	return nil
+}
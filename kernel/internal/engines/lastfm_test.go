@@ -0,0 +1,138 @@
+package engines
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/zvirgilx/searxng-go/kernel/internal/engine"
+	"github.com/zvirgilx/searxng-go/kernel/internal/network"
+)
+
+func newTestLastFM(t *testing.T) *lastFM {
+	t.Helper()
+	e := &lastFM{cache: map[string]lastFMCacheEntry{}}
+	if err := e.ApplyConfig(engine.Config{Client: &network.Config{}, Extra: map[string]interface{}{"api_key": "key"}}); err != nil {
+		t.Fatal(err)
+	}
+	return e
+}
+
+func TestLastFMResponse(t *testing.T) {
+	res := parseFixture(t, newTestLastFM(t), engine.Options{Query: "artist radiohead", PageNo: 1, Locale: "en-US"}, "lastfm/search.json")
+	assertGolden(t, "lastfm/search.golden.json", res)
+
+	data := res.GetData()
+	if len(data) != 2 {
+		t.Fatalf("got %d data, want 2, the artist without name is skipped", len(data))
+	}
+	if data[0].Content != "6M listeners" {
+		t.Errorf("content = %q", data[0].Content)
+	}
+	if data[1].Thumbnail != "" {
+		t.Errorf("thumbnail of artist without images = %q", data[1].Thumbnail)
+	}
+}
+
+func TestLastFMRequest(t *testing.T) {
+	e := newTestLastFM(t)
+	opts := engine.Options{Query: "Artist Radiohead", PageNo: 2}
+	if err := e.Request(context.Background(), &opts); err != nil {
+		t.Fatal(err)
+	}
+	q := opts.Request.URL().Query()
+	if q.Get("artist") != "Radiohead" || q.Get("method") != "artist.search" || q.Get("page") != "2" || q.Get("api_key") != "key" {
+		t.Errorf("unexpected request %s", opts.Request.URL())
+	}
+}
+
+func TestLastFMInfoBox(t *testing.T) {
+	e := newTestLastFM(t)
+	opts := engine.Options{Query: "radiohead", PageNo: 1, Locale: "en-US"}
+	res := parseFixture(t, e, opts, "lastfm/search.json")
+
+	// the info of the top artist is followed up.
+	if err := e.FollowUp(context.Background(), &opts, res, readFixture(t, "lastfm/search.json")); err != nil {
+		t.Fatal(err)
+	}
+	if opts.Request == nil {
+		t.Fatal("the artist info is not requested")
+	}
+	if q := opts.Request.URL().Query(); q.Get("method") != "artist.getinfo" || q.Get("artist") != "Radiohead" || q.Get("lang") != "en" {
+		t.Fatalf("unexpected request %s", opts.Request.URL())
+	}
+	if err := e.FollowUpResponse(context.Background(), &opts, res, readFixture(t, "lastfm/artist.json")); err != nil {
+		t.Fatal(err)
+	}
+
+	box := res.InfoBox
+	if box == nil || box.Title != "Radiohead" || box.ImgSrc != "https://lastfm.freetls.fastly.net/i/u/300x300/radiohead.png" {
+		t.Fatalf("unexpected infobox %+v", box)
+	}
+	for _, line := range []string{
+		"Radiohead are an English rock band formed in Abingdon, Oxfordshire, in 1985.",
+		"Listeners: 6M, Scrobbles: 812.3M",
+		"Tags: alternative, rock, alternative rock, indie, electronic",
+		"Similar: Thom Yorke, Atoms for Peace, The Smile, Muse, Portishead",
+	} {
+		if !strings.Contains(box.Content, line) {
+			t.Errorf("content %q does not contain %q", box.Content, line)
+		}
+	}
+	if strings.Contains(box.Content, "Read more") || strings.Contains(box.Content, "british") || strings.Contains(box.Content, "Massive Attack") {
+		t.Errorf("content = %q", box.Content)
+	}
+
+	// the cached info is used without requesting.
+	opts = engine.Options{Query: "radiohead", PageNo: 1, Locale: "en-US"}
+	res = parseFixture(t, e, opts, "lastfm/search.json")
+	if err := e.FollowUp(context.Background(), &opts, res, nil); err != nil {
+		t.Fatal(err)
+	}
+	if opts.Request != nil || res.InfoBox == nil || res.InfoBox.Title != "Radiohead" {
+		t.Errorf("the cached info is not used, request %v, infobox %+v", opts.Request, res.InfoBox)
+	}
+}
+
+func TestLastFMInfoBoxNotFound(t *testing.T) {
+	e := newTestLastFM(t)
+	opts := engine.Options{Query: "radiohead", PageNo: 1}
+	res := parseFixture(t, e, opts, "lastfm/search.json")
+
+	// the results are kept without infobox.
+	if err := e.FollowUpResponse(context.Background(), &opts, res, []byte(`{"error":6,"message":"The artist you supplied could not be found"}`)); err != nil {
+		t.Fatal(err)
+	}
+	if res.InfoBox != nil || res.GetDataSize() != 2 {
+		t.Errorf("infobox %+v, %d data", res.InfoBox, res.GetDataSize())
+	}
+
+	// no info is requested beyond the first page.
+	opts.PageNo = 2
+	if err := e.FollowUp(context.Background(), &opts, res, nil); err != nil || opts.Request != nil {
+		t.Errorf("the artist info is requested on page 2, err %v", err)
+	}
+}
+
+func TestLastFMRequiresApiKey(t *testing.T) {
+	for _, extra := range []map[string]interface{}{nil, {"api_key": ""}} {
+		e := &lastFM{cache: map[string]lastFMCacheEntry{}}
+		if err := e.ApplyConfig(engine.Config{Client: &network.Config{}, Extra: extra}); err == nil {
+			t.Errorf("the engine is enabled without api key, extra %v", extra)
+		}
+	}
+}
+
+func TestLastFMThumbnailSize(t *testing.T) {
+	cases := map[string]string{
+		engine.ThumbnailSizeSmall: "https://lastfm.freetls.fastly.net/i/u/64s/radiohead.png",
+		"":                        "https://lastfm.freetls.fastly.net/i/u/174s/radiohead.png",
+		engine.ThumbnailSizeLarge: "https://lastfm.freetls.fastly.net/i/u/300x300/radiohead.png",
+	}
+	for size, want := range cases {
+		opts := engine.Options{Query: "artist radiohead", PageNo: 1, ThumbnailSize: size}
+		if got := parseFixture(t, newTestLastFM(t), opts, "lastfm/search.json").GetData()[0].Thumbnail; got != want {
+			t.Errorf("thumbnail of size %q = %s, want %s", size, got, want)
+		}
+	}
+}
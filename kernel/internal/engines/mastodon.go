@@ -0,0 +1,216 @@
+package engines
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/mitchellh/mapstructure"
+	"github.com/stretchr/objx"
+	"github.com/zvirgilx/searxng-go/kernel/internal/engine"
+	"github.com/zvirgilx/searxng-go/kernel/internal/network"
+	"github.com/zvirgilx/searxng-go/kernel/internal/result"
+)
+
+const (
+	EngineNameMastodon = "mastodon"
+
+	mastodonPageSize = 20
+
+	// mastodon allows 300 requests per 5 minutes by default.
+	mastodonRateLimitWindow = 5 * time.Minute
+	mastodonRateLimit       = 300
+)
+
+var errMastodonRateLimited = errors.New("mastodon rate limit exceeded")
+
+type mastodon struct {
+	client *network.Client
+
+	baseUrl   string
+	token     string
+	rateLimit int
+
+	// the requests are counted in the current rate limit window.
+	mu          sync.Mutex
+	windowStart time.Time
+	requests    int
+}
+
+type MastodonConfig struct {
+	BaseUrl   string `mapstructure:"base_url"`   // BaseUrl is the url of mastodon instance, e.g. https://mastodon.social.
+	Token     string `mapstructure:"token"`      // Token is the optional access token, some instances require it for statuses search.
+	RateLimit int    `mapstructure:"rate_limit"` // RateLimit is the maximum number of requests per 5 minutes.
+}
+
+func init() {
+	engine.RegisterGlobalEngine(&mastodon{
+		client:    network.DefaultClient(),
+		baseUrl:   "https://mastodon.social",
+		rateLimit: mastodonRateLimit,
+	}, engine.CategorySocial)
+}
+
+func (m *mastodon) Request(ctx context.Context, opts *engine.Options) error {
+	if !m.allow() {
+		slog.WarnContext(ctx, "mastodon rate limit exceeded, skip request", slog.String("baseUrl", m.baseUrl))
+		return nil
+	}
+
+	base, err := url.Parse(m.baseUrl)
+	if err != nil {
+		return err
+	}
+
+	// example: https://mastodon.social/api/v2/search?q=test&type=statuses&limit=20&offset=0
+	req := m.client.Get().Base(base).Path("api/v2/search").
+		Param("q", opts.Query).
+		Param("type", "statuses").
+		Param("limit", strconv.Itoa(mastodonPageSize)).
+		Param("offset", strconv.Itoa((opts.PageNo-1)*mastodonPageSize))
+
+	if m.token != "" {
+		req.Header("Authorization", "Bearer "+m.token)
+	}
+
+	opts.Request = req
+	return nil
+}
+
+// Trending requests the statuses trending on the instance.
+// The rate limit is reported as an error, since the trending search has no other result to fall back on.
+func (m *mastodon) Trending(ctx context.Context, opts *engine.Options) error {
+	if !m.allow() {
+		return errMastodonRateLimited
+	}
+
+	base, err := url.Parse(m.baseUrl)
+	if err != nil {
+		return err
+	}
+
+	// example: https://mastodon.social/api/v1/trends/statuses?limit=20&offset=0
+	req := m.client.Get().Base(base).Path("api/v1/trends/statuses").
+		Param("limit", strconv.Itoa(mastodonPageSize)).
+		Param("offset", strconv.Itoa((opts.PageNo-1)*mastodonPageSize))
+
+	if m.token != "" {
+		req.Header("Authorization", "Bearer "+m.token)
+	}
+
+	opts.Request = req
+	return nil
+}
+
+// allow reports whether a request is allowed in the current rate limit window.
+func (m *mastodon) allow() bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := time.Now()
+	if now.Sub(m.windowStart) > mastodonRateLimitWindow {
+		m.windowStart = now
+		m.requests = 0
+	}
+	if m.requests >= m.rateLimit {
+		return false
+	}
+	m.requests++
+	return true
+}
+
+func (m *mastodon) Response(ctx context.Context, opts *engine.Options, resp []byte) (*result.Result, error) {
+	log := slog.With("func", "mastodon.Response")
+
+	// the trending statuses are an array instead of the statuses of search.
+	body := string(resp)
+	if opts.Query == "" {
+		body = `{"statuses":` + body + `}`
+	}
+
+	data, err := objx.FromJSON(body)
+	if err != nil {
+		log.ErrorContext(ctx, "failed to parse mastodon response", slog.String("err", err.Error()))
+		return nil, err
+	}
+
+	res := result.CreateResult(EngineNameMastodon, opts.PageNo)
+	data.Get("statuses").EachObjxMap(func(i int, v objx.Map) bool {
+		link := v.Get("url").Str()
+		if link == "" {
+			return true
+		}
+
+		account := v.Get("account").ObjxMap()
+		title := "@" + account.Get("acct").Str()
+		if name := account.Get("display_name").Str(); name != "" {
+			title = fmt.Sprintf("%s (%s)", name, title)
+		}
+
+		var thumbnail string
+		if media := v.Get("media_attachments").ObjxMapSlice(); len(media) > 0 {
+			thumbnail = media[0].Get("preview_url").Str()
+		}
+
+		publishedDate, _ := time.Parse(time.RFC3339, v.Get("created_at").Str())
+
+		res.AppendData(&result.Data{
+			Engine:        EngineNameMastodon,
+			Title:         title,
+			Url:           link,
+			Content:       htmlToText(v.Get("content").Str()),
+			Thumbnail:     thumbnail,
+			PublishedDate: publishedDate,
+			Query:         opts.Query,
+		})
+		return true
+	})
+
+	return res, nil
+}
+
+// htmlToText strips the html tags and returns the text of html.
+func htmlToText(html string) string {
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(html))
+	if err != nil {
+		return html
+	}
+
+	// paragraphs and line breaks are separated by spaces.
+	doc.Find("br, p").Each(func(i int, s *goquery.Selection) {
+		s.AfterHtml(" ")
+	})
+	return strings.Join(strings.Fields(doc.Text()), " ")
+}
+
+func (m *mastodon) GetName() string {
+	return EngineNameMastodon
+}
+
+func (m *mastodon) ApplyConfig(conf engine.Config) error {
+	m.client = network.NewClient(conf.Client)
+
+	var c *MastodonConfig
+	if err := mapstructure.Decode(conf.Extra, &c); err != nil {
+		return err
+	}
+	if c == nil {
+		return nil
+	}
+
+	if c.BaseUrl != "" {
+		m.baseUrl = c.BaseUrl
+	}
+	if c.RateLimit > 0 {
+		m.rateLimit = c.RateLimit
+	}
+	m.token = c.Token
+	return nil
+}
@@ -0,0 +1,79 @@
+package engines
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/zvirgilx/searxng-go/kernel/internal/engine"
+	"github.com/zvirgilx/searxng-go/kernel/internal/network"
+)
+
+func TestMastodonResponse(t *testing.T) {
+	res := parseFixture(t, &mastodon{}, engine.Options{Query: "golang", PageNo: 1}, "mastodon/search.json")
+	assertGolden(t, "mastodon/search.golden.json", res)
+
+	data := res.GetData()
+	if len(data) != 2 {
+		t.Fatalf("got %d data, want 2, the status without url is skipped", len(data))
+	}
+	// the html of status is stripped, paragraphs and line breaks are separated by spaces.
+	if want := "Go 1.22 is out! #golang Range over int & loop vars are finally fixed."; data[0].Content != want {
+		t.Errorf("content = %q, want %q", data[0].Content, want)
+	}
+	if data[0].Title != "Gopher (@gopher)" || data[1].Title != "@dev@fosstodon.org" {
+		t.Errorf("unexpected titles %q, %q", data[0].Title, data[1].Title)
+	}
+	if want := time.Date(2024, 5, 20, 8, 15, 30, 0, time.UTC); !data[0].PublishedDate.Equal(want) {
+		t.Errorf("published date = %v, want %v", data[0].PublishedDate, want)
+	}
+}
+
+func TestMastodonRequest(t *testing.T) {
+	m := &mastodon{client: network.DefaultClient(), rateLimit: 1}
+	if err := m.ApplyConfig(engine.Config{Extra: map[string]interface{}{"base_url": "https://fosstodon.org", "token": "secret", "rate_limit": 1}}); err != nil {
+		t.Fatal(err)
+	}
+
+	opts := engine.Options{Query: "golang", PageNo: 2}
+	if err := m.Request(context.Background(), &opts); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := opts.Request.URL().String(), "https://fosstodon.org/api/v2/search?limit=20&offset=20&q=golang&type=statuses"; got != want {
+		t.Errorf("url = %s, want %s", got, want)
+	}
+
+	// the requests beyond the rate limit are skipped.
+	opts = engine.Options{Query: "golang", PageNo: 1}
+	if err := m.Request(context.Background(), &opts); err != nil {
+		t.Fatal(err)
+	}
+	if opts.Request != nil {
+		t.Error("the request beyond the rate limit is sent")
+	}
+}
+
+func TestMastodonTrending(t *testing.T) {
+	m := &mastodon{client: network.DefaultClient(), baseUrl: "https://mastodon.social", rateLimit: 1}
+
+	opts := engine.Options{PageNo: 1}
+	if err := m.Trending(context.Background(), &opts); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := opts.Request.URL().String(), "https://mastodon.social/api/v1/trends/statuses?limit=20&offset=0"; got != want {
+		t.Errorf("url = %s, want %s", got, want)
+	}
+
+	// the trending statuses are an array, parsed like the statuses of search.
+	res := parseFixture(t, m, opts, "mastodon/trending.json")
+	if n := res.GetDataSize(); n != 2 {
+		t.Errorf("got %d trending data, want 2", n)
+	}
+
+	// the trending request beyond the rate limit fails rather than returning nothing.
+	opts = engine.Options{PageNo: 1}
+	if err := m.Trending(context.Background(), &opts); !errors.Is(err, errMastodonRateLimited) {
+		t.Errorf("err = %v, want rate limited", err)
+	}
+}
@@ -0,0 +1,147 @@
+package engines
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mitchellh/mapstructure"
+	"github.com/stretchr/objx"
+	"github.com/zvirgilx/searxng-go/kernel/internal/engine"
+	"github.com/zvirgilx/searxng-go/kernel/internal/network"
+	"github.com/zvirgilx/searxng-go/kernel/internal/result"
+)
+
+const (
+	EngineNameNewsAPI = "newsapi"
+
+	newsAPIUrl      = "https://newsapi.org"
+	newsAPIPageSize = 20
+)
+
+// newsAPI searches the top headlines of news api, the headlines of region are trending without query.
+type newsAPI struct {
+	client *network.Client
+
+	apiKey  string
+	country string
+}
+
+type NewsAPIConfig struct {
+	ApiKey  string `mapstructure:"api_key"` // ApiKey is required by news api, the engine is disabled without it.
+	Country string `mapstructure:"country"` // Country is the default region of headlines if the locale has none, e.g. us.
+}
+
+func init() {
+	engine.RegisterGlobalEngine(&newsAPI{client: network.DefaultClient(), country: "us"}, engine.CategoryNews)
+}
+
+func (n *newsAPI) Capabilities() engine.Capabilities {
+	return engine.Capabilities{
+		Categories:  []string{engine.CategoryNews},
+		Paging:      true,
+		Language:    true,
+		ContentType: "application/json",
+	}
+}
+
+func (n *newsAPI) Request(ctx context.Context, opts *engine.Options) error {
+	opts.Request = n.topHeadlines(opts).Param("q", opts.Query)
+	return nil
+}
+
+// Trending requests the top headlines of the region of locale.
+func (n *newsAPI) Trending(ctx context.Context, opts *engine.Options) error {
+	opts.Request = n.topHeadlines(opts)
+	return nil
+}
+
+// topHeadlines builds the request of top headlines without query.
+func (n *newsAPI) topHeadlines(opts *engine.Options) *network.Request {
+	// example: https://newsapi.org/v2/top-headlines?country=us&pageSize=20&page=1
+	base, _ := url.Parse(newsAPIUrl)
+	req := n.client.Get().Base(base).Path("v2/top-headlines").
+		Param("pageSize", strconv.Itoa(newsAPIPageSize)).
+		Param("page", strconv.Itoa(opts.PageNo)).
+		Header("X-Api-Key", n.apiKey)
+
+	// the headlines of region, e.g. en-US -> us.
+	country := n.country
+	if _, region, ok := strings.Cut(opts.Locale, "-"); ok {
+		country = strings.ToLower(region)
+	}
+	if country != "" {
+		req.Param("country", country)
+	}
+	return req
+}
+
+func (n *newsAPI) Response(ctx context.Context, opts *engine.Options, resp []byte) (*result.Result, error) {
+	log := slog.With("func", "newsapi.Response")
+
+	m, err := objx.FromJSON(string(resp))
+	if err != nil {
+		log.ErrorContext(ctx, "failed to parse newsapi response", slog.String("err", err.Error()))
+		return nil, err
+	}
+	// e.g. {"status":"error","code":"apiKeyInvalid","message":"Your API key is invalid or incorrect."}
+	if m.Get("status").Str() == "error" {
+		return nil, fmt.Errorf("newsapi error: %s: %s", m.Get("code").Str(), m.Get("message").Str())
+	}
+
+	res := result.CreateResult(EngineNameNewsAPI, opts.PageNo)
+	lang, _, _ := strings.Cut(opts.Locale, "-")
+	m.Get("articles").EachObjxMap(func(i int, v objx.Map) bool {
+		title := strings.TrimSpace(v.Get("title").Str())
+		link := v.Get("url").Str()
+		// the removed articles are kept with placeholders, e.g. {"title":"[Removed]","url":"https://removed.com"}.
+		if title == "" || link == "" || title == "[Removed]" {
+			return true
+		}
+
+		publishedDate, _ := time.Parse(time.RFC3339, v.Get("publishedAt").Str())
+		author := v.Get("source.name").Str(v.Get("author").Str())
+
+		res.AppendData(&result.Data{
+			Engine:        EngineNameNewsAPI,
+			Title:         title,
+			Url:           link,
+			Content:       engine.DatedContent(publishedDate, strings.TrimSpace(v.Get("description").Str()), lang),
+			Thumbnail:     v.Get("urlToImage").Str(),
+			Author:        author,
+			PublishedDate: publishedDate,
+			Query:         opts.Query,
+		})
+		return true
+	})
+
+	return res, nil
+}
+
+func (n *newsAPI) GetName() string {
+	return EngineNameNewsAPI
+}
+
+func (n *newsAPI) ApplyConfig(conf engine.Config) error {
+	n.client = network.NewClient(conf.Client)
+
+	var c *NewsAPIConfig
+	if err := mapstructure.Decode(conf.Extra, &c); err != nil {
+		return err
+	}
+
+	// the engine disables itself without an api key.
+	if c == nil || c.ApiKey == "" {
+		return errors.New("api key of newsapi is required")
+	}
+	n.apiKey = c.ApiKey
+	if c.Country != "" {
+		n.country = strings.ToLower(c.Country)
+	}
+	return nil
+}
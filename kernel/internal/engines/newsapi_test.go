@@ -0,0 +1,103 @@
+package engines
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/zvirgilx/searxng-go/kernel/internal/engine"
+	"github.com/zvirgilx/searxng-go/kernel/internal/network"
+)
+
+func newTestNewsAPI(t *testing.T, extra map[string]interface{}) *newsAPI {
+	t.Helper()
+	n := &newsAPI{country: "us"}
+	if err := n.ApplyConfig(engine.Config{Client: &network.Config{}, Extra: extra}); err != nil {
+		t.Fatal(err)
+	}
+	return n
+}
+
+func TestNewsAPIResponse(t *testing.T) {
+	res := parseFixture(t, newTestNewsAPI(t, map[string]interface{}{"api_key": "key"}), engine.Options{Query: "go", PageNo: 1}, "newsapi/headlines.json")
+	assertGolden(t, "newsapi/headlines.golden.json", res)
+
+	data := res.GetData()
+	if len(data) != 2 {
+		t.Fatalf("got %d data, want 2, the removed articles and the ones without url are skipped", len(data))
+	}
+	// the source is the author of article, the content is dated.
+	if data[0].Title != "Go 1.22 is released" || data[0].Author != "The Verge" || data[0].Content != "Feb 6, 2024 - The new release of go brings range over integers." {
+		t.Errorf("unexpected data %+v", data[0])
+	}
+	if want := time.Date(2024, 2, 6, 18, 0, 0, 0, time.UTC); !data[0].PublishedDate.Equal(want) {
+		t.Errorf("published date = %v, want %v", data[0].PublishedDate, want)
+	}
+	// the author is used without the name of source, the invalid date is left out.
+	if data[1].Author != "John Roe" || data[1].Content != "" || !data[1].PublishedDate.IsZero() {
+		t.Errorf("unexpected data %+v", data[1])
+	}
+}
+
+func TestNewsAPIResponseError(t *testing.T) {
+	body := []byte(`{"status":"error","code":"apiKeyInvalid","message":"Your API key is invalid or incorrect."}`)
+	_, err := newTestNewsAPI(t, map[string]interface{}{"api_key": "key"}).Response(context.Background(), &engine.Options{PageNo: 1}, body)
+	if err == nil || err.Error() != "newsapi error: apiKeyInvalid: Your API key is invalid or incorrect." {
+		t.Errorf("err = %v", err)
+	}
+}
+
+func TestNewsAPIRequest(t *testing.T) {
+	cases := map[string]struct {
+		extra    map[string]interface{}
+		locale   string
+		trending bool
+		want     string
+	}{
+		"query": {
+			extra: map[string]interface{}{"api_key": "key"},
+			want:  "https://newsapi.org/v2/top-headlines?country=us&page=2&pageSize=20&q=go",
+		},
+		"region of locale": {
+			extra:  map[string]interface{}{"api_key": "key", "country": "GB"},
+			locale: "de-DE",
+			want:   "https://newsapi.org/v2/top-headlines?country=de&page=2&pageSize=20&q=go",
+		},
+		"configured country": {
+			extra:  map[string]interface{}{"api_key": "key", "country": "GB"},
+			locale: "en",
+			want:   "https://newsapi.org/v2/top-headlines?country=gb&page=2&pageSize=20&q=go",
+		},
+		"trending": {
+			extra:    map[string]interface{}{"api_key": "key"},
+			trending: true,
+			want:     "https://newsapi.org/v2/top-headlines?country=us&page=2&pageSize=20",
+		},
+	}
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			n := newTestNewsAPI(t, c.extra)
+			opts := engine.Options{Query: "go", PageNo: 2, Locale: c.locale}
+			request := n.Request
+			if c.trending {
+				request = n.Trending
+			}
+			if err := request(context.Background(), &opts); err != nil {
+				t.Fatal(err)
+			}
+			if got := opts.Request.URL().String(); got != c.want {
+				t.Errorf("url = %s, want %s", got, c.want)
+			}
+		})
+	}
+}
+
+func TestNewsAPIApiKeyRequired(t *testing.T) {
+	for name, extra := range map[string]map[string]interface{}{"nil": nil, "empty": {"api_key": ""}} {
+		t.Run(name, func(t *testing.T) {
+			if err := (&newsAPI{}).ApplyConfig(engine.Config{Client: &network.Config{}, Extra: extra}); err == nil {
+				t.Error("the engine is configured without api key")
+			}
+		})
+	}
+}
@@ -0,0 +1,106 @@
+package engines
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/stretchr/objx"
+	"github.com/zvirgilx/searxng-go/kernel/internal/engine"
+	"github.com/zvirgilx/searxng-go/kernel/internal/network"
+	"github.com/zvirgilx/searxng-go/kernel/internal/result"
+)
+
+const (
+	EngineNameOpenLibrary = "openlibrary"
+
+	openLibraryBaseUrl = "https://openlibrary.org"
+	// the cover size could be S, M or L.
+	openLibraryCoverUrl = "https://covers.openlibrary.org/b/id/%d-%s.jpg"
+)
+
+type openLibrary struct {
+	client *network.Client
+}
+
+func init() {
+	engine.RegisterGlobalEngine(&openLibrary{client: network.DefaultClient()}, engine.CategoryBooks)
+}
+
+func (o *openLibrary) Request(ctx context.Context, opts *engine.Options) error {
+	// example: https://openlibrary.org/search.json?q=test&page=1
+	base, _ := url.Parse(openLibraryBaseUrl)
+	opts.Request = o.client.Get().Base(base).Path("search.json").
+		Param("q", opts.Query).
+		Param("page", strconv.Itoa(opts.PageNo)).
+		Param("fields", "key,title,author_name,first_publish_year,cover_i")
+	return nil
+}
+
+func (o *openLibrary) Response(ctx context.Context, opts *engine.Options, resp []byte) (*result.Result, error) {
+	log := slog.With("func", "openLibrary.Response")
+
+	m, err := objx.FromJSON(string(resp))
+	if err != nil {
+		log.ErrorContext(ctx, "failed to parse openlibrary response", slog.String("err", err.Error()))
+		return nil, err
+	}
+
+	res := result.CreateResult(EngineNameOpenLibrary, opts.PageNo)
+	m.Get("docs").EachObjxMap(func(i int, v objx.Map) bool {
+		title := v.Get("title").Str()
+		key := v.Get("key").Str()
+		if title == "" || key == "" {
+			return true
+		}
+
+		var authors []string
+		for _, a := range v.Get("author_name").InterSlice() {
+			if name, ok := a.(string); ok {
+				authors = append(authors, name)
+			}
+		}
+		author := strings.Join(authors, ", ")
+
+		var publishedDate time.Time
+		content := author
+		if year := v.Get("first_publish_year").Int(); year > 0 {
+			publishedDate = time.Date(year, time.January, 1, 0, 0, 0, 0, time.UTC)
+			content = fmt.Sprintf("%s - %d", author, year)
+		}
+
+		// books lacking a cover have no cover_i.
+		var thumbnail string
+		if cover := v.Get("cover_i").Int(); cover > 0 {
+			// the sizes of cover are S, M and L.
+			thumbnail = fmt.Sprintf(openLibraryCoverUrl, cover, engine.ThumbnailToken(opts, "S", "M", "L"))
+		}
+
+		res.AppendData(&result.Data{
+			Engine:        EngineNameOpenLibrary,
+			Title:         title,
+			Url:           openLibraryBaseUrl + key,
+			Content:       strings.TrimPrefix(content, " - "),
+			Thumbnail:     thumbnail,
+			Author:        author,
+			PublishedDate: publishedDate,
+			Query:         opts.Query,
+		})
+		return true
+	})
+
+	return res, nil
+}
+
+func (o *openLibrary) GetName() string {
+	return EngineNameOpenLibrary
+}
+
+func (o *openLibrary) ApplyConfig(conf engine.Config) error {
+	o.client = network.NewClient(conf.Client)
+	return nil
+}
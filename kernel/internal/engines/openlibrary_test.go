@@ -0,0 +1,45 @@
+package engines
+
+import (
+	"testing"
+	"time"
+
+	"github.com/zvirgilx/searxng-go/kernel/internal/engine"
+)
+
+func TestOpenLibraryResponse(t *testing.T) {
+	res := parseFixture(t, &openLibrary{}, engine.Options{Query: "fantasy", PageNo: 1}, "openlibrary/search.json")
+	assertGolden(t, "openlibrary/search.golden.json", res)
+
+	data := res.GetData()
+	if len(data) != 3 {
+		t.Fatalf("got %d data, want 3, the book without key is skipped", len(data))
+	}
+
+	// the cover url is built by cover_i, the medium size is the default.
+	if want := "https://covers.openlibrary.org/b/id/14625765-M.jpg"; data[0].Thumbnail != want {
+		t.Errorf("cover = %s, want %s", data[0].Thumbnail, want)
+	}
+	if data[0].Url != "https://openlibrary.org/works/OL27448W" || data[0].Content != "J.R.R. Tolkien - 1954" {
+		t.Errorf("unexpected %+v", data[0])
+	}
+	if want := time.Date(1954, 1, 1, 0, 0, 0, 0, time.UTC); !data[0].PublishedDate.Equal(want) {
+		t.Errorf("published date = %v, want %v", data[0].PublishedDate, want)
+	}
+
+	// the books lacking a cover have no thumbnail.
+	if data[1].Thumbnail != "" || data[1].Author != "Terry Pratchett, Neil Gaiman" {
+		t.Errorf("unexpected %+v", data[1])
+	}
+	if data[2].Content != "" || !data[2].PublishedDate.IsZero() {
+		t.Errorf("unexpected book without author and year %+v", data[2])
+	}
+}
+
+func TestOpenLibraryCoverSize(t *testing.T) {
+	opts := engine.Options{Query: "fantasy", PageNo: 1, ThumbnailSize: engine.ThumbnailSizeLarge}
+	res := parseFixture(t, &openLibrary{}, opts, "openlibrary/search.json")
+	if want := "https://covers.openlibrary.org/b/id/14625765-L.jpg"; res.GetData()[0].Thumbnail != want {
+		t.Errorf("cover = %s, want %s", res.GetData()[0].Thumbnail, want)
+	}
+}
@@ -0,0 +1,177 @@
+package engines
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mitchellh/mapstructure"
+	"github.com/stretchr/objx"
+	"github.com/zvirgilx/searxng-go/kernel/internal/engine"
+	"github.com/zvirgilx/searxng-go/kernel/internal/network"
+	"github.com/zvirgilx/searxng-go/kernel/internal/result"
+)
+
+const (
+	EngineNamePodcasts = "podcasts"
+
+	iTunesApiUrl   = "https://itunes.apple.com"
+	iTunesPageSize = 20
+
+	// the entities of podcast search, the shows or their episodes.
+	podcastEntityShow    = "podcast"
+	podcastEntityEpisode = "podcastEpisode"
+)
+
+// podcasts searches the podcasts by the itunes search api.
+type podcasts struct {
+	client *network.Client
+
+	entity string
+}
+
+type PodcastsConfig struct {
+	Entity string `mapstructure:"entity"` // Entity is podcast to search shows, or podcastEpisode to search episodes.
+}
+
+func init() {
+	engine.RegisterGlobalEngine(&podcasts{client: network.DefaultClient(), entity: podcastEntityShow}, engine.CategoryMusic)
+}
+
+func (p *podcasts) Capabilities() engine.Capabilities {
+	return engine.Capabilities{
+		Categories: []string{engine.CategoryMusic},
+		Paging:     true,
+		Language:   true,
+	}
+}
+
+func (p *podcasts) Request(ctx context.Context, opts *engine.Options) error {
+	// example: https://itunes.apple.com/search?term=test&media=podcast&entity=podcast&limit=20&offset=0&country=US
+	base, _ := url.Parse(iTunesApiUrl)
+	req := p.client.Get().Base(base).Path("search").
+		Param("term", opts.Query).
+		Param("media", "podcast").
+		Param("entity", p.entity).
+		Param("limit", strconv.Itoa(iTunesPageSize)).
+		Param("offset", strconv.Itoa((opts.PageNo-1)*iTunesPageSize))
+
+	// the store of country is searched, e.g. en-US -> US.
+	if _, region, ok := strings.Cut(opts.Locale, "-"); ok {
+		req.Param("country", region)
+	}
+
+	opts.Request = req
+	return nil
+}
+
+func (p *podcasts) Response(ctx context.Context, opts *engine.Options, resp []byte) (*result.Result, error) {
+	log := slog.With("func", "podcasts.Response")
+
+	m, err := objx.FromJSON(string(resp))
+	if err != nil {
+		log.ErrorContext(ctx, "failed to parse itunes response", slog.String("err", err.Error()))
+		return nil, err
+	}
+	// e.g. {"errorMessage":"Invalid value(s) for key(s): [offset]"}
+	if msg := m.Get("errorMessage").Str(); msg != "" {
+		return nil, fmt.Errorf("itunes error: %s", msg)
+	}
+
+	res := result.CreateResult(EngineNamePodcasts, opts.PageNo)
+	m.Get("results").EachObjxMap(func(i int, v objx.Map) bool {
+		// the episodes have track names and urls, the shows have collection ones, the feed is the last resort.
+		title := v.Get("trackName").Str(v.Get("collectionName").Str())
+		link := v.Get("trackViewUrl").Str(v.Get("collectionViewUrl").Str(v.Get("feedUrl").Str()))
+		if title == "" || link == "" {
+			return true
+		}
+
+		var parts []string
+		if v.Get("kind").Str() == "podcast-episode" {
+			parts = append(parts, v.Get("collectionName").Str())
+		} else {
+			parts = append(parts, v.Get("artistName").Str())
+		}
+		parts = append(parts, v.Get("primaryGenreName").Str())
+		if count := v.Get("trackCount").Int(); count > 0 {
+			parts = append(parts, fmt.Sprintf("%d episodes", count))
+		}
+		if description := strings.TrimSpace(v.Get("description").Str()); description != "" {
+			parts = append(parts, description)
+		}
+
+		var content []string
+		for _, part := range parts {
+			if part != "" {
+				content = append(content, part)
+			}
+		}
+
+		publishedDate, _ := time.Parse(time.RFC3339, v.Get("releaseDate").Str())
+
+		res.AppendData(&result.Data{
+			Engine:          EngineNamePodcasts,
+			Title:           title,
+			Url:             link,
+			Content:         strings.Join(content, " - "),
+			Thumbnail:       iTunesArtwork(v, engine.ThumbnailToken(opts, "artworkUrl60", "artworkUrl100", "artworkUrl600")),
+			Author:          v.Get("artistName").Str(),
+			PublishedDate:   publishedDate,
+			DurationSeconds: v.Get("trackTimeMillis").Int() / 1000,
+			PreviewUrl:      v.Get("episodeUrl").Str(),
+			Query:           opts.Query,
+		})
+		return true
+	})
+
+	return res, nil
+}
+
+// iTunesArtworkSizes are the keys of artworks from small to large.
+var iTunesArtworkSizes = []string{"artworkUrl30", "artworkUrl60", "artworkUrl100", "artworkUrl160", "artworkUrl600"}
+
+// iTunesArtwork gets the artwork in the preferred size,
+// the nearest larger one is used if it is missing, then the largest one.
+func iTunesArtwork(v objx.Map, preferred string) string {
+	var largest string
+	found := false
+	for _, size := range iTunesArtworkSizes {
+		found = found || size == preferred
+		src := v.Get(size).Str()
+		if src == "" {
+			continue
+		}
+		if found {
+			return src
+		}
+		largest = src
+	}
+	return largest
+}
+
+func (p *podcasts) GetName() string {
+	return EngineNamePodcasts
+}
+
+func (p *podcasts) ApplyConfig(conf engine.Config) error {
+	p.client = network.NewClient(conf.Client)
+
+	var c *PodcastsConfig
+	if err := mapstructure.Decode(conf.Extra, &c); err != nil {
+		return err
+	}
+
+	p.entity = podcastEntityShow
+	if c != nil && c.Entity != "" {
+		if c.Entity != podcastEntityShow && c.Entity != podcastEntityEpisode {
+			return fmt.Errorf("unknown entity of podcasts: %s", c.Entity)
+		}
+		p.entity = c.Entity
+	}
+	return nil
+}
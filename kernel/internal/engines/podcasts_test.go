@@ -0,0 +1,119 @@
+package engines
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/zvirgilx/searxng-go/kernel/internal/engine"
+	"github.com/zvirgilx/searxng-go/kernel/internal/network"
+)
+
+func newTestPodcasts(t *testing.T, extra map[string]interface{}) *podcasts {
+	t.Helper()
+	p := &podcasts{}
+	if err := p.ApplyConfig(engine.Config{Client: &network.Config{}, Extra: extra}); err != nil {
+		t.Fatal(err)
+	}
+	return p
+}
+
+func TestPodcastsResponse(t *testing.T) {
+	res := parseFixture(t, newTestPodcasts(t, nil), engine.Options{Query: "go", PageNo: 1}, "podcasts/shows.json")
+	assertGolden(t, "podcasts/shows.golden.json", res)
+
+	data := res.GetData()
+	if len(data) != 2 {
+		t.Fatalf("got %d data, want 2, the podcast without url is skipped", len(data))
+	}
+	if data[0].Content != "Changelog Media - Technology - 300 episodes" || data[0].Author != "Changelog Media" {
+		t.Errorf("content = %q, author = %q", data[0].Content, data[0].Author)
+	}
+	if want := time.Date(2024, 5, 14, 17, 0, 0, 0, time.UTC); !data[0].PublishedDate.Equal(want) {
+		t.Errorf("published date = %v, want %v", data[0].PublishedDate, want)
+	}
+	// the feed is the url of show without view url, the empty parts of content are left out.
+	if data[1].Url != "https://example.com/gopher/feed" || data[1].Content != "Technology" || !data[1].PublishedDate.IsZero() {
+		t.Errorf("unexpected data %+v", data[1])
+	}
+}
+
+func TestPodcastsEpisodes(t *testing.T) {
+	res := parseFixture(t, newTestPodcasts(t, map[string]interface{}{"entity": "podcastEpisode"}), engine.Options{Query: "go", PageNo: 1}, "podcasts/episodes.json")
+
+	data := res.GetData()
+	if len(data) != 1 {
+		t.Fatalf("got %d data, want 1", len(data))
+	}
+	d := data[0]
+	// the show of episode is in the content in place of the artist.
+	if d.Title != "Generics in Go" || d.Content != "Go Time - Generics landed in Go 1.18." {
+		t.Errorf("title = %q, content = %q", d.Title, d.Content)
+	}
+	if d.DurationSeconds != 3723 || !strings.HasSuffix(d.PreviewUrl, ".mp3") {
+		t.Errorf("duration = %d, preview = %q", d.DurationSeconds, d.PreviewUrl)
+	}
+}
+
+func TestPodcastsArtwork(t *testing.T) {
+	cases := map[string]struct {
+		fixture, size, want string
+	}{
+		"small":                    {fixture: "podcasts/shows.json", size: engine.ThumbnailSizeSmall, want: "https://is1-ssl.mzstatic.com/image/thumb/gotime/60x60bb.jpg"},
+		"medium":                   {fixture: "podcasts/shows.json", want: "https://is1-ssl.mzstatic.com/image/thumb/gotime/100x100bb.jpg"},
+		"large":                    {fixture: "podcasts/shows.json", size: engine.ThumbnailSizeLarge, want: "https://is1-ssl.mzstatic.com/image/thumb/gotime/600x600bb.jpg"},
+		"nearest larger of medium": {fixture: "podcasts/episodes.json", want: "https://is1-ssl.mzstatic.com/image/thumb/episode/160x160bb.jpg"},
+	}
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			opts := engine.Options{Query: "go", PageNo: 1, ThumbnailSize: c.size}
+			if got := parseFixture(t, newTestPodcasts(t, nil), opts, c.fixture).GetData()[0].Thumbnail; got != c.want {
+				t.Errorf("thumbnail = %s, want %s", got, c.want)
+			}
+		})
+	}
+
+	// the largest one is used if none is as large as preferred.
+	opts := engine.Options{Query: "go", PageNo: 1, ThumbnailSize: engine.ThumbnailSizeLarge}
+	res, err := newTestPodcasts(t, nil).Response(context.Background(), &opts, []byte(`{"results": [
+		{"trackName": "Small artwork", "trackViewUrl": "https://example.com/small", "artworkUrl30": "https://example.com/30.jpg", "artworkUrl100": "https://example.com/100.jpg"}]}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := res.GetData()[0].Thumbnail; got != "https://example.com/100.jpg" {
+		t.Errorf("thumbnail = %s, want the largest one", got)
+	}
+}
+
+func TestPodcastsRequest(t *testing.T) {
+	opts := engine.Options{Query: "go", PageNo: 3, Locale: "de-DE"}
+	if err := newTestPodcasts(t, nil).Request(context.Background(), &opts); err != nil {
+		t.Fatal(err)
+	}
+	q := opts.Request.URL().Query()
+	if q.Get("term") != "go" || q.Get("entity") != "podcast" || q.Get("offset") != "40" || q.Get("limit") != "20" || q.Get("country") != "DE" {
+		t.Errorf("unexpected request %s", opts.Request.URL())
+	}
+
+	opts = engine.Options{Query: "go", PageNo: 1, Locale: "de"}
+	if err := newTestPodcasts(t, nil).Request(context.Background(), &opts); err != nil {
+		t.Fatal(err)
+	}
+	if q := opts.Request.URL().Query(); q.Has("country") {
+		t.Errorf("the country is sent without region: %s", opts.Request.URL())
+	}
+}
+
+func TestPodcastsErrors(t *testing.T) {
+	_, err := newTestPodcasts(t, nil).Response(context.Background(), &engine.Options{PageNo: 1},
+		[]byte(`{"errorMessage":"Invalid value(s) for key(s): [offset]"}`))
+	if err == nil || !strings.Contains(err.Error(), "Invalid value(s)") {
+		t.Errorf("err = %v", err)
+	}
+
+	p := &podcasts{}
+	if err := p.ApplyConfig(engine.Config{Client: &network.Config{}, Extra: map[string]interface{}{"entity": "album"}}); err == nil {
+		t.Error("the unknown entity is accepted")
+	}
+}
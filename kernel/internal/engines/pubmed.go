@@ -0,0 +1,197 @@
+package engines
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/mitchellh/mapstructure"
+	"github.com/stretchr/objx"
+	"github.com/zvirgilx/searxng-go/kernel/internal/engine"
+	"github.com/zvirgilx/searxng-go/kernel/internal/network"
+	"github.com/zvirgilx/searxng-go/kernel/internal/result"
+)
+
+const (
+	EngineNamePubMed = "pubmed"
+
+	pubMedBaseUrl  = "https://eutils.ncbi.nlm.nih.gov"
+	pubMedPageSize = 10
+	pubMedHrefBase = "https://pubmed.ncbi.nlm.nih.gov/%s/"
+)
+
+// pubmed searches articles by NCBI E-utilities in two steps,
+// the ids of articles are searched by esearch, then the summaries are fetched by the follow-up of esummary.
+type pubmed struct {
+	client *network.Client
+
+	apiKey string
+
+	// NCBI allows 3 requests per second without api key, and 10 requests per second with api key.
+	mu          sync.Mutex
+	interval    time.Duration
+	lastRequest time.Time
+}
+
+type PubMedConfig struct {
+	ApiKey string `mapstructure:"api_key"` // ApiKey is the optional NCBI api key for a higher rate limit.
+}
+
+func init() {
+	engine.RegisterGlobalEngine(&pubmed{client: network.DefaultClient(), interval: time.Second / 3}, engine.CategoryScience)
+}
+
+func (p *pubmed) Request(ctx context.Context, opts *engine.Options) error {
+	if err := p.wait(ctx); err != nil {
+		return err
+	}
+
+	// example: https://eutils.ncbi.nlm.nih.gov/entrez/eutils/esearch.fcgi?db=pubmed&term=test&retmode=json&retstart=0&retmax=10
+	base, _ := url.Parse(pubMedBaseUrl)
+	opts.Request = p.withApiKey(p.client.Get().Base(base).Path("entrez/eutils/esearch.fcgi").
+		Param("db", "pubmed").
+		Param("term", opts.Query).
+		Param("retmode", "json").
+		Param("retstart", strconv.Itoa((opts.PageNo-1)*pubMedPageSize)).
+		Param("retmax", strconv.Itoa(pubMedPageSize)))
+	return nil
+}
+
+// Response parses the ids of esearch, the articles are added by the follow-up of esummary.
+func (p *pubmed) Response(ctx context.Context, opts *engine.Options, resp []byte) (*result.Result, error) {
+	if _, err := pubMedIds(resp); err != nil {
+		slog.ErrorContext(ctx, "failed to parse pubmed esearch response", slog.String("func", "pubmed.Response"), slog.String("err", err.Error()))
+		return nil, err
+	}
+	return result.CreateResult(EngineNamePubMed, opts.PageNo), nil
+}
+
+// FollowUp requests the summaries of the ids searched by esearch.
+func (p *pubmed) FollowUp(ctx context.Context, opts *engine.Options, res *result.Result, resp []byte) error {
+	ids, err := pubMedIds(resp)
+	if err != nil || len(ids) == 0 {
+		return err
+	}
+
+	if err = p.wait(ctx); err != nil {
+		return err
+	}
+
+	// example: https://eutils.ncbi.nlm.nih.gov/entrez/eutils/esummary.fcgi?db=pubmed&id=1,2&retmode=json
+	base, _ := url.Parse(pubMedBaseUrl)
+	opts.Request = p.withApiKey(p.client.Get().Base(base).Path("entrez/eutils/esummary.fcgi").
+		Param("db", "pubmed").
+		Param("id", strings.Join(ids, ",")).
+		Param("retmode", "json"))
+	return nil
+}
+
+// FollowUpResponse parses the summaries of esummary in the order of searched ids.
+func (p *pubmed) FollowUpResponse(ctx context.Context, opts *engine.Options, res *result.Result, resp []byte) error {
+	summaries, err := objx.FromJSON(string(resp))
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to parse pubmed esummary response", slog.String("func", "pubmed.FollowUpResponse"), slog.String("err", err.Error()))
+		return err
+	}
+
+	for _, uid := range summaries.Get("result.uids").InterSlice() {
+		id, ok := uid.(string)
+		if !ok {
+			continue
+		}
+		v := summaries.Get("result").ObjxMap().Get(id).ObjxMap()
+		title := v.Get("title").Str()
+		if title == "" {
+			continue
+		}
+
+		var authors []string
+		v.Get("authors").EachObjxMap(func(i int, a objx.Map) bool {
+			authors = append(authors, a.Get("name").Str())
+			return true
+		})
+
+		content := v.Get("fulljournalname").Str()
+		if len(authors) > 0 {
+			content = fmt.Sprintf("%s - %s", strings.Join(authors, ", "), content)
+		}
+
+		// e.g. "2020/01/05 00:00"
+		publishedDate, _ := time.Parse("2006/01/02 15:04", v.Get("sortpubdate").Str())
+
+		res.AppendData(&result.Data{
+			Engine:        EngineNamePubMed,
+			Title:         title,
+			Url:           fmt.Sprintf(pubMedHrefBase, id),
+			Content:       content,
+			PublishedDate: publishedDate,
+			Query:         opts.Query,
+		})
+	}
+	return nil
+}
+
+// pubMedIds gets the ids of articles searched by esearch.
+func pubMedIds(resp []byte) ([]string, error) {
+	m, err := objx.FromJSON(string(resp))
+	if err != nil {
+		return nil, err
+	}
+
+	var ids []string
+	for _, id := range m.Get("esearchresult.idlist").InterSlice() {
+		if s, ok := id.(string); ok {
+			ids = append(ids, s)
+		}
+	}
+	return ids, nil
+}
+
+func (p *pubmed) withApiKey(r *network.Request) *network.Request {
+	if p.apiKey != "" {
+		r.Param("api_key", p.apiKey)
+	}
+	return r
+}
+
+// wait blocks until the next request is allowed by the rate limit.
+func (p *pubmed) wait(ctx context.Context) error {
+	p.mu.Lock()
+	next := p.lastRequest.Add(p.interval)
+	now := time.Now()
+	if next.Before(now) {
+		next = now
+	}
+	p.lastRequest = next
+	p.mu.Unlock()
+
+	select {
+	case <-ctx.Done():
+		return fmt.Errorf("pubmed rate limit wait canceled: %w", ctx.Err())
+	case <-time.After(time.Until(next)):
+		return nil
+	}
+}
+
+func (p *pubmed) GetName() string {
+	return EngineNamePubMed
+}
+
+func (p *pubmed) ApplyConfig(conf engine.Config) error {
+	p.client = network.NewClient(conf.Client)
+
+	var c *PubMedConfig
+	if err := mapstructure.Decode(conf.Extra, &c); err != nil {
+		return err
+	}
+	if c != nil && c.ApiKey != "" {
+		p.apiKey = c.ApiKey
+		p.interval = time.Second / 10
+	}
+	return nil
+}
@@ -0,0 +1,81 @@
+package engines
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/zvirgilx/searxng-go/kernel/internal/engine"
+	"github.com/zvirgilx/searxng-go/kernel/internal/network"
+)
+
+func TestPubMedFollowUp(t *testing.T) {
+	p := &pubmed{client: network.DefaultClient(), apiKey: "key"}
+	opts := engine.Options{Query: "crispr", PageNo: 1}
+
+	// the first response only has the ids, the articles are added by the follow-up.
+	res := parseFixture(t, p, opts, "pubmed/esearch.json")
+	if n := res.GetDataSize(); n != 0 {
+		t.Fatalf("got %d data of esearch, want 0", n)
+	}
+
+	if err := p.FollowUp(context.Background(), &opts, res, readFixture(t, "pubmed/esearch.json")); err != nil {
+		t.Fatal(err)
+	}
+	if opts.Request == nil {
+		t.Fatal("the summaries are not requested")
+	}
+	want := "https://eutils.ncbi.nlm.nih.gov/entrez/eutils/esummary.fcgi?api_key=key&db=pubmed&id=38012345%2C37998765%2C37880001&retmode=json"
+	if got := opts.Request.URL().String(); got != want {
+		t.Errorf("url = %s, want %s", got, want)
+	}
+
+	if err := p.FollowUpResponse(context.Background(), &opts, res, readFixture(t, "pubmed/esummary.json")); err != nil {
+		t.Fatal(err)
+	}
+	assertGolden(t, "pubmed/esummary.golden.json", res)
+
+	data := res.GetData()
+	if len(data) != 2 {
+		t.Fatalf("got %d data, want 2, the summary with error is skipped", len(data))
+	}
+	if data[0].Url != "https://pubmed.ncbi.nlm.nih.gov/38012345/" || data[0].Content != "Smith J, Chen L - Nature biotechnology" {
+		t.Errorf("unexpected %+v", data[0])
+	}
+	if want := time.Date(2024, 1, 5, 0, 0, 0, 0, time.UTC); !data[0].PublishedDate.Equal(want) {
+		t.Errorf("published date = %v, want %v", data[0].PublishedDate, want)
+	}
+	if data[1].Content != "Cell" {
+		t.Errorf("content without authors = %q, want the journal", data[1].Content)
+	}
+}
+
+func TestPubMedFollowUpNotFound(t *testing.T) {
+	p := &pubmed{client: network.DefaultClient()}
+	opts := engine.Options{Query: "nothing", PageNo: 1}
+	body := []byte(`{"esearchresult":{"count":"0","idlist":[]}}`)
+
+	res, err := p.Response(context.Background(), &opts, body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := p.FollowUp(context.Background(), &opts, res, body); err != nil {
+		t.Fatal(err)
+	}
+	if opts.Request != nil {
+		t.Error("the summaries are requested without ids")
+	}
+}
+
+func TestPubMedWaitCanceled(t *testing.T) {
+	p := &pubmed{client: network.DefaultClient(), interval: time.Hour, lastRequest: time.Now()}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	// the error of context is kept, so that the deadline of search is told from the failures of engine.
+	opts := engine.Options{Query: "crispr", PageNo: 1}
+	if err := p.Request(ctx, &opts); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("err = %v, want the deadline exceeded", err)
+	}
+}
@@ -0,0 +1,150 @@
+package engines
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/stretchr/objx"
+	"github.com/zvirgilx/searxng-go/kernel/internal/engine"
+	"github.com/zvirgilx/searxng-go/kernel/internal/network"
+	"github.com/zvirgilx/searxng-go/kernel/internal/result"
+)
+
+const (
+	EngineNameQwant       = "qwant"
+	EngineNameQwantImages = "qwant_images"
+	EngineNameQwantNews   = "qwant_news"
+
+	qwantTypeWeb    = "web"
+	qwantTypeImages = "images"
+	qwantTypeNews   = "news"
+
+	qwantPageSize = 10
+)
+
+// qwant serves different categories by the type of search api,
+// so each type is registered as an independent engine.
+type qwant struct {
+	client *network.Client
+
+	name       string
+	searchType string
+}
+
+func init() {
+	engine.RegisterGlobalEngine(&qwant{client: network.DefaultClient(), name: EngineNameQwant, searchType: qwantTypeWeb}, engine.CategoryGeneral)
+	engine.RegisterGlobalEngine(&qwant{client: network.DefaultClient(), name: EngineNameQwantImages, searchType: qwantTypeImages}, engine.CategoryImage)
+	engine.RegisterGlobalEngine(&qwant{client: network.DefaultClient(), name: EngineNameQwantNews, searchType: qwantTypeNews}, engine.CategoryNews)
+}
+
+func (q *qwant) Request(ctx context.Context, opts *engine.Options) error {
+	// example: https://api.qwant.com/v3/search/web?q=test&count=10&offset=0&locale=en_US
+	base, _ := url.Parse("https://api.qwant.com")
+	opts.Request = q.client.Get().Base(base).Path("v3/search/"+q.searchType).
+		Param("q", opts.Query).
+		Param("count", strconv.Itoa(qwantPageSize)).
+		Param("offset", strconv.Itoa((opts.PageNo-1)*qwantPageSize)).
+		Param("locale", qwantLocale(opts.Locale))
+	return nil
+}
+
+func (q *qwant) Response(ctx context.Context, opts *engine.Options, resp []byte) (*result.Result, error) {
+	log := slog.With("func", "qwant.Response")
+
+	m, err := objx.FromJSON(string(resp))
+	if err != nil {
+		log.ErrorContext(ctx, "failed to parse qwant response", slog.String("err", err.Error()))
+		return nil, err
+	}
+
+	if status := m.Get("status").Str(); status != "success" {
+		return nil, errors.New("qwant api error: " + m.Get("data.error_code").String())
+	}
+
+	res := result.CreateResult(q.name, opts.PageNo)
+	items := m.Get("data.result.items")
+
+	switch q.searchType {
+	case qwantTypeWeb:
+		// the web results are grouped in the mainline, only the web type is needed.
+		for _, row := range m.Get("data.result.items.mainline").ObjxMapSlice() {
+			if row.Get("type").Str() != qwantTypeWeb {
+				continue
+			}
+			row.Get("items").EachObjxMap(func(i int, v objx.Map) bool {
+				res.AppendData(&result.Data{
+					Engine:  q.name,
+					Title:   v.Get("title").Str(),
+					Url:     v.Get("url").Str(),
+					Content: v.Get("desc").Str(),
+					Query:   opts.Query,
+				})
+				return true
+			})
+		}
+	case qwantTypeImages:
+		items.EachObjxMap(func(i int, v objx.Map) bool {
+			res.AppendData(&result.Data{
+				Engine:    q.name,
+				Title:     v.Get("title").Str(),
+				Url:       v.Get("url").Str(),
+				Content:   v.Get("source").Str(),
+				ImgSrc:    v.Get("media").Str(),
+				Thumbnail: v.Get("thumbnail").Str(),
+				ImgWidth:  v.Get("width").Int(),
+				ImgHeight: v.Get("height").Int(),
+				Query:     opts.Query,
+			})
+			return true
+		})
+	case qwantTypeNews:
+		lang, _, _ := strings.Cut(opts.Locale, "-")
+		items.EachObjxMap(func(i int, v objx.Map) bool {
+			var thumbnail string
+			if media := v.Get("media").ObjxMapSlice(); len(media) > 0 {
+				thumbnail = media[0].Get("pict.url").Str()
+			}
+
+			// the news without date are not dated at the epoch.
+			var publishedDate time.Time
+			if date := int64(v.Get("date").Float64()); date > 0 {
+				publishedDate = time.Unix(date, 0)
+			}
+
+			res.AppendData(&result.Data{
+				Engine:        q.name,
+				Title:         v.Get("title").Str(),
+				Url:           v.Get("url").Str(),
+				Content:       engine.DatedContent(publishedDate, v.Get("desc").Str(), lang),
+				Thumbnail:     thumbnail,
+				PublishedDate: publishedDate,
+				Query:         opts.Query,
+			})
+			return true
+		})
+	}
+
+	return res, nil
+}
+
+// qwantLocale converts the locale to qwant locale, e.g. en-US -> en_US.
+func qwantLocale(locale string) string {
+	if locale == "" || !strings.Contains(locale, "-") {
+		return "en_US"
+	}
+	return strings.ReplaceAll(locale, "-", "_")
+}
+
+func (q *qwant) GetName() string {
+	return q.name
+}
+
+func (q *qwant) ApplyConfig(conf engine.Config) error {
+	q.client = network.NewClient(conf.Client)
+	return nil
+}
@@ -0,0 +1,51 @@
+package engines
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/zvirgilx/searxng-go/kernel/internal/engine"
+)
+
+func TestQwantImagesResponse(t *testing.T) {
+	e := &qwant{name: EngineNameQwantImages, searchType: qwantTypeImages}
+	res := parseFixture(t, e, engine.Options{Query: "eiffel tower", PageNo: 1}, "qwant/images.json")
+
+	data := res.GetData()
+	if len(data) != 2 {
+		t.Fatalf("got %d data, want 2", len(data))
+	}
+	d := data[0]
+	if d.Engine != EngineNameQwantImages || d.ImgSrc != "https://images.example.com/eiffel-night.jpg" ||
+		d.Thumbnail != "https://s1.qwant.com/thumbr/474x316/eiffel-night.jpg" || d.ImgWidth != 1920 || d.ImgHeight != 1280 {
+		t.Errorf("unexpected image %+v", d)
+	}
+	if d.Content != "example.com" {
+		t.Errorf("content = %q, want the source", d.Content)
+	}
+}
+
+func TestQwantNewsResponse(t *testing.T) {
+	e := &qwant{name: EngineNameQwantNews, searchType: qwantTypeNews}
+	res := parseFixture(t, e, engine.Options{Query: "climate", PageNo: 1, Locale: "de-DE"}, "qwant/news.json")
+
+	data := res.GetData()
+	if len(data) != 2 {
+		t.Fatalf("got %d data, want 2", len(data))
+	}
+	if want := time.Unix(1717243200, 0); !data[0].PublishedDate.Equal(want) {
+		t.Errorf("published date = %v, want %v", data[0].PublishedDate, want)
+	}
+	if data[0].Thumbnail != "https://s1.qwant.com/thumbr/0x0/climate.jpg" {
+		t.Errorf("thumbnail = %q", data[0].Thumbnail)
+	}
+	// the content is dated in the language of locale.
+	if want := engine.FormatDate(data[0].PublishedDate, "de") + " - "; !strings.HasPrefix(data[0].Content, want) {
+		t.Errorf("content = %q, want the prefix %q", data[0].Content, want)
+	}
+	// the news without date are not dated at the epoch.
+	if !data[1].PublishedDate.IsZero() || data[1].Thumbnail != "" || strings.Contains(data[1].Content, " - ") {
+		t.Errorf("unexpected undated news %+v", data[1])
+	}
+}
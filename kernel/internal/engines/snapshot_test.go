@@ -0,0 +1,51 @@
+package engines
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/zvirgilx/searxng-go/kernel/internal/engine"
+	"github.com/zvirgilx/searxng-go/kernel/internal/snapshot"
+)
+
+// snapshotDir is the directory of snapshots relative to the engines, it is snapshot.DefaultDir relative to the kernel.
+const snapshotDir = "testdata/snapshots"
+
+// TestSnapshots replays the recorded responses of engines, it fails if the parsed data changed.
+// The expected data are rewritten by the recorded responses with -update, e.g. after the parsing of engine is fixed,
+// and the responses are recorded again by the snapshot record command.
+func TestSnapshots(t *testing.T) {
+	dirs, err := os.ReadDir(snapshotDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, dir := range dirs {
+		if !dir.IsDir() {
+			continue
+		}
+		e := engine.GetEngine(dir.Name())
+		if e == nil {
+			t.Errorf("engine of snapshots %s is not registered", dir.Name())
+			continue
+		}
+
+		names, err := snapshot.Names(snapshotDir, e.GetName())
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, name := range names {
+			t.Run(filepath.Join(e.GetName(), name), func(t *testing.T) {
+				if *update {
+					if err := snapshot.Update(context.Background(), e, snapshotDir, name); err != nil {
+						t.Fatal(err)
+					}
+				}
+				if err := snapshot.Replay(context.Background(), e, snapshotDir, name); err != nil {
+					t.Error(err)
+				}
+			})
+		}
+	}
+}
@@ -0,0 +1,215 @@
+package engines
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mitchellh/mapstructure"
+	"github.com/stretchr/objx"
+	"github.com/zvirgilx/searxng-go/kernel/internal/engine"
+	"github.com/zvirgilx/searxng-go/kernel/internal/network"
+	"github.com/zvirgilx/searxng-go/kernel/internal/result"
+)
+
+const (
+	EngineNameTMDB = "tmdb"
+
+	tmdbApiUrl    = "https://api.themoviedb.org"
+	tmdbHrefBase  = "https://www.themoviedb.org/movie/%d"
+	tmdbPosterUrl = "https://image.tmdb.org/t/p/%s%s"
+
+	// the count of cast shown in the infobox.
+	tmdbMaxCast = 5
+)
+
+type tmdb struct {
+	client *network.Client
+
+	apiKey string
+}
+
+type TMDBConfig struct {
+	ApiKey string `mapstructure:"api_key"` // ApiKey is required by TMDB api, the engine is disabled without it.
+}
+
+func init() {
+	engine.RegisterGlobalEngine(&tmdb{client: network.DefaultClient()}, engine.CategoryMovies)
+}
+
+func (t *tmdb) Request(ctx context.Context, opts *engine.Options) error {
+	// example: https://api.themoviedb.org/3/search/movie?api_key=key&query=test&page=1&language=en-US
+	base, _ := url.Parse(tmdbApiUrl)
+	req := t.client.Get().Base(base).Path("3/search/movie").
+		Param("api_key", t.apiKey).
+		Param("query", opts.Query).
+		Param("page", strconv.Itoa(opts.PageNo))
+	if opts.Locale != "" {
+		req.Param("language", opts.Locale)
+	}
+
+	opts.Request = req
+	return nil
+}
+
+func (t *tmdb) Response(ctx context.Context, opts *engine.Options, resp []byte) (*result.Result, error) {
+	log := slog.With("func", "tmdb.Response")
+
+	m, err := objx.FromJSON(string(resp))
+	if err != nil {
+		log.ErrorContext(ctx, "failed to parse tmdb response", slog.String("err", err.Error()))
+		return nil, err
+	}
+
+	res := result.CreateResult(EngineNameTMDB, opts.PageNo)
+	m.Get("results").EachObjxMap(func(i int, v objx.Map) bool {
+		id := v.Get("id").Int()
+		title := v.Get("title").Str()
+		if id == 0 || title == "" {
+			return true
+		}
+
+		releaseDate, _ := time.Parse(time.DateOnly, v.Get("release_date").Str())
+
+		var parts []string
+		if !releaseDate.IsZero() {
+			parts = append(parts, strconv.Itoa(releaseDate.Year()))
+		}
+		if rating := v.Get("vote_average").Float64(); rating > 0 {
+			parts = append(parts, fmt.Sprintf("%.1f/10", rating))
+		}
+		if overview := v.Get("overview").Str(); overview != "" {
+			parts = append(parts, overview)
+		}
+
+		var poster string
+		if p := v.Get("poster_path").Str(); p != "" {
+			poster = fmt.Sprintf(tmdbPosterUrl, engine.ThumbnailToken(opts, "w185", "w342", "w780"), p)
+		}
+
+		res.AppendData(&result.Data{
+			Engine:        EngineNameTMDB,
+			Title:         title,
+			Url:           fmt.Sprintf(tmdbHrefBase, id),
+			Content:       strings.Join(parts, " - "),
+			ImgSrc:        poster,
+			PublishedDate: releaseDate,
+			Query:         opts.Query,
+		})
+		return true
+	})
+
+	return res, nil
+}
+
+// FollowUp requests the details of the exact title match for the infobox, it is only shown on the first page.
+func (t *tmdb) FollowUp(ctx context.Context, opts *engine.Options, res *result.Result, resp []byte) error {
+	if opts.PageNo != 1 {
+		return nil
+	}
+	id := tmdbExactMatch(resp, opts.Query)
+	if id == 0 {
+		return nil
+	}
+
+	// example: https://api.themoviedb.org/3/movie/27205?api_key=key&append_to_response=credits
+	base, _ := url.Parse(tmdbApiUrl)
+	req := t.client.Get().Base(base).Path(fmt.Sprintf("3/movie/%d", id)).
+		Param("api_key", t.apiKey).
+		Param("append_to_response", "credits")
+	if opts.Locale != "" {
+		req.Param("language", opts.Locale)
+	}
+
+	opts.Request = req
+	return nil
+}
+
+// FollowUpResponse sets the infobox of movie with director and cast, the results are kept without it if failed.
+func (t *tmdb) FollowUpResponse(ctx context.Context, opts *engine.Options, res *result.Result, resp []byte) error {
+	m, err := objx.FromJSON(string(resp))
+	if err != nil || m.Get("id").Int() == 0 {
+		slog.WarnContext(ctx, "failed to get tmdb infobox", slog.String("func", "tmdb.FollowUpResponse"))
+		return nil
+	}
+	id := m.Get("id").Int()
+
+	var directors, cast []string
+	m.Get("credits.crew").EachObjxMap(func(i int, v objx.Map) bool {
+		if v.Get("job").Str() == "Director" {
+			directors = append(directors, v.Get("name").Str())
+		}
+		return true
+	})
+	m.Get("credits.cast").EachObjxMap(func(i int, v objx.Map) bool {
+		cast = append(cast, v.Get("name").Str())
+		return len(cast) < tmdbMaxCast
+	})
+
+	content := m.Get("overview").Str()
+	if len(directors) > 0 {
+		content += "\nDirector: " + strings.Join(directors, ", ")
+	}
+	if len(cast) > 0 {
+		content += "\nCast: " + strings.Join(cast, ", ")
+	}
+
+	var poster string
+	if p := m.Get("poster_path").Str(); p != "" {
+		poster = fmt.Sprintf(tmdbPosterUrl, "w342", p)
+	}
+
+	link := fmt.Sprintf(tmdbHrefBase, id)
+	urlList := []map[string]string{{"title": "TMDB", "url": link}}
+	if imdbId := m.Get("imdb_id").Str(); imdbId != "" {
+		urlList = append(urlList, map[string]string{"title": "IMDb", "url": fmt.Sprintf(hrefBase, "title", imdbId)})
+	}
+
+	res.InfoBox = &result.InfoBox{
+		Title:   m.Get("title").Str(),
+		Content: strings.TrimSpace(content),
+		ImgSrc:  poster,
+		Url:     link,
+		UrlList: urlList,
+	}
+	return nil
+}
+
+// tmdbExactMatch gets the id of the first movie whose title equals to the query, 0 is returned if none.
+func tmdbExactMatch(resp []byte, query string) int {
+	m, err := objx.FromJSON(string(resp))
+	if err != nil {
+		return 0
+	}
+	for _, v := range m.Get("results").ObjxMapSlice() {
+		if id := v.Get("id").Int(); id != 0 && strings.EqualFold(v.Get("title").Str(), query) {
+			return id
+		}
+	}
+	return 0
+}
+
+func (t *tmdb) GetName() string {
+	return EngineNameTMDB
+}
+
+func (t *tmdb) ApplyConfig(conf engine.Config) error {
+	t.client = network.NewClient(conf.Client)
+
+	var c *TMDBConfig
+	if err := mapstructure.Decode(conf.Extra, &c); err != nil {
+		return err
+	}
+
+	// the engine disables itself without an api key.
+	if c == nil || c.ApiKey == "" {
+		return errors.New("api key of tmdb is required")
+	}
+	t.apiKey = c.ApiKey
+	return nil
+}
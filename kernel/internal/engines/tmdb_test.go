@@ -0,0 +1,105 @@
+package engines
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/zvirgilx/searxng-go/kernel/internal/engine"
+	"github.com/zvirgilx/searxng-go/kernel/internal/network"
+)
+
+func newTestTMDB(t *testing.T) *tmdb {
+	t.Helper()
+	e := &tmdb{}
+	if err := e.ApplyConfig(engine.Config{Client: &network.Config{}, Extra: map[string]interface{}{"api_key": "key"}}); err != nil {
+		t.Fatal(err)
+	}
+	return e
+}
+
+func TestTMDBResponse(t *testing.T) {
+	res := parseFixture(t, newTestTMDB(t), engine.Options{Query: "inception", PageNo: 1}, "tmdb/search.json")
+	assertGolden(t, "tmdb/search.golden.json", res)
+
+	data := res.GetData()
+	if len(data) != 2 {
+		t.Fatalf("got %d data, want 2, the movie without id is skipped", len(data))
+	}
+	if data[0].Content != "2010 - 8.4/10 - Cobb, a skilled thief who commits corporate espionage by infiltrating the subconscious of his targets." {
+		t.Errorf("content = %q", data[0].Content)
+	}
+	if data[0].ImgSrc != "https://image.tmdb.org/t/p/w342/oYuLEt3zVCKq57qu2F8dT7NIa6f.jpg" || data[1].ImgSrc != "" {
+		t.Errorf("posters = %q, %q", data[0].ImgSrc, data[1].ImgSrc)
+	}
+}
+
+func TestTMDBInfoBox(t *testing.T) {
+	e := newTestTMDB(t)
+	opts := engine.Options{Query: "Inception", PageNo: 1}
+	res := parseFixture(t, e, opts, "tmdb/search.json")
+
+	// the details of the exact title match are followed up.
+	if err := e.FollowUp(context.Background(), &opts, res, readFixture(t, "tmdb/search.json")); err != nil {
+		t.Fatal(err)
+	}
+	if opts.Request == nil || !strings.HasPrefix(opts.Request.URL().String(), "https://api.themoviedb.org/3/movie/27205?") {
+		t.Fatalf("the details of exact match are not requested")
+	}
+	if err := e.FollowUpResponse(context.Background(), &opts, res, readFixture(t, "tmdb/movie.json")); err != nil {
+		t.Fatal(err)
+	}
+
+	box := res.InfoBox
+	if box == nil || box.Title != "Inception" {
+		t.Fatalf("unexpected infobox %+v", box)
+	}
+	// the cast is capped to the top ones.
+	if !strings.Contains(box.Content, "Director: Christopher Nolan") ||
+		!strings.Contains(box.Content, "Cast: Leonardo DiCaprio, Joseph Gordon-Levitt, Ken Watanabe, Tom Hardy, Elliot Page") ||
+		strings.Contains(box.Content, "Dileep Rao") {
+		t.Errorf("content = %q", box.Content)
+	}
+	if len(box.UrlList) != 2 || box.UrlList[1]["url"] != "https://imdb.com/title/tt1375666" {
+		t.Errorf("url list = %v", box.UrlList)
+	}
+}
+
+func TestTMDBInfoBoxWithoutExactMatch(t *testing.T) {
+	e := newTestTMDB(t)
+	for _, opts := range []engine.Options{{Query: "incep", PageNo: 1}, {Query: "Inception", PageNo: 2}} {
+		res := parseFixture(t, e, opts, "tmdb/search.json")
+		if err := e.FollowUp(context.Background(), &opts, res, readFixture(t, "tmdb/search.json")); err != nil {
+			t.Fatal(err)
+		}
+		if opts.Request != nil {
+			t.Errorf("the details are requested for %q page %d", opts.Query, opts.PageNo)
+		}
+	}
+}
+
+func TestTMDBWithoutApiKey(t *testing.T) {
+	// the engine disables itself without an api key.
+	if err := (&tmdb{}).ApplyConfig(engine.Config{Client: &network.Config{}}); err == nil {
+		t.Error("tmdb is configured without api key")
+	}
+	if err := (&tmdb{}).ApplyConfig(engine.Config{Client: &network.Config{}, Extra: map[string]interface{}{"api_key": ""}}); err == nil {
+		t.Error("tmdb is configured with an empty api key")
+	}
+}
+
+func TestIMDBInstancePerCategory(t *testing.T) {
+	general := engine.GetEnginesByCategory(engine.CategoryGeneral)[EngineNameIMDB]
+	movies := engine.GetEnginesByCategory(engine.CategoryMovies)[EngineNameIMDB]
+	if general == nil || movies == nil || general == movies {
+		t.Errorf("imdb is not registered by an instance per category")
+	}
+}
+
+func TestTMDBPosterSize(t *testing.T) {
+	opts := engine.Options{Query: "inception", PageNo: 1, ThumbnailSize: engine.ThumbnailSizeSmall}
+	res := parseFixture(t, newTestTMDB(t), opts, "tmdb/search.json")
+	if want := "https://image.tmdb.org/t/p/w185/oYuLEt3zVCKq57qu2F8dT7NIa6f.jpg"; res.GetData()[0].ImgSrc != want {
+		t.Errorf("poster = %s, want %s", res.GetData()[0].ImgSrc, want)
+	}
+}
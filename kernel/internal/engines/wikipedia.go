@@ -22,6 +22,7 @@ type wikipedia struct {
 
 func init() {
 	engine.RegisterGlobalEngine(&wikipedia{client: network.DefaultClient()}, engine.CategoryGeneral)
+	engine.RegisterAlias("wp", EngineNameWikipedia)
 }
 
 func (w *wikipedia) Request(ctx context.Context, opts *engine.Options) error {
@@ -47,7 +48,7 @@ func (w *wikipedia) Response(ctx context.Context, opts *engine.Options, resp []b
 	log := slog.With("func", "wikipedia.Response")
 	m, err := objx.FromJSON(string(resp))
 	if err != nil {
-		log.ErrorContext(ctx, "err", err)
+		log.ErrorContext(ctx, "failed to parse wikipedia response", slog.String("err", err.Error()))
 		return nil, err
 	}
 
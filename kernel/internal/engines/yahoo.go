@@ -0,0 +1,139 @@
+package engines
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/zvirgilx/searxng-go/kernel/internal/engine"
+	"github.com/zvirgilx/searxng-go/kernel/internal/network"
+	"github.com/zvirgilx/searxng-go/kernel/internal/result"
+	"github.com/zvirgilx/searxng-go/kernel/internal/util"
+)
+
+const (
+	EngineNameYahoo = "yahoo"
+
+	// yahoo shows 7 results per page.
+	yahooPageSize = 7
+)
+
+var (
+	// yahoo does not support year time range.
+	yahooTimeRangeMap = map[string]string{
+		"day":   "d",
+		"week":  "w",
+		"month": "m",
+	}
+)
+
+type yahoo struct {
+	client *network.Client
+}
+
+func init() {
+	engine.RegisterGlobalEngine(&yahoo{client: network.DefaultClient()}, engine.CategoryGeneral)
+}
+
+func (y *yahoo) Request(ctx context.Context, opts *engine.Options) error {
+	// example: https://search.yahoo.com/search?p=test&b=8&age=1d&btf=d&fr2=time
+	base, _ := url.Parse("https://search.yahoo.com")
+	req := y.client.Get().Base(base).Path("search").
+		Param("p", opts.Query).
+		// b is the 1-based offset of the results.
+		Param("b", strconv.Itoa((opts.PageNo-1)*yahooPageSize+1))
+
+	if t, ok := yahooTimeRangeMap[opts.TimeRange]; ok {
+		req.Param("age", "1"+t).Param("btf", t).Param("fr2", "time")
+	}
+
+	opts.Request = req
+	return nil
+}
+
+func (y *yahoo) Response(ctx context.Context, opts *engine.Options, resp []byte) (*result.Result, error) {
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(string(resp)))
+	if err != nil {
+		return nil, errors.New("error parsing document")
+	}
+
+	res := result.CreateResult(EngineNameYahoo, opts.PageNo)
+	doc.Find("div.algo").Each(func(i int, s *goquery.Selection) {
+		a := s.Find("div.compTitle h3 a").First()
+		link, ok := a.Attr("href")
+		if !ok {
+			return
+		}
+
+		// the text of link may contain the domain of the link, so aria-label is preferred.
+		title, ok := a.Attr("aria-label")
+		if !ok {
+			title = a.Text()
+		}
+		title = strings.TrimSpace(title)
+		if title == "" {
+			return
+		}
+
+		content := strings.TrimSpace(s.Find("div.compText").First().Text())
+
+		res.AppendData(&result.Data{
+			Engine:  EngineNameYahoo,
+			Title:   title,
+			Url:     yahooUnwrapUrl(link),
+			Content: content,
+			Query:   opts.Query,
+		})
+	})
+
+	doc.Find("div.AlsoTry table a").Each(func(i int, s *goquery.Selection) {
+		if sug := strings.TrimSpace(s.Text()); sug != "" {
+			util.SetAdd(res.Suggestions, sug)
+		}
+	})
+
+	return res, nil
+}
+
+// yahooUnwrapUrl gets the real url from the yahoo redirect url.
+// e.g. https://r.search.yahoo.com/_ylt=A;_ylu=B/RV=2/RE=1/RO=10/RU=https%3a%2f%2fexample.com%2f/RK=2/RS=C- -> https://example.com/
+func yahooUnwrapUrl(link string) string {
+	ru := strings.Index(link, "/RU=")
+	if ru == -1 {
+		return link
+	}
+
+	start := strings.Index(link[ru:], "http")
+	if start == -1 {
+		return link
+	}
+	start += ru
+
+	end := -1
+	for _, ending := range []string{"/RK=", "/RS="} {
+		if pos := strings.LastIndex(link, ending); pos > start && (end == -1 || pos < end) {
+			end = pos
+		}
+	}
+	if end == -1 {
+		return link
+	}
+
+	u, err := url.QueryUnescape(link[start:end])
+	if err != nil {
+		return link
+	}
+	return u
+}
+
+func (y *yahoo) GetName() string {
+	return EngineNameYahoo
+}
+
+func (y *yahoo) ApplyConfig(conf engine.Config) error {
+	y.client = network.NewClient(conf.Client)
+	return nil
+}
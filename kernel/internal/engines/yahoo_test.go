@@ -0,0 +1,52 @@
+package engines
+
+import (
+	"context"
+	"testing"
+
+	"github.com/zvirgilx/searxng-go/kernel/internal/engine"
+	"github.com/zvirgilx/searxng-go/kernel/internal/network"
+	"github.com/zvirgilx/searxng-go/kernel/internal/util"
+)
+
+func TestYahooResponse(t *testing.T) {
+	res := parseFixture(t, &yahoo{}, engine.Options{Query: "golang", PageNo: 1}, "yahoo/search.html")
+	assertGolden(t, "yahoo/search.golden.json", res)
+
+	if n := len(res.GetData()); n != 3 {
+		t.Fatalf("got %d data, want 3, the link without title is skipped", n)
+	}
+	if sugs := util.SetToArray[string](res.Suggestions); len(sugs) != 2 {
+		t.Errorf("got suggestions %v, want 2", sugs)
+	}
+}
+
+func TestYahooUnwrapUrl(t *testing.T) {
+	tests := []struct {
+		link string
+		want string
+	}{
+		{"https://r.search.yahoo.com/_ylt=A;_ylu=B/RV=2/RE=1/RO=10/RU=https%3a%2f%2fexample.com%2f/RK=2/RS=C-", "https://example.com/"},
+		{"https://r.search.yahoo.com/_ylt=A/RV=2/RU=https%3a%2f%2fexample.com%2fa%3fq%3d1%26p%3d2/RS=C-", "https://example.com/a?q=1&p=2"},
+		// the links without redirect or with malformed redirect are kept.
+		{"https://example.com/page", "https://example.com/page"},
+		{"https://r.search.yahoo.com/_ylt=A/RU=https%3a%2f%2fexample.com%2f", "https://r.search.yahoo.com/_ylt=A/RU=https%3a%2f%2fexample.com%2f"},
+	}
+	for _, tt := range tests {
+		if got := yahooUnwrapUrl(tt.link); got != tt.want {
+			t.Errorf("yahooUnwrapUrl(%q) = %q, want %q", tt.link, got, tt.want)
+		}
+	}
+}
+
+func TestYahooRequest(t *testing.T) {
+	opts := engine.Options{Query: "golang", PageNo: 2, TimeRange: "week"}
+	if err := (&yahoo{client: network.DefaultClient()}).Request(context.Background(), &opts); err != nil {
+		t.Fatal(err)
+	}
+	q := opts.Request.URL().Query()
+	// b is the 1-based offset, the second page starts at the 8th result.
+	if q.Get("p") != "golang" || q.Get("b") != "8" || q.Get("age") != "1w" || q.Get("btf") != "w" {
+		t.Errorf("unexpected query %v", q)
+	}
+}
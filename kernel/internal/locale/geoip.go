@@ -0,0 +1,144 @@
+package locale
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/netip"
+	"os"
+	"sort"
+	"strings"
+
+	"golang.org/x/text/language"
+)
+
+type GeoIPConfig struct {
+	// Enable derives the default locale of search from the client ip, it is opt-in.
+	Enable bool `mapstructure:"enable"`
+	// Database is the path of csv database, each line is "start_ip,end_ip,country_code", e.g. "1.0.0.0,1.0.0.255,AU".
+	Database string `mapstructure:"database"`
+}
+
+// GeoIPResolver resolves the country of ip.
+type GeoIPResolver interface {
+	// Country returns the ISO 3166-1 alpha-2 country code of ip, e.g. DE.
+	Country(ip netip.Addr) (string, bool)
+}
+
+// geoIP is the resolver of client ip, nil means the feature is disabled.
+var geoIP GeoIPResolver
+
+func InitGeoIP(c GeoIPConfig) error {
+	if !c.Enable {
+		return nil
+	}
+	db, err := LoadGeoIPDatabase(c.Database)
+	if err != nil {
+		return err
+	}
+	SetGeoIPResolver(db)
+	return nil
+}
+
+// SetGeoIPResolver sets the resolver of client ip, nil disables the feature.
+func SetGeoIPResolver(r GeoIPResolver) {
+	geoIP = r
+}
+
+// FromIP derives the locale from the country of ip, e.g. 1.0.0.1 -> en-AU.
+// defaultVal is returned if the feature is disabled or the lookup fails.
+func FromIP(ip string, defaultVal string) string {
+	if geoIP == nil {
+		return defaultVal
+	}
+
+	addr, err := netip.ParseAddr(ip)
+	if err != nil {
+		return defaultVal
+	}
+	country, ok := geoIP.Country(addr.Unmap())
+	if !ok {
+		return defaultVal
+	}
+
+	region, err := language.ParseRegion(country)
+	if err != nil || !region.IsCountry() {
+		slog.Warn("failed to parse region of geoip", slog.String("country", country))
+		return defaultVal
+	}
+
+	// the most likely language of region, e.g. DE -> de.
+	tag, err := language.Compose(language.Und, region)
+	if err != nil {
+		return defaultVal
+	}
+	base, confidence := tag.Base()
+	if confidence == language.No {
+		return defaultVal
+	}
+	return base.String() + "-" + region.String()
+}
+
+// geoIPRange is the ip range of a country.
+type geoIPRange struct {
+	start, end netip.Addr
+	country    string
+}
+
+// GeoIPDatabase resolves the country of ip by the ranges sorted by start ip.
+type GeoIPDatabase struct {
+	ranges []geoIPRange
+}
+
+// LoadGeoIPDatabase loads the csv database of ip ranges.
+func LoadGeoIPDatabase(path string) (*GeoIPDatabase, error) {
+	if path == "" {
+		return nil, errors.New("geoip database is required")
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	db := &GeoIPDatabase{}
+	scanner := bufio.NewScanner(f)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+
+		fields := strings.Split(text, ",")
+		if len(fields) != 3 {
+			return nil, fmt.Errorf("invalid geoip database at line %d", line)
+		}
+		start, err1 := netip.ParseAddr(strings.TrimSpace(fields[0]))
+		end, err2 := netip.ParseAddr(strings.TrimSpace(fields[1]))
+		if err1 != nil || err2 != nil || end.Less(start) {
+			return nil, fmt.Errorf("invalid ip range of geoip database at line %d", line)
+		}
+		db.ranges = append(db.ranges, geoIPRange{start: start, end: end, country: strings.ToUpper(strings.TrimSpace(fields[2]))})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	sort.Slice(db.ranges, func(i, j int) bool {
+		return db.ranges[i].start.Less(db.ranges[j].start)
+	})
+	return db, nil
+}
+
+func (db *GeoIPDatabase) Country(ip netip.Addr) (string, bool) {
+	// the last range starting before or at ip.
+	i := sort.Search(len(db.ranges), func(i int) bool {
+		return ip.Less(db.ranges[i].start)
+	}) - 1
+	if i < 0 || db.ranges[i].end.Less(ip) {
+		return "", false
+	}
+	return db.ranges[i].country, true
+}
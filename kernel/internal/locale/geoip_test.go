@@ -0,0 +1,83 @@
+package locale
+
+import (
+	"net/netip"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// mockResolver resolves the countries of ips by the map.
+type mockResolver map[string]string
+
+func (r mockResolver) Country(ip netip.Addr) (string, bool) {
+	c, ok := r[ip.String()]
+	return c, ok
+}
+
+func withGeoIP(t *testing.T, r GeoIPResolver) {
+	t.Helper()
+	SetGeoIPResolver(r)
+	t.Cleanup(func() { SetGeoIPResolver(nil) })
+}
+
+func TestFromIP(t *testing.T) {
+	withGeoIP(t, mockResolver{"1.0.0.1": "AU", "5.1.2.3": "DE", "2001:db8::1": "JP", "9.9.9.9": "ZZZ"})
+
+	tests := map[string]string{
+		"1.0.0.1":        "en-AU",
+		"5.1.2.3":        "de-DE",
+		"::ffff:5.1.2.3": "de-DE", // the ipv4-mapped address is unmapped.
+		"2001:db8::1":    "ja-JP",
+		"9.9.9.9":        "en-US", // the invalid country falls back.
+		"8.8.8.8":        "en-US", // the unknown ip falls back.
+		"not an ip":      "en-US",
+		"":               "en-US",
+	}
+	for ip, want := range tests {
+		if got := FromIP(ip, "en-US"); got != want {
+			t.Errorf("FromIP(%q) = %q, want %q", ip, got, want)
+		}
+	}
+}
+
+func TestFromIPDisabled(t *testing.T) {
+	withGeoIP(t, nil)
+	if got := FromIP("5.1.2.3", "en-US"); got != "en-US" {
+		t.Errorf("got %q, want the default without resolver", got)
+	}
+}
+
+func TestLoadGeoIPDatabase(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "geoip.csv")
+	content := "# start,end,country\n5.0.0.0,5.255.255.255,de\n\n1.0.0.0,1.0.0.255,AU\n2001:db8::,2001:db8::ffff,JP\n"
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	db, err := LoadGeoIPDatabase(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for ip, want := range map[string]string{"1.0.0.0": "AU", "1.0.0.255": "AU", "5.10.0.1": "DE", "2001:db8::42": "JP", "1.0.1.0": "", "0.0.0.1": ""} {
+		got, ok := db.Country(netip.MustParseAddr(ip))
+		if got != want || ok != (want != "") {
+			t.Errorf("Country(%s) = %q, %v, want %q", ip, got, ok, want)
+		}
+	}
+}
+
+func TestLoadGeoIPDatabaseInvalid(t *testing.T) {
+	if _, err := LoadGeoIPDatabase(""); err == nil {
+		t.Error("the database without path is loaded")
+	}
+	for _, content := range []string{"1.0.0.0,AU\n", "1.0.0.255,1.0.0.0,AU\n", "1.0.0.0,x,AU\n"} {
+		path := filepath.Join(t.TempDir(), "geoip.csv")
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := LoadGeoIPDatabase(path); err == nil {
+			t.Errorf("the invalid database %q is loaded", content)
+		}
+	}
+}
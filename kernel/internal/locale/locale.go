@@ -17,7 +17,7 @@ func GetLanguageFromTrait(locale string, trait *traits.EngineTraits, defaultVal
 	}
 	tag, err := language.Parse(locale)
 	if err != nil {
-		log.Error("msg", err)
+		log.Error("failed to parse locale", slog.String("locale", locale), slog.String("err", err.Error()))
 		return defaultVal
 	}
 
@@ -8,6 +8,7 @@ func init() {
 	prometheus.MustRegister(ResponseCounter)
 	prometheus.MustRegister(EnginesResponseCounter)
 	prometheus.MustRegister(EnginesSearchResultCounter)
+	prometheus.MustRegister(EnginesAutoDisabledCounter)
 
 }
 
@@ -42,4 +43,13 @@ var (
 		},
 		[]string{"engine"},
 	)
+
+	// EnginesAutoDisabledCounter counts the times the engine is disabled by its error rate.
+	EnginesAutoDisabledCounter = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "engines_auto_disabled_total",
+			Help: "Total number of times engines are disabled by their error rate.",
+		},
+		[]string{"engine"},
+	)
 )
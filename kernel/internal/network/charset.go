@@ -0,0 +1,25 @@
+package network
+
+import (
+	"bytes"
+	"io"
+	"unicode/utf8"
+
+	"golang.org/x/net/html/charset"
+)
+
+// DecodeToUTF8 converts the body to UTF-8.
+// The charset is detected from the content type first, then the <meta charset> of html.
+func DecodeToUTF8(body []byte, contentType string) ([]byte, error) {
+	enc, name, certain := charset.DetermineEncoding(body, contentType)
+	if name == "utf-8" {
+		return body, nil
+	}
+
+	// the charset is guessed from the beginning of body, so keep the body if it is valid UTF-8.
+	if !certain && utf8.Valid(body) {
+		return body, nil
+	}
+
+	return io.ReadAll(enc.NewDecoder().Reader(bytes.NewReader(body)))
+}
@@ -0,0 +1,63 @@
+package network
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"golang.org/x/text/encoding/charmap"
+	"golang.org/x/text/encoding/simplifiedchinese"
+)
+
+func TestDecodeToUTF8(t *testing.T) {
+	gbk, _ := simplifiedchinese.GBK.NewEncoder().String("<p>百度一下，你就知道</p>")
+	latin1, _ := charmap.ISO8859_1.NewEncoder().String("<p>Café crème brûlée</p>")
+
+	tests := []struct {
+		name        string
+		body        string
+		contentType string
+		want        string
+	}{
+		{"gbk by header", gbk, "text/html; charset=gbk", "<p>百度一下，你就知道</p>"},
+		{"gbk by meta", `<meta charset="gbk">` + gbk, "text/html", `<meta charset="gbk"><p>百度一下，你就知道</p>`},
+		{"iso-8859-1 by header", latin1, "text/html; charset=ISO-8859-1", "<p>Café crème brûlée</p>"},
+		{"iso-8859-1 by meta", `<meta http-equiv="Content-Type" content="text/html; charset=iso-8859-1">` + latin1, "",
+			`<meta http-equiv="Content-Type" content="text/html; charset=iso-8859-1"><p>Café crème brûlée</p>`},
+		{"utf-8", "<p>héllo</p>", "text/html; charset=utf-8", "<p>héllo</p>"},
+		// the guessed charset is ignored for the valid UTF-8 body, e.g. json without charset.
+		{"utf-8 without charset", `{"q":"héllo"}`, "application/json", `{"q":"héllo"}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := DecodeToUTF8([]byte(tt.body), tt.contentType)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRequestKeepCharset(t *testing.T) {
+	gbk, _ := simplifiedchinese.GBK.NewEncoder().String("百度")
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html; charset=gbk")
+		w.Write([]byte(gbk))
+	}))
+	defer srv.Close()
+	base, _ := url.Parse(srv.URL)
+
+	if r := DefaultClient().Get().Base(base).Do(context.Background()); string(r.Body) != "百度" {
+		t.Errorf("body is not decoded: %q", r.Body)
+	}
+	if r := DefaultClient().Get().Base(base).KeepCharset().Do(context.Background()); !bytes.Equal(r.Body, []byte(gbk)) {
+		t.Errorf("body is decoded by KeepCharset: %q", r.Body)
+	}
+}
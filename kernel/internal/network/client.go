@@ -1,19 +1,55 @@
 package network
 
 import (
+	"errors"
+	"fmt"
+	"net"
 	"net/http"
+	"net/netip"
 	"net/url"
+	"syscall"
 	"time"
 )
 
 // Client is an encapsulation and extension of the http client.
 type Client struct {
 	Client *http.Client
+
+	// Headers are the default headers of each request, headers set by the request will overwrite them.
+	Headers http.Header
+
+	// UserAgentClass picks a random user agent of the class for each request, e.g. mobile.
+	// The user agent set by the request is kept, empty means the user agent of headers.
+	UserAgentClass string
 }
 
 type Config struct {
-	Timeout  time.Duration `mapstructure:"timeout"`
-	ProxyUrl string        `mapstructure:"proxy_url"`
+	Timeout  time.Duration     `mapstructure:"timeout"` // Timeout is the overall timeout of a request, including reading the body.
+	ProxyUrl string            `mapstructure:"proxy_url"`
+	Headers  map[string]string `mapstructure:"headers"` // Headers are merged over the default headers.
+
+	// UserAgentClass is the class of user agents picked at random for requests, e.g. desktop, mobile.
+	// It is the preferred class of engine if empty, and ignored if the user agent is set by headers.
+	UserAgentClass string `mapstructure:"user_agent_class"`
+
+	// The timeouts of phases of a request, so that unreachable hosts fail fast while slow bodies are allowed more time.
+	ConnectTimeout        time.Duration `mapstructure:"connect_timeout"`         // ConnectTimeout is the timeout of dialing, including DNS.
+	TLSHandshakeTimeout   time.Duration `mapstructure:"tls_handshake_timeout"`   // TLSHandshakeTimeout is the timeout of TLS handshake.
+	ResponseHeaderTimeout time.Duration `mapstructure:"response_header_timeout"` // ResponseHeaderTimeout is the timeout of waiting for response headers after the request is written.
+
+	// PublicOnly rejects dialing private, loopback, link-local and unspecified addresses,
+	// e.g. for fetching the urls of results, which are chosen by the third parties.
+	// The addresses are checked after DNS resolving, so that the names resolved to internal addresses are rejected as well.
+	PublicOnly bool `mapstructure:"-"`
+
+	// PoolKey is the key of engine-hash assignment of proxy pool, it is set to the engine name by the engine configuration.
+	PoolKey string `mapstructure:"-"`
+}
+
+// defaultHeaders are sent by every request unless they are overwritten.
+var defaultHeaders = http.Header{
+	"User-Agent": {"Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/120.0.0.0 Safari/537.36"},
+	"Accept":     {"*/*"},
 }
 
 // DefaultClient return the default http client.
@@ -25,26 +61,105 @@ func DefaultClient() *Client {
 // if config is nil, it will return a default client.
 func NewClient(config *Config) *Client {
 	if config == nil {
-		return &Client{http.DefaultClient}
+		return &Client{Client: http.DefaultClient, Headers: defaultHeaders.Clone()}
+	}
+
+	headers := defaultHeaders.Clone()
+	userAgentClass := config.UserAgentClass
+	for k, v := range config.Headers {
+		headers.Set(k, v)
+		if http.CanonicalHeaderKey(k) == "User-Agent" {
+			userAgentClass = ""
+		}
 	}
 
-	transport := http.DefaultTransport
+	var rt http.RoundTripper
+	transport := newTransport(config)
+	if transport != nil {
+		rt = transport
+	}
+	// clients without their own proxy share the proxy pool.
+	if proxyPool != nil && config.ProxyUrl == "" {
+		if transport == nil {
+			transport = http.DefaultTransport.(*http.Transport).Clone()
+		}
+		rt = proxyPool.roundTripper(config.PoolKey, transport)
+	}
+
+	// the default transport is used if rt is nil.
+	if rt != nil || config.Timeout > 0 {
+		return &Client{Client: &http.Client{Timeout: config.Timeout, Transport: rt}, Headers: headers, UserAgentClass: userAgentClass}
+	}
+
+	return &Client{Client: http.DefaultClient, Headers: headers, UserAgentClass: userAgentClass}
+}
+
+// newTransport creates a transport for the proxy and timeouts of config, nil is returned if none of them is set.
+func newTransport(config *Config) *http.Transport {
+	var proxy *url.URL
 	if config.ProxyUrl != "" {
 		if parsedU, err := url.Parse(config.ProxyUrl); err == nil {
-			transport = &http.Transport{
-				Proxy:             http.ProxyURL(parsedU),
-				DisableKeepAlives: true,
-			}
+			proxy = parsedU
 		}
 	}
-
-	if transport != http.DefaultTransport || config.Timeout > 0 {
-		return &Client{&http.Client{Timeout: config.Timeout, Transport: transport}}
+	if proxy == nil && config.ConnectTimeout <= 0 && config.TLSHandshakeTimeout <= 0 && config.ResponseHeaderTimeout <= 0 && !config.PublicOnly {
+		return nil
 	}
 
-	return &Client{http.DefaultClient}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if proxy != nil {
+		transport.Proxy = http.ProxyURL(proxy)
+		transport.DisableKeepAlives = true
+	}
+	if config.ConnectTimeout > 0 || config.PublicOnly {
+		dialer := &net.Dialer{Timeout: 30 * time.Second, KeepAlive: 30 * time.Second}
+		if config.ConnectTimeout > 0 {
+			dialer.Timeout = config.ConnectTimeout
+		}
+		if config.PublicOnly {
+			dialer.Control = publicOnlyControl
+		}
+		transport.DialContext = dialer.DialContext
+	}
+	if config.TLSHandshakeTimeout > 0 {
+		transport.TLSHandshakeTimeout = config.TLSHandshakeTimeout
+	}
+	if config.ResponseHeaderTimeout > 0 {
+		transport.ResponseHeaderTimeout = config.ResponseHeaderTimeout
+	}
+	return transport
 }
 
 func (c *Client) Get() *Request {
 	return NewRequest(c).Method(http.MethodGet)
 }
+
+func (c *Client) Post() *Request {
+	return NewRequest(c).Method(http.MethodPost)
+}
+
+// ErrNonPublicAddress is returned when a client of public only dials a non-public address.
+var ErrNonPublicAddress = errors.New("dialing non-public address")
+
+// publicOnlyControl rejects the connections to non-public addresses, it is called with the resolved address before dialing.
+func publicOnlyControl(_, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+	ip, err := netip.ParseAddr(host)
+	if err != nil {
+		return err
+	}
+	if !IsPublicAddr(ip) {
+		return fmt.Errorf("%w: %s", ErrNonPublicAddress, ip)
+	}
+	return nil
+}
+
+// IsPublicAddr reports whether ip is neither private, loopback, link-local, multicast nor unspecified.
+func IsPublicAddr(ip netip.Addr) bool {
+	ip = ip.Unmap()
+	return ip.IsValid() && !ip.IsPrivate() && !ip.IsLoopback() && !ip.IsLinkLocalUnicast() &&
+		!ip.IsLinkLocalMulticast() && !ip.IsInterfaceLocalMulticast() && !ip.IsMulticast() && !ip.IsUnspecified()
+}
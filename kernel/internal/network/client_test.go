@@ -0,0 +1,110 @@
+package network
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"net/url"
+	"testing"
+	"time"
+)
+
+// stallingServer stalls the headers for headerDelay and then the body for bodyDelay.
+func stallingServer(t *testing.T, headerDelay, bodyDelay time.Duration) *url.URL {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(headerDelay)
+		w.Header().Set("Content-Type", "text/plain")
+		w.WriteHeader(http.StatusOK)
+		w.(http.Flusher).Flush()
+		time.Sleep(bodyDelay)
+		w.Write([]byte("ok"))
+	}))
+	t.Cleanup(srv.Close)
+	u, _ := url.Parse(srv.URL)
+	return u
+}
+
+func TestResponseHeaderTimeout(t *testing.T) {
+	c := NewClient(&Config{Timeout: 5 * time.Second, ResponseHeaderTimeout: 50 * time.Millisecond})
+
+	// the stalled headers fail fast, long before the overall timeout.
+	start := time.Now()
+	r := c.Get().Base(stallingServer(t, 500*time.Millisecond, 0)).Do(context.Background())
+	if r.Err == nil {
+		t.Fatal("the stalled headers do not time out")
+	}
+	if elapsed := time.Since(start); elapsed >= 500*time.Millisecond {
+		t.Errorf("timed out after %v, want the response header timeout", elapsed)
+	}
+
+	// the slow body after the headers is allowed by the overall timeout.
+	r = c.Get().Base(stallingServer(t, 0, 200*time.Millisecond)).Do(context.Background())
+	if r.Err != nil || string(r.Body) != "ok" {
+		t.Errorf("the slow body fails: %v, %q", r.Err, r.Body)
+	}
+}
+
+func TestOverallTimeout(t *testing.T) {
+	c := NewClient(&Config{Timeout: 50 * time.Millisecond, ResponseHeaderTimeout: time.Second})
+	if r := c.Get().Base(stallingServer(t, 0, 500*time.Millisecond)).Do(context.Background()); r.Err == nil {
+		t.Error("the slow body does not exceed the overall timeout")
+	}
+}
+
+func TestNewTransport(t *testing.T) {
+	if tr := newTransport(&Config{Timeout: time.Second}); tr != nil {
+		t.Errorf("the transport is created without proxy or phase timeouts: %v", tr)
+	}
+
+	tr := newTransport(&Config{ConnectTimeout: time.Second, TLSHandshakeTimeout: 2 * time.Second, ResponseHeaderTimeout: 3 * time.Second})
+	if tr == nil {
+		t.Fatal("no transport is created for the phase timeouts")
+	}
+	if tr.DialContext == nil || tr.TLSHandshakeTimeout != 2*time.Second || tr.ResponseHeaderTimeout != 3*time.Second {
+		t.Errorf("the timeouts are not set: %+v", tr)
+	}
+	if tr.Proxy == nil {
+		t.Error("the proxy from environment of default transport is dropped")
+	}
+}
+
+func TestPublicOnly(t *testing.T) {
+	// the test server listens on the loopback address.
+	srv := stallingServer(t, 0, 0)
+
+	r := NewClient(&Config{PublicOnly: true}).Get().Base(srv).Do(context.Background())
+	if !errors.Is(r.Err, ErrNonPublicAddress) {
+		t.Errorf("err = %v, want the loopback address rejected", r.Err)
+	}
+	if r := NewClient(&Config{}).Get().Base(srv).Do(context.Background()); r.Err != nil {
+		t.Errorf("the client not public only fails: %v", r.Err)
+	}
+}
+
+func TestIsPublicAddr(t *testing.T) {
+	cases := map[string]bool{
+		"8.8.8.8":              true,
+		"2001:4860:4860::8888": true,
+		"127.0.0.1":            false,
+		"::1":                  false,
+		"10.1.2.3":             false,
+		"172.16.0.1":           false,
+		"192.168.1.1":          false,
+		"fd00::1":              false,
+		"169.254.169.254":      false,
+		"fe80::1":              false,
+		"0.0.0.0":              false,
+		"::":                   false,
+		"224.0.0.1":            false,
+		"::ffff:127.0.0.1":     false,
+		"::ffff:8.8.8.8":       true,
+	}
+	for addr, want := range cases {
+		if got := IsPublicAddr(netip.MustParseAddr(addr)); got != want {
+			t.Errorf("IsPublicAddr(%s) = %v, want %v", addr, got, want)
+		}
+	}
+}
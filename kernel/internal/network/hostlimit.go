@@ -0,0 +1,128 @@
+package network
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+)
+
+// HostLimitConfig is the configuration of limiting the requests to each upstream host,
+// so that engines sharing a host, e.g. bing and bing_videos, do not hammer it with parallel requests.
+type HostLimitConfig struct {
+	Enable bool `mapstructure:"enable"`
+
+	// MaxConcurrency is the maximum of requests in flight to a host, 0 means unlimited.
+	MaxConcurrency int `mapstructure:"max_concurrency"`
+	// MinInterval is the minimum interval between the starts of requests to a host.
+	MinInterval time.Duration `mapstructure:"min_interval"`
+
+	// Hosts override the limits of certain hosts.
+	// It is a list rather than a map keyed by host, because the dots of host split the keys of config.
+	Hosts []HostLimit `mapstructure:"hosts"`
+}
+
+// HostLimit is the limit of requests to a host.
+type HostLimit struct {
+	Host           string        `mapstructure:"host"` // Host is the host name, e.g. www.bing.com.
+	MaxConcurrency int           `mapstructure:"max_concurrency"`
+	MinInterval    time.Duration `mapstructure:"min_interval"`
+}
+
+// hostLimiter is shared by all clients, nil means no limit.
+var hostLimiter *HostLimiter
+
+// InitHostLimit creates the shared host limiter by config.
+func InitHostLimit(c HostLimitConfig) {
+	if !c.Enable {
+		hostLimiter = nil
+		return
+	}
+	hostLimiter = NewHostLimiter(c)
+}
+
+// HostLimiter limits the concurrency and interval of requests to each host.
+type HostLimiter struct {
+	conf HostLimitConfig
+
+	mu    sync.Mutex
+	hosts map[string]*hostState
+}
+
+type hostState struct {
+	limit HostLimit
+	sem   chan struct{} // sem is nil if the concurrency is unlimited.
+
+	mu   sync.Mutex
+	next time.Time // next is the earliest start of the next request.
+}
+
+func NewHostLimiter(c HostLimitConfig) *HostLimiter {
+	return &HostLimiter{conf: c, hosts: map[string]*hostState{}}
+}
+
+func (l *HostLimiter) state(host string) *hostState {
+	host = strings.ToLower(host)
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if s, ok := l.hosts[host]; ok {
+		return s
+	}
+
+	limit := HostLimit{Host: host, MaxConcurrency: l.conf.MaxConcurrency, MinInterval: l.conf.MinInterval}
+	for _, h := range l.conf.Hosts {
+		if strings.EqualFold(h.Host, host) {
+			limit = h
+			break
+		}
+	}
+
+	s := &hostState{limit: limit}
+	if limit.MaxConcurrency > 0 {
+		s.sem = make(chan struct{}, limit.MaxConcurrency)
+	}
+	l.hosts[host] = s
+	return s
+}
+
+// Acquire waits until a request to the host is allowed, the returned release must be called once the request is done.
+// The error of context is returned if it is done before allowed.
+func (l *HostLimiter) Acquire(ctx context.Context, host string) (release func(), err error) {
+	s := l.state(host)
+
+	release = func() {}
+	if s.sem != nil {
+		select {
+		case s.sem <- struct{}{}:
+			release = func() { <-s.sem }
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+
+	if s.limit.MinInterval > 0 {
+		// the start of request is reserved, so that the waiting requests are spaced by the interval.
+		s.mu.Lock()
+		now := time.Now()
+		start := s.next
+		if start.Before(now) {
+			start = now
+		}
+		s.next = start.Add(s.limit.MinInterval)
+		s.mu.Unlock()
+
+		if wait := time.Until(start); wait > 0 {
+			timer := time.NewTimer(wait)
+			defer timer.Stop()
+			select {
+			case <-timer.C:
+			case <-ctx.Done():
+				release()
+				return nil, ctx.Err()
+			}
+		}
+	}
+	return release, nil
+}
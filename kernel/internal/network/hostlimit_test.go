@@ -0,0 +1,160 @@
+package network
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// track counts a request in flight and records the peak of them, the returned done must be called once the request is done.
+func track(inFlight, peak *atomic.Int32) (done func()) {
+	n := inFlight.Add(1)
+	for {
+		p := peak.Load()
+		if n <= p || peak.CompareAndSwap(p, n) {
+			break
+		}
+	}
+	return func() { inFlight.Add(-1) }
+}
+
+func TestHostLimiterConcurrency(t *testing.T) {
+	l := NewHostLimiter(HostLimitConfig{Enable: true, MaxConcurrency: 2})
+
+	var inFlight, peak atomic.Int32
+	var wg sync.WaitGroup
+	for i := 0; i < 6; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			release, err := l.Acquire(context.Background(), "www.bing.com")
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			defer release()
+
+			done := track(&inFlight, &peak)
+			time.Sleep(20 * time.Millisecond)
+			done()
+		}()
+	}
+	wg.Wait()
+
+	if p := peak.Load(); p != 2 {
+		t.Errorf("peak of requests in flight = %d, want 2", p)
+	}
+}
+
+func TestHostLimiterInterval(t *testing.T) {
+	l := NewHostLimiter(HostLimitConfig{Enable: true, MinInterval: 30 * time.Millisecond})
+
+	start := time.Now()
+	for i := 0; i < 3; i++ {
+		release, err := l.Acquire(context.Background(), "www.bing.com")
+		if err != nil {
+			t.Fatal(err)
+		}
+		release()
+	}
+	// the first request starts at once, the others are spaced by the interval.
+	if elapsed := time.Since(start); elapsed < 60*time.Millisecond {
+		t.Errorf("3 requests started in %v, want at least 60ms", elapsed)
+	}
+
+	// the other hosts are not delayed.
+	start = time.Now()
+	release, err := l.Acquire(context.Background(), "www.google.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+	release()
+	if elapsed := time.Since(start); elapsed >= 30*time.Millisecond {
+		t.Errorf("the request to another host waited %v", elapsed)
+	}
+}
+
+func TestHostLimiterOverride(t *testing.T) {
+	l := NewHostLimiter(HostLimitConfig{Enable: true, MaxConcurrency: 1, Hosts: []HostLimit{{Host: "WWW.Bing.com", MaxConcurrency: 2}}})
+
+	// the override of host is matched case-insensitively.
+	for i := 0; i < 2; i++ {
+		if _, err := l.Acquire(context.Background(), "www.bing.COM"); err != nil {
+			t.Fatalf("request %d: %v", i, err)
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if _, err := l.Acquire(ctx, "www.bing.com"); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("err = %v, want the request beyond the override waiting until done", err)
+	}
+
+	// the default limit applies to the hosts without override.
+	if _, err := l.Acquire(context.Background(), "www.google.com"); err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel = context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if _, err := l.Acquire(ctx, "www.google.com"); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("err = %v, want the request beyond the default limit waiting until done", err)
+	}
+}
+
+func TestHostLimiterCanceledInterval(t *testing.T) {
+	l := NewHostLimiter(HostLimitConfig{Enable: true, MaxConcurrency: 1, MinInterval: time.Second})
+	release, err := l.Acquire(context.Background(), "www.bing.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+	release()
+
+	// the slot is released if the context is done while waiting for the interval.
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if _, err := l.Acquire(ctx, "www.bing.com"); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("err = %v, want waiting for the interval", err)
+	}
+	select {
+	case l.state("www.bing.com").sem <- struct{}{}:
+	default:
+		t.Error("the slot is not released after the wait is canceled")
+	}
+}
+
+func TestRequestHostLimit(t *testing.T) {
+	var inFlight, peak atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		defer track(&inFlight, &peak)()
+		time.Sleep(20 * time.Millisecond)
+		w.Write([]byte("ok"))
+	}))
+	defer srv.Close()
+
+	InitHostLimit(HostLimitConfig{Enable: true, MaxConcurrency: 1})
+	defer InitHostLimit(HostLimitConfig{})
+
+	base, _ := url.Parse(srv.URL)
+	c := NewClient(&Config{})
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if r := c.Get().Base(base).Do(context.Background()); r.Err != nil {
+				t.Error(r.Err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if p := peak.Load(); p != 1 {
+		t.Errorf("peak of requests in flight = %d, want 1", p)
+	}
+}
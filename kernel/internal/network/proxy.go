@@ -0,0 +1,171 @@
+package network
+
+import (
+	"errors"
+	"hash/fnv"
+	"net/http"
+	"net/url"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	// ProxyStrategyRoundRobin assigns the proxies of pool to requests in turn.
+	ProxyStrategyRoundRobin = "round_robin"
+	// ProxyStrategyEngineHash assigns a proxy to each engine, so that the session of engine stays on one egress ip.
+	ProxyStrategyEngineHash = "engine_hash"
+)
+
+type ProxyPoolConfig struct {
+	Proxies  []string `mapstructure:"proxies"`  // Proxies are the urls of proxies, the pool is disabled if empty.
+	Strategy string   `mapstructure:"strategy"` // Strategy is one of round_robin and engine_hash, round_robin by default.
+
+	// MaxFailures is the count of consecutive failures a proxy is evicted after.
+	MaxFailures int `mapstructure:"max_failures"`
+	// EvictDuration is the duration a proxy is evicted for, it is retried after that.
+	EvictDuration time.Duration `mapstructure:"evict_duration"`
+}
+
+// proxyPool is shared by the clients without their own proxy, nil means no pool.
+var proxyPool *ProxyPool
+
+// InitProxyPool creates the shared proxy pool by config.
+func InitProxyPool(c ProxyPoolConfig) error {
+	if len(c.Proxies) == 0 {
+		proxyPool = nil
+		return nil
+	}
+	pool, err := NewProxyPool(c)
+	if err != nil {
+		return err
+	}
+	proxyPool = pool
+	return nil
+}
+
+type poolProxy struct {
+	url *url.URL
+
+	failures     int
+	evictedUntil time.Time
+}
+
+// ProxyPool is a pool of proxies with health eviction.
+type ProxyPool struct {
+	strategy      string
+	maxFailures   int
+	evictDuration time.Duration
+
+	next atomic.Uint64
+
+	mu      sync.Mutex
+	proxies []*poolProxy
+}
+
+func NewProxyPool(c ProxyPoolConfig) (*ProxyPool, error) {
+	strategy := c.Strategy
+	if strategy == "" {
+		strategy = ProxyStrategyRoundRobin
+	}
+	if strategy != ProxyStrategyRoundRobin && strategy != ProxyStrategyEngineHash {
+		return nil, errors.New("unknown strategy of proxy pool: " + strategy)
+	}
+
+	p := &ProxyPool{strategy: strategy, maxFailures: c.MaxFailures, evictDuration: c.EvictDuration}
+	if p.maxFailures <= 0 {
+		p.maxFailures = 3
+	}
+	if p.evictDuration <= 0 {
+		p.evictDuration = time.Minute
+	}
+
+	for _, raw := range c.Proxies {
+		u, err := url.Parse(raw)
+		if err != nil {
+			return nil, err
+		}
+		p.proxies = append(p.proxies, &poolProxy{url: u})
+	}
+	return p, nil
+}
+
+// pick picks a healthy proxy for the key, all proxies are candidates if none of them is healthy.
+// The engine-hash strategy uses rendezvous hashing, so that evicting a proxy only moves the keys assigned to it.
+func (p *ProxyPool) pick(key string) *poolProxy {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	now := time.Now()
+	var alive []*poolProxy
+	for _, proxy := range p.proxies {
+		if now.After(proxy.evictedUntil) {
+			alive = append(alive, proxy)
+		}
+	}
+	if len(alive) == 0 {
+		alive = p.proxies
+	}
+
+	if p.strategy == ProxyStrategyRoundRobin {
+		return alive[(p.next.Add(1)-1)%uint64(len(alive))]
+	}
+
+	var best *poolProxy
+	var bestWeight uint64
+	for _, proxy := range alive {
+		h := fnv.New64a()
+		h.Write([]byte(key))
+		h.Write([]byte(proxy.url.String()))
+		if w := h.Sum64(); best == nil || w > bestWeight {
+			best, bestWeight = proxy, w
+		}
+	}
+	return best
+}
+
+// report records the result of a request by the proxy, the proxy is evicted after consecutive failures.
+func (p *ProxyPool) report(proxy *poolProxy, err error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if err == nil {
+		proxy.failures = 0
+		return
+	}
+	proxy.failures++
+	if proxy.failures >= p.maxFailures {
+		proxy.failures = 0
+		proxy.evictedUntil = time.Now().Add(p.evictDuration)
+	}
+}
+
+// roundTripper returns a round tripper requesting by the proxies of pool for the key,
+// the transport of each proxy is cloned from base.
+func (p *ProxyPool) roundTripper(key string, base *http.Transport) http.RoundTripper {
+	rt := &poolRoundTripper{pool: p, key: key, transports: map[*poolProxy]*http.Transport{}}
+	for _, proxy := range p.proxies {
+		t := base.Clone()
+		t.Proxy = http.ProxyURL(proxy.url)
+		rt.transports[proxy] = t
+	}
+	return rt
+}
+
+type poolRoundTripper struct {
+	pool *ProxyPool
+	key  string
+
+	// transports are created for every proxy once, they are read-only.
+	transports map[*poolProxy]*http.Transport
+}
+
+func (rt *poolRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	proxy := rt.pool.pick(rt.key)
+	resp, err := rt.transports[proxy].RoundTrip(req)
+	// the failures caused by canceling are not the fault of proxy.
+	if req.Context().Err() == nil {
+		rt.pool.report(proxy, err)
+	}
+	return resp, err
+}
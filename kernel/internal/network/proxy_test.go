@@ -0,0 +1,144 @@
+package network
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func newTestPool(t *testing.T, strategy string, proxies ...string) *ProxyPool {
+	t.Helper()
+	p, err := NewProxyPool(ProxyPoolConfig{Proxies: proxies, Strategy: strategy, MaxFailures: 2, EvictDuration: 50 * time.Millisecond})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return p
+}
+
+func TestNewProxyPool(t *testing.T) {
+	if _, err := NewProxyPool(ProxyPoolConfig{Proxies: []string{"http://p1:8080"}, Strategy: "random"}); err == nil {
+		t.Error("the unknown strategy is accepted")
+	}
+	if _, err := NewProxyPool(ProxyPoolConfig{Proxies: []string{"http://p1:8080", ":bad"}}); err == nil {
+		t.Error("the invalid proxy is accepted")
+	}
+
+	p, err := NewProxyPool(ProxyPoolConfig{Proxies: []string{"http://p1:8080"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p.strategy != ProxyStrategyRoundRobin || p.maxFailures != 3 || p.evictDuration != time.Minute {
+		t.Errorf("defaults of pool: %s, %d, %v", p.strategy, p.maxFailures, p.evictDuration)
+	}
+}
+
+func TestProxyPoolRoundRobin(t *testing.T) {
+	p := newTestPool(t, ProxyStrategyRoundRobin, "http://p1:8080", "http://p2:8080", "http://p3:8080")
+
+	var got []string
+	for i := 0; i < 6; i++ {
+		got = append(got, p.pick("bing").url.Host)
+	}
+	if want := "[p1:8080 p2:8080 p3:8080 p1:8080 p2:8080 p3:8080]"; fmt.Sprint(got) != want {
+		t.Errorf("proxies = %v, want %s", got, want)
+	}
+}
+
+func TestProxyPoolEngineHash(t *testing.T) {
+	p := newTestPool(t, ProxyStrategyEngineHash, "http://p1:8080", "http://p2:8080", "http://p3:8080")
+
+	keys := []string{"bing", "google", "duckduckgo", "baidu", "wikipedia", "yahoo", "brave", "mojeek"}
+	assigned := map[string]*poolProxy{}
+	for _, key := range keys {
+		assigned[key] = p.pick(key)
+		// the engine sticks to its proxy.
+		for i := 0; i < 3; i++ {
+			if proxy := p.pick(key); proxy != assigned[key] {
+				t.Fatalf("%s moved from %s to %s", key, assigned[key].url, proxy.url)
+			}
+		}
+	}
+
+	// evicting a proxy only moves the engines assigned to it.
+	evicted := assigned["bing"]
+	for i := 0; i < 2; i++ {
+		p.report(evicted, errors.New("connection refused"))
+	}
+	for _, key := range keys {
+		proxy := p.pick(key)
+		if proxy == evicted {
+			t.Errorf("%s is assigned to the evicted proxy", key)
+		}
+		if assigned[key] != evicted && proxy != assigned[key] {
+			t.Errorf("%s moved from the healthy %s to %s", key, assigned[key].url, proxy.url)
+		}
+	}
+
+	// the evicted proxy is retried after the evict duration, the engines move back.
+	time.Sleep(60 * time.Millisecond)
+	if proxy := p.pick("bing"); proxy != evicted {
+		t.Errorf("bing is not assigned back to %s, got %s", evicted.url, proxy.url)
+	}
+}
+
+func TestProxyPoolEviction(t *testing.T) {
+	p := newTestPool(t, ProxyStrategyRoundRobin, "http://p1:8080", "http://p2:8080")
+	p1 := p.proxies[0]
+
+	// a success resets the consecutive failures.
+	p.report(p1, errors.New("timeout"))
+	p.report(p1, nil)
+	p.report(p1, errors.New("timeout"))
+	for i := 0; i < 4; i++ {
+		if p.pick("bing") != p.proxies[i%2] {
+			t.Fatal("the proxy is evicted before consecutive failures")
+		}
+	}
+
+	p.report(p1, errors.New("timeout"))
+	for i := 0; i < 3; i++ {
+		if proxy := p.pick("bing"); proxy == p1 {
+			t.Fatal("the evicted proxy is picked")
+		}
+	}
+
+	// all proxies are candidates if none of them is healthy.
+	p.report(p.proxies[1], errors.New("timeout"))
+	p.report(p.proxies[1], errors.New("timeout"))
+	if proxy := p.pick("bing"); proxy == nil {
+		t.Error("no proxy is picked when all are evicted")
+	}
+}
+
+func TestProxyPoolRoundTripper(t *testing.T) {
+	// the proxies answer the proxied requests themselves, the host of each proxy is returned.
+	healthy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("healthy " + r.URL.Host))
+	}))
+	defer healthy.Close()
+	broken := httptest.NewServer(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {}))
+	broken.Close()
+
+	p := newTestPool(t, ProxyStrategyRoundRobin, broken.URL, healthy.URL)
+	client := &http.Client{Transport: p.roundTripper("bing", &http.Transport{})}
+
+	var failures int
+	for i := 0; i < 4; i++ {
+		resp, err := client.Get("http://www.bing.com/search?q=go")
+		if err != nil {
+			failures++
+			continue
+		}
+		resp.Body.Close()
+	}
+	// the broken proxy is evicted after 2 failures, the requests are sent by the healthy one then.
+	if failures != 2 {
+		t.Errorf("got %d failures, want 2 before the broken proxy is evicted", failures)
+	}
+	if !time.Now().Before(p.proxies[0].evictedUntil) {
+		t.Error("the broken proxy is not evicted")
+	}
+}
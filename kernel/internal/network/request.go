@@ -25,6 +25,18 @@ type Request struct {
 	timeout time.Duration
 
 	body []byte
+
+	// jar stores the cookies of the request, it is shared by requests of the same session.
+	jar http.CookieJar
+
+	// keepCharset disables converting the response body to UTF-8.
+	keepCharset bool
+
+	// maxBodySize is the maximum of bytes of the response body read, 0 means unlimited.
+	maxBodySize int64
+
+	// noRedirect returns the redirect response instead of following it.
+	noRedirect bool
 }
 
 func NewRequest(c *Client) *Request {
@@ -66,12 +78,15 @@ func (r *Request) Header(key string, values ...string) *Request {
 	return r
 }
 
-func (r *Request) Param(key string, value string) *Request {
+// Param sets the values of the query parameter, e.g. fq=a&fq=b, the values set before are replaced.
+func (r *Request) Param(key string, values ...string) *Request {
 	if r.params == nil {
 		r.params = url.Values{}
 	}
 	r.params.Del(key)
-	r.params.Add(key, value)
+	for _, value := range values {
+		r.params.Add(key, value)
+	}
 	return r
 }
 
@@ -80,9 +95,47 @@ func (r *Request) Body(b []byte) *Request {
 	return r
 }
 
+// Jar sets the cookie jar of the request.
+// Requests with the same jar will send cookies received by each other.
+func (r *Request) Jar(jar http.CookieJar) *Request {
+	r.jar = jar
+	return r
+}
+
+// KeepCharset keeps the original charset of response body,
+// used by engines which handle their own decoding.
+func (r *Request) KeepCharset() *Request {
+	r.keepCharset = true
+	return r
+}
+
+// MaxBodySize limits the bytes of the response body read, the rest of body is dropped.
+// It is for the responses of untrusted size, e.g. the pages of results.
+func (r *Request) MaxBodySize(n int64) *Request {
+	r.maxBodySize = n
+	return r
+}
+
+// NoRedirect returns the redirect response instead of following it, its location is set in Result.Location.
+// It is for resolving the redirect links without fetching their targets.
+func (r *Request) NoRedirect() *Request {
+	r.noRedirect = true
+	return r
+}
+
+// Form sets the url encoded form as the request body, usually used by POST request.
+func (r *Request) Form(form url.Values) *Request {
+	return r.Body([]byte(form.Encode())).Header("Content-Type", "application/x-www-form-urlencoded")
+}
+
 // URL returns the url according to the Request.
+// The base url is copied, so building the url has no side effects on it.
 func (r *Request) URL() *url.URL {
-	u := r.base
+	u := &url.URL{}
+	if r.base != nil {
+		b := *r.base
+		u = &b
+	}
 
 	query := url.Values{}
 	for k, vs := range r.params {
@@ -99,15 +152,33 @@ func (r *Request) URL() *url.URL {
 
 // newHTTPRequest returns a build-in http request from Request.
 func (r *Request) newHTTPRequest(ctx context.Context) (*http.Request, error) {
+	method := r.method
+	if method == "" {
+		method = http.MethodGet
+	}
+
 	var body io.Reader
 	if r.body != nil {
 		body = bytes.NewReader(r.body)
 	}
-	req, err := http.NewRequestWithContext(ctx, r.method, r.URL().String(), body)
+	req, err := http.NewRequestWithContext(ctx, method, r.URL().String(), body)
 	if err != nil {
 		return nil, err
 	}
-	req.Header = r.headers
+
+	// headers set by request are merged over the default headers of client.
+	req.Header = r.c.Headers.Clone()
+	if req.Header == nil {
+		req.Header = http.Header{}
+	}
+	if r.c.UserAgentClass != "" && r.headers.Get("User-Agent") == "" {
+		if ua := RandomUserAgent(r.c.UserAgentClass); ua != "" {
+			req.Header.Set("User-Agent", ua)
+		}
+	}
+	for k, vs := range r.headers {
+		req.Header[k] = vs
+	}
 	return req, nil
 }
 
@@ -118,6 +189,19 @@ func (r *Request) request(ctx context.Context, fn func(r *http.Request, resp *ht
 		client = http.DefaultClient
 	}
 
+	if r.jar != nil {
+		c := *client
+		c.Jar = r.jar
+		client = &c
+	}
+	if r.noRedirect {
+		c := *client
+		c.CheckRedirect = func(req *http.Request, via []*http.Request) error {
+			return http.ErrUseLastResponse
+		}
+		client = &c
+	}
+
 	if r.timeout > 0 {
 		var cancel context.CancelFunc
 		ctx, cancel = context.WithTimeout(ctx, r.timeout)
@@ -129,6 +213,15 @@ func (r *Request) request(ctx context.Context, fn func(r *http.Request, resp *ht
 		return err
 	}
 
+	// the requests to a host are limited across engines.
+	if hostLimiter != nil {
+		release, err := hostLimiter.Acquire(ctx, req.URL.Hostname())
+		if err != nil {
+			return err
+		}
+		defer release()
+	}
+
 	resp, err := client.Do(req)
 	if err != nil {
 		return err
@@ -136,6 +229,8 @@ func (r *Request) request(ctx context.Context, fn func(r *http.Request, resp *ht
 	if resp == nil {
 		return nil
 	}
+	// the body is closed even if it is not read to the end, e.g. limited by the max body size.
+	defer resp.Body.Close()
 
 	fn(req, resp)
 
@@ -144,9 +239,13 @@ func (r *Request) request(ctx context.Context, fn func(r *http.Request, resp *ht
 }
 
 type Result struct {
-	Body       []byte
-	Err        error
-	StatusCode int
+	Body        []byte
+	Err         error
+	StatusCode  int
+	ContentType string
+
+	// Location is the location of the redirect response, only set if the redirect is not followed.
+	Location string
 }
 
 // resultForResponse parse the http response.
@@ -154,25 +253,45 @@ type Result struct {
 func (r *Request) resultForResponse(resp *http.Response) Result {
 	var body []byte
 	if resp.Body != nil {
-		d, err := io.ReadAll(resp.Body)
+		var reader io.Reader = resp.Body
+		if r.maxBodySize > 0 {
+			reader = io.LimitReader(resp.Body, r.maxBodySize)
+		}
+		d, err := io.ReadAll(reader)
 		if err != nil {
 			return Result{
 				Err: fmt.Errorf("error happen when reading response Body. error: %w", err),
 			}
 		}
 		body = d
+
+		if !r.keepCharset {
+			if d, err = DecodeToUTF8(body, resp.Header.Get("Content-Type")); err == nil {
+				body = d
+			}
+		}
+	}
+	if r.noRedirect && resp.StatusCode >= http.StatusMultipleChoices && resp.StatusCode < http.StatusBadRequest {
+		return Result{
+			Body:        body,
+			StatusCode:  resp.StatusCode,
+			ContentType: resp.Header.Get("Content-Type"),
+			Location:    resp.Header.Get("Location"),
+		}
 	}
 	if resp.StatusCode < http.StatusOK || resp.StatusCode > http.StatusPartialContent {
 		err := fmt.Errorf("status code of response is not ok. status code: %d", resp.StatusCode)
 		return Result{
-			Body:       body,
-			Err:        err,
-			StatusCode: resp.StatusCode,
+			Body:        body,
+			Err:         err,
+			StatusCode:  resp.StatusCode,
+			ContentType: resp.Header.Get("Content-Type"),
 		}
 	}
 	return Result{
-		Body:       body,
-		StatusCode: resp.StatusCode,
+		Body:        body,
+		StatusCode:  resp.StatusCode,
+		ContentType: resp.Header.Get("Content-Type"),
 	}
 }
 
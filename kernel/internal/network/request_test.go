@@ -0,0 +1,164 @@
+package network
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+)
+
+// received is the request the echo server got.
+type received struct {
+	method      string
+	contentType string
+	body        string
+	header      http.Header
+}
+
+// newEchoServer records the last request to received.
+func newEchoServer(t *testing.T) (*httptest.Server, *received) {
+	t.Helper()
+	got := &received{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, _ := io.ReadAll(r.Body)
+		got.method, got.contentType, got.body, got.header = r.Method, r.Header.Get("Content-Type"), string(b), r.Header.Clone()
+		w.Write([]byte("ok"))
+	}))
+	t.Cleanup(srv.Close)
+	return srv, got
+}
+
+func TestRequestPostForm(t *testing.T) {
+	srv, got := newEchoServer(t)
+	base, _ := url.Parse(srv.URL)
+
+	r := DefaultClient().Post().Base(base).Path("/search").Form(url.Values{"q": {"hello world"}, "page": {"2"}}).Do(context.Background())
+	if r.Err != nil {
+		t.Fatal(r.Err)
+	}
+	if got.method != http.MethodPost {
+		t.Errorf("method = %s, want POST", got.method)
+	}
+	if got.contentType != "application/x-www-form-urlencoded" {
+		t.Errorf("content type = %s", got.contentType)
+	}
+	if want := "page=2&q=hello+world"; got.body != want {
+		t.Errorf("body = %q, want %q", got.body, want)
+	}
+}
+
+func TestRequestPostBody(t *testing.T) {
+	srv, got := newEchoServer(t)
+	base, _ := url.Parse(srv.URL)
+
+	body := `{"query":{"match":{"title":"go"}}}`
+	r := DefaultClient().Post().Base(base).Path("/index/_search").Body([]byte(body)).Header("Content-Type", "application/json").Do(context.Background())
+	if r.Err != nil {
+		t.Fatal(r.Err)
+	}
+	if got.method != http.MethodPost || got.body != body || got.contentType != "application/json" {
+		t.Errorf("got %s %s %q", got.method, got.contentType, got.body)
+	}
+}
+
+func TestRequestGetWithoutBody(t *testing.T) {
+	srv, got := newEchoServer(t)
+	base, _ := url.Parse(srv.URL)
+
+	if r := DefaultClient().Get().Base(base).Path("/").Do(context.Background()); r.Err != nil {
+		t.Fatal(r.Err)
+	}
+	if got.method != http.MethodGet || got.body != "" {
+		t.Errorf("got %s %q", got.method, got.body)
+	}
+}
+
+func TestRequestHeaders(t *testing.T) {
+	srv, got := newEchoServer(t)
+	base, _ := url.Parse(srv.URL)
+
+	client := NewClient(&Config{Headers: map[string]string{"Accept-Language": "de-DE"}})
+	r := client.Get().Base(base).Header("Accept", "application/json").Header("X-Api-Key", "secret").Do(context.Background())
+	if r.Err != nil {
+		t.Fatal(r.Err)
+	}
+
+	// the headers of request override the defaults, the others are kept.
+	tests := map[string]string{
+		"Accept":          "application/json",
+		"X-Api-Key":       "secret",
+		"Accept-Language": "de-DE",
+		"User-Agent":      defaultHeaders.Get("User-Agent"),
+	}
+	for k, want := range tests {
+		if v := got.header.Get(k); v != want {
+			t.Errorf("header %s = %q, want %q", k, v, want)
+		}
+	}
+}
+
+func TestRequestDefaultHeaders(t *testing.T) {
+	srv, got := newEchoServer(t)
+	base, _ := url.Parse(srv.URL)
+
+	if r := DefaultClient().Get().Base(base).Do(context.Background()); r.Err != nil {
+		t.Fatal(r.Err)
+	}
+	for k := range defaultHeaders {
+		if got.header.Get(k) != defaultHeaders.Get(k) {
+			t.Errorf("default header %s = %q, want %q", k, got.header.Get(k), defaultHeaders.Get(k))
+		}
+	}
+}
+
+func TestRequestMaxBodySize(t *testing.T) {
+	srv, _ := newEchoServer(t)
+	base, _ := url.Parse(srv.URL)
+	c := NewClient(&Config{})
+
+	if r := c.Get().Base(base).MaxBodySize(1).Do(context.Background()); r.Err != nil || string(r.Body) != "o" {
+		t.Errorf("got %q, %v, want the body limited", r.Body, r.Err)
+	}
+	if r := c.Get().Base(base).Do(context.Background()); r.Err != nil || string(r.Body) != "ok" {
+		t.Errorf("got %q, %v, want the whole body", r.Body, r.Err)
+	}
+}
+
+func TestRequestNoRedirect(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/link" {
+			http.Redirect(w, r, "/target", http.StatusFound)
+			return
+		}
+		w.Write([]byte("target"))
+	}))
+	defer srv.Close()
+	base, _ := url.Parse(srv.URL)
+
+	// the redirect response is returned with its location, the target is not requested.
+	r := DefaultClient().Get().Method(http.MethodHead).Base(base).Path("/link").NoRedirect().Do(context.Background())
+	if r.Err != nil || r.StatusCode != http.StatusFound || r.Location != "/target" {
+		t.Errorf("got %d, %q, %v, want the redirect", r.StatusCode, r.Location, r.Err)
+	}
+
+	// the redirect is followed by default.
+	r = DefaultClient().Get().Base(base).Path("/link").Do(context.Background())
+	if r.Err != nil || string(r.Body) != "target" || r.Location != "" {
+		t.Errorf("got %q, %q, %v, want the target", r.Body, r.Location, r.Err)
+	}
+}
+
+func TestRequestParam(t *testing.T) {
+	base, _ := url.Parse("https://example.com")
+	r := DefaultClient().Get().Base(base).Param("q", "go").Param("fq", "a", "b")
+	if got, want := r.URL().String(), "https://example.com?fq=a&fq=b&q=go"; got != want {
+		t.Errorf("url = %s, want %s", got, want)
+	}
+
+	// the values set before are replaced.
+	if got, want := r.Param("fq", "c").URL().String(), "https://example.com?fq=c&q=go"; got != want {
+		t.Errorf("url = %s, want %s", got, want)
+	}
+}
@@ -0,0 +1,58 @@
+package network
+
+import "math/rand"
+
+const (
+	// UserAgentClassDesktop is the class of user agents of desktop browsers.
+	UserAgentClassDesktop = "desktop"
+	// UserAgentClassMobile is the class of user agents of mobile browsers.
+	UserAgentClassMobile = "mobile"
+)
+
+type weightedUserAgent struct {
+	userAgent string
+	weight    int // weight is the relative share of the browser, the common browsers are picked more often.
+}
+
+// userAgents are the user agents of each class.
+var userAgents = map[string][]weightedUserAgent{
+	UserAgentClassDesktop: {
+		{"Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/120.0.0.0 Safari/537.36", 50},
+		{"Mozilla/5.0 (Macintosh; Intel Mac OS X 10_15_7) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/120.0.0.0 Safari/537.36", 20},
+		{"Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/120.0.0.0 Safari/537.36 Edg/120.0.0.0", 15},
+		{"Mozilla/5.0 (Windows NT 10.0; Win64; x64; rv:121.0) Gecko/20100101 Firefox/121.0", 10},
+		{"Mozilla/5.0 (Macintosh; Intel Mac OS X 10_15_7) AppleWebKit/605.1.15 (KHTML, like Gecko) Version/17.2 Safari/605.1.15", 5},
+	},
+	UserAgentClassMobile: {
+		{"Mozilla/5.0 (Linux; Android 10; K) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/120.0.0.0 Mobile Safari/537.36", 55},
+		{"Mozilla/5.0 (iPhone; CPU iPhone OS 17_2 like Mac OS X) AppleWebKit/605.1.15 (KHTML, like Gecko) Version/17.2 Mobile/15E148 Safari/604.1", 35},
+		{"Mozilla/5.0 (Android 14; Mobile; rv:121.0) Gecko/121.0 Firefox/121.0", 10},
+	},
+}
+
+// IsUserAgentClass reports whether the class of user agents is known.
+func IsUserAgentClass(class string) bool {
+	_, ok := userAgents[class]
+	return ok
+}
+
+// RandomUserAgent picks a user agent of the class at random by weight, empty is returned if the class is unknown.
+func RandomUserAgent(class string) string {
+	candidates := userAgents[class]
+	total := 0
+	for _, c := range candidates {
+		total += c.weight
+	}
+	if total == 0 {
+		return ""
+	}
+
+	n := rand.Intn(total)
+	for _, c := range candidates {
+		if n < c.weight {
+			return c.userAgent
+		}
+		n -= c.weight
+	}
+	return ""
+}
@@ -0,0 +1,79 @@
+package network
+
+import (
+	"context"
+	"net/url"
+	"slices"
+	"testing"
+)
+
+// classUserAgents gets the user agents of class.
+func classUserAgents(class string) []string {
+	var uas []string
+	for _, c := range userAgents[class] {
+		uas = append(uas, c.userAgent)
+	}
+	return uas
+}
+
+func TestRandomUserAgent(t *testing.T) {
+	for _, class := range []string{UserAgentClassDesktop, UserAgentClassMobile} {
+		t.Run(class, func(t *testing.T) {
+			if !IsUserAgentClass(class) {
+				t.Fatalf("class %s is unknown", class)
+			}
+
+			// the user agents are picked by weight, so the most common browser is picked most often.
+			counts := map[string]int{}
+			for i := 0; i < 2000; i++ {
+				counts[RandomUserAgent(class)]++
+			}
+			uas := classUserAgents(class)
+			for ua := range counts {
+				if !slices.Contains(uas, ua) {
+					t.Fatalf("user agent %q is not of class %s", ua, class)
+				}
+			}
+			for _, ua := range uas[1:] {
+				if counts[ua] >= counts[uas[0]] {
+					t.Errorf("%q is picked %d times, more than the most weighted one %d", ua, counts[ua], counts[uas[0]])
+				}
+			}
+		})
+	}
+
+	if IsUserAgentClass("tablet") || RandomUserAgent("tablet") != "" {
+		t.Error("the unknown class has user agents")
+	}
+}
+
+func TestClientUserAgentClass(t *testing.T) {
+	srv, got := newEchoServer(t)
+	base, _ := url.Parse(srv.URL)
+
+	cases := map[string]struct {
+		config  Config
+		request string
+		want    []string
+	}{
+		"class":              {config: Config{UserAgentClass: UserAgentClassMobile}, want: classUserAgents(UserAgentClassMobile)},
+		"header over class":  {config: Config{UserAgentClass: UserAgentClassMobile, Headers: map[string]string{"user-agent": "custom"}}, want: []string{"custom"}},
+		"request over class": {config: Config{UserAgentClass: UserAgentClassMobile}, request: "request", want: []string{"request"}},
+		"unknown class":      {config: Config{UserAgentClass: "tablet"}, want: []string{defaultHeaders.Get("User-Agent")}},
+		"default user agent": {want: []string{defaultHeaders.Get("User-Agent")}},
+	}
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			req := NewClient(&c.config).Get().Base(base).Path("/")
+			if c.request != "" {
+				req.Header("User-Agent", c.request)
+			}
+			if r := req.Do(context.Background()); r.Err != nil {
+				t.Fatal(r.Err)
+			}
+			if ua := got.header.Get("User-Agent"); !slices.Contains(c.want, ua) {
+				t.Errorf("user agent = %q, want one of %q", ua, c.want)
+			}
+		})
+	}
+}
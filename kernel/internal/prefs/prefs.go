@@ -0,0 +1,139 @@
+package prefs
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Version is the version of token encoded by Encode, the tokens of other versions are rejected by Decode.
+const Version = 1
+
+var (
+	ErrInvalidToken     = errors.New("invalid preferences token")
+	ErrInvalidSignature = errors.New("invalid signature of preferences token")
+)
+
+type Config struct {
+	// SigningKey signs the tokens by hmac-sha256 if it is not empty, the tokens without valid signature are rejected.
+	// The tokens are only encoded and not signed if it is empty.
+	SigningKey string `mapstructure:"signing_key"`
+}
+
+var conf Config
+
+func InitConfig(c Config) {
+	conf = c
+}
+
+// Preferences are the settings of search shared by token, they are the defaults of params of search,
+// so that the params in url still override them.
+type Preferences struct {
+	Engines    []string `json:"e,omitempty"`
+	Categories []string `json:"c,omitempty"`
+	Language   string   `json:"l,omitempty"`
+	SafeSearch *int     `json:"s,omitempty"`
+}
+
+// token is the versioned payload of token, the keys are short to keep the url compact.
+type token struct {
+	Version int `json:"v"`
+	Preferences
+}
+
+// Encode encodes the preferences to a url-safe token, e.g. eyJ2IjoxLCJsIjoiZW4tVVMifQ.
+// The signature is appended after a dot if the signing key is configured.
+func Encode(p Preferences) string {
+	b, _ := json.Marshal(token{Version: Version, Preferences: p})
+	payload := base64.RawURLEncoding.EncodeToString(b)
+	if conf.SigningKey == "" {
+		return payload
+	}
+	return payload + "." + sign(payload)
+}
+
+// Decode decodes the preferences of token encoded by Encode.
+// The token is rejected if its signature is missing or invalid while the signing key is configured,
+// or its version is unknown.
+func Decode(t string) (Preferences, error) {
+	payload, signature, signed := strings.Cut(t, ".")
+	if conf.SigningKey != "" {
+		if !signed || !hmac.Equal([]byte(signature), []byte(sign(payload))) {
+			return Preferences{}, ErrInvalidSignature
+		}
+	}
+
+	b, err := base64.RawURLEncoding.DecodeString(payload)
+	if err != nil {
+		return Preferences{}, ErrInvalidToken
+	}
+	var tok token
+	if err = json.Unmarshal(b, &tok); err != nil {
+		return Preferences{}, ErrInvalidToken
+	}
+	if tok.Version != Version {
+		return Preferences{}, fmt.Errorf("unknown version of preferences token: %d", tok.Version)
+	}
+	return tok.Preferences, nil
+}
+
+func sign(payload string) string {
+	mac := hmac.New(sha256.New, []byte(conf.SigningKey))
+	mac.Write([]byte(payload))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// Param gets the param of search by the preferences, e.g. engines=g,wikipedia.
+// It reports false if the preference of param is not set.
+func (p Preferences) Param(name string) (string, bool) {
+	switch name {
+	case "engines":
+		return strings.Join(p.Engines, ","), len(p.Engines) > 0
+	case "categories":
+		return strings.Join(p.Categories, ","), len(p.Categories) > 0
+	case "language":
+		return p.Language, p.Language != ""
+	case "safe_search":
+		if p.SafeSearch != nil {
+			return strconv.Itoa(*p.SafeSearch), true
+		}
+	}
+	return "", false
+}
+
+// FromParams creates the preferences by the params of search got from getQuery, the missing params are not set.
+func FromParams(getQuery func(string) (string, bool)) (Preferences, error) {
+	var p Preferences
+	split := func(v string) []string {
+		var items []string
+		for _, item := range strings.Split(v, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				items = append(items, item)
+			}
+		}
+		return items
+	}
+
+	if v, ok := getQuery("engines"); ok {
+		p.Engines = split(v)
+	}
+	if v, ok := getQuery("categories"); ok {
+		p.Categories = split(v)
+	}
+	if v, ok := getQuery("language"); ok {
+		p.Language = v
+	}
+	if v, ok := getQuery("safe_search"); ok {
+		num, err := strconv.Atoi(v)
+		if err != nil {
+			return Preferences{}, errors.New("safe search level error")
+		}
+		p.SafeSearch = &num
+	}
+	return p, nil
+}
@@ -0,0 +1,132 @@
+package prefs
+
+import (
+	"encoding/base64"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// withSigningKey sets the signing key during the test.
+func withSigningKey(t *testing.T, key string) {
+	t.Helper()
+	InitConfig(Config{SigningKey: key})
+	t.Cleanup(func() { InitConfig(Config{}) })
+}
+
+func TestEncodeDecode(t *testing.T) {
+	strict := 2
+	p := Preferences{Engines: []string{"g", "wikipedia"}, Categories: []string{"general", "news"}, Language: "en-US", SafeSearch: &strict}
+
+	cases := map[string]string{"unsigned": "", "signed": "secret"}
+	for name, key := range cases {
+		t.Run(name, func(t *testing.T) {
+			withSigningKey(t, key)
+
+			tok := Encode(p)
+			if signed := strings.Contains(tok, "."); signed != (key != "") {
+				t.Errorf("token %q is signed = %v", tok, signed)
+			}
+			got, err := Decode(tok)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, p) {
+				t.Errorf("decoded %+v, want %+v", got, p)
+			}
+		})
+	}
+}
+
+func TestDecodeSignature(t *testing.T) {
+	withSigningKey(t, "secret")
+	tok := Encode(Preferences{Language: "en-US"})
+	payload, signature, _ := strings.Cut(tok, ".")
+
+	// the payload changed by others is rejected.
+	forged := base64.RawURLEncoding.EncodeToString([]byte(`{"v":1,"l":"fr-FR"}`))
+	cases := map[string]string{
+		"missing signature": payload,
+		"tampered payload":  forged + "." + signature,
+		"invalid signature": payload + ".invalid",
+	}
+	for name, tok := range cases {
+		t.Run(name, func(t *testing.T) {
+			if _, err := Decode(tok); !errors.Is(err, ErrInvalidSignature) {
+				t.Errorf("err = %v, want %v", err, ErrInvalidSignature)
+			}
+		})
+	}
+
+	// the token signed by another key is rejected.
+	InitConfig(Config{SigningKey: "other"})
+	if _, err := Decode(tok); !errors.Is(err, ErrInvalidSignature) {
+		t.Errorf("err = %v, want %v", err, ErrInvalidSignature)
+	}
+}
+
+func TestDecodeInvalid(t *testing.T) {
+	encode := func(s string) string { return base64.RawURLEncoding.EncodeToString([]byte(s)) }
+	cases := map[string]struct {
+		token string
+		want  error
+	}{
+		"not base64":      {token: "!!!", want: ErrInvalidToken},
+		"not json":        {token: encode("engines=g"), want: ErrInvalidToken},
+		"unknown version": {token: encode(`{"v":2,"l":"en-US"}`)},
+		"missing version": {token: encode(`{"l":"en-US"}`)},
+	}
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			_, err := Decode(c.token)
+			if err == nil {
+				t.Fatal("the invalid token is decoded")
+			}
+			if c.want != nil && !errors.Is(err, c.want) {
+				t.Errorf("err = %v, want %v", err, c.want)
+			}
+		})
+	}
+}
+
+func TestParam(t *testing.T) {
+	off := 0
+	p := Preferences{Engines: []string{"g", "wikipedia"}, SafeSearch: &off}
+
+	cases := map[string]struct {
+		want string
+		ok   bool
+	}{
+		"engines":     {want: "g,wikipedia", ok: true},
+		"safe_search": {want: "0", ok: true},
+		"categories":  {},
+		"language":    {},
+		"q":           {},
+	}
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			if got, ok := p.Param(name); got != c.want || ok != c.ok {
+				t.Errorf("Param(%s) = %q, %v, want %q, %v", name, got, ok, c.want, c.ok)
+			}
+		})
+	}
+}
+
+func TestFromParams(t *testing.T) {
+	params := map[string]string{"engines": " g, ,wikipedia ", "language": "de-DE", "safe_search": "1", "q": "ignored"}
+	p, err := FromParams(func(name string) (string, bool) {
+		v, ok := params[name]
+		return v, ok
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(p.Engines, []string{"g", "wikipedia"}) || p.Categories != nil || p.Language != "de-DE" || p.SafeSearch == nil || *p.SafeSearch != 1 {
+		t.Errorf("unexpected preferences %+v", p)
+	}
+
+	if _, err = FromParams(func(name string) (string, bool) { return "strict", name == "safe_search" }); err == nil {
+		t.Error("the invalid safe search is accepted")
+	}
+}
@@ -0,0 +1,100 @@
+package ratelimit
+
+import (
+	"math"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+type Config struct {
+	Enable bool    `mapstructure:"enable"`
+	Rate   float64 `mapstructure:"rate"`  // Rate is the count of requests allowed per second of each client ip.
+	Burst  int     `mapstructure:"burst"` // Burst is the maximum of requests allowed at once of each client ip.
+}
+
+// Store takes tokens of buckets by key, it could be shared by multiple instances, e.g. redis.
+type Store interface {
+	// Take takes a token of the bucket of key, it reports whether the token is taken,
+	// and how long to wait for the next token if not.
+	Take(key string, now time.Time) (bool, time.Duration)
+}
+
+// RateLimit limits the requests of each client ip by the token bucket of store,
+// 429 is responded with Retry-After if the limit is exceeded.
+func RateLimit(store Store) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ok, wait := store.Take(c.ClientIP(), time.Now())
+		if !ok {
+			c.Header("Retry-After", strconv.Itoa(int(math.Ceil(wait.Seconds()))))
+			c.AbortWithStatusJSON(http.StatusTooManyRequests, gin.H{"msg": "too many requests"})
+			return
+		}
+		c.Next()
+	}
+}
+
+// sweepInterval is the interval of removing idle buckets of memory store.
+const sweepInterval = time.Minute
+
+type bucket struct {
+	tokens float64
+	last   time.Time
+}
+
+// MemoryStore is a store in memory of a single instance.
+type MemoryStore struct {
+	rate  float64
+	burst float64
+
+	mu        sync.Mutex
+	buckets   map[string]*bucket
+	lastSweep time.Time
+}
+
+func NewMemoryStore(rate float64, burst int) *MemoryStore {
+	return &MemoryStore{rate: rate, burst: float64(max(burst, 1)), buckets: map[string]*bucket{}}
+}
+
+func (s *MemoryStore) Take(key string, now time.Time) (bool, time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.sweep(now)
+
+	b, ok := s.buckets[key]
+	if !ok {
+		b = &bucket{tokens: s.burst, last: now}
+		s.buckets[key] = b
+	}
+
+	// the tokens are refilled by the elapsed time since the last take.
+	b.tokens = math.Min(s.burst, b.tokens+now.Sub(b.last).Seconds()*s.rate)
+	b.last = now
+
+	if b.tokens >= 1 {
+		b.tokens--
+		return true, 0
+	}
+	if s.rate <= 0 {
+		return false, time.Hour
+	}
+	return false, time.Duration((1 - b.tokens) / s.rate * float64(time.Second))
+}
+
+// sweep removes the buckets which are refilled to full, they are the same as new buckets.
+func (s *MemoryStore) sweep(now time.Time) {
+	if now.Sub(s.lastSweep) < sweepInterval {
+		return
+	}
+	s.lastSweep = now
+
+	for key, b := range s.buckets {
+		if b.tokens+now.Sub(b.last).Seconds()*s.rate >= s.burst {
+			delete(s.buckets, key)
+		}
+	}
+}
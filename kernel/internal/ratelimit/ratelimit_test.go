@@ -0,0 +1,111 @@
+package ratelimit
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestMemoryStoreTake(t *testing.T) {
+	s := NewMemoryStore(2, 3)
+	now := time.Now()
+
+	for i := 0; i < 3; i++ {
+		if ok, _ := s.Take("a", now); !ok {
+			t.Fatalf("take %d within burst is not allowed", i)
+		}
+	}
+	ok, wait := s.Take("a", now)
+	if ok || wait != 500*time.Millisecond {
+		t.Errorf("take beyond burst: %v, wait %v, want to wait 500ms for the next token", ok, wait)
+	}
+	// the bucket of another key is separate.
+	if ok, _ := s.Take("b", now); !ok {
+		t.Error("the other key is limited")
+	}
+
+	// the tokens are refilled by rate.
+	if ok, _ := s.Take("a", now.Add(500*time.Millisecond)); !ok {
+		t.Error("the refilled token is not taken")
+	}
+	if ok, _ := s.Take("a", now.Add(500*time.Millisecond)); ok {
+		t.Error("more tokens than refilled are taken")
+	}
+}
+
+func TestMemoryStoreSweep(t *testing.T) {
+	s := NewMemoryStore(1, 1)
+	now := time.Now()
+	s.Take("idle", now)
+	s.Take("busy", now)
+
+	// the buckets refilled to full are removed, the one taken again is created afresh.
+	later := now.Add(sweepInterval + time.Second)
+	s.Take("busy", later)
+	if _, ok := s.buckets["idle"]; ok {
+		t.Error("the idle bucket is not swept")
+	}
+	if b, ok := s.buckets["busy"]; !ok || b.tokens != 0 {
+		t.Errorf("the busy bucket = %+v", b)
+	}
+}
+
+func TestRateLimit(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(RateLimit(NewMemoryStore(0.5, 2)))
+	router.GET("/search", func(c *gin.Context) { c.Status(http.StatusOK) })
+
+	get := func(ip string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodGet, "/search?q=go", nil)
+		r.RemoteAddr = ip + ":1234"
+		router.ServeHTTP(w, r)
+		return w
+	}
+
+	for i := 0; i < 2; i++ {
+		if w := get("192.0.2.1"); w.Code != http.StatusOK {
+			t.Fatalf("request %d: status = %d", i, w.Code)
+		}
+	}
+	w := get("192.0.2.1")
+	if w.Code != http.StatusTooManyRequests {
+		t.Fatalf("status = %d, want 429", w.Code)
+	}
+	if ra := w.Header().Get("Retry-After"); ra != "2" {
+		t.Errorf("Retry-After = %q, want 2", ra)
+	}
+
+	// a different client ip is not affected by the limited one.
+	if w := get("192.0.2.2"); w.Code != http.StatusOK {
+		t.Errorf("the other client ip: status = %d", w.Code)
+	}
+}
+
+func TestRateLimitUntrustedForwardedFor(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	// no proxies are trusted by default of config, the forwarded ip of client is ignored.
+	if err := router.SetTrustedProxies(nil); err != nil {
+		t.Fatal(err)
+	}
+	router.Use(RateLimit(NewMemoryStore(0, 1)))
+	router.GET("/search", func(c *gin.Context) { c.Status(http.StatusOK) })
+
+	codes := make([]int, 0, 2)
+	for _, forwarded := range []string{"198.51.100.1", "198.51.100.2"} {
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodGet, "/search?q=go", nil)
+		r.RemoteAddr = "192.0.2.1:1234"
+		r.Header.Set("X-Forwarded-For", forwarded)
+		router.ServeHTTP(w, r)
+		codes = append(codes, w.Code)
+	}
+	if codes[0] != http.StatusOK || codes[1] != http.StatusTooManyRequests {
+		t.Errorf("statuses = %v, the limit is bypassed by X-Forwarded-For", codes)
+	}
+}
@@ -0,0 +1,33 @@
+package result
+
+import (
+	"encoding/csv"
+	"io"
+	"strconv"
+	"time"
+)
+
+var csvHeader = []string{"engine", "title", "url", "content", "published_date", "score"}
+
+// ToCSV writes the data of result as csv, a header row is written first.
+// Fields containing commas, quotes or newlines are quoted by csv writer.
+func ToCSV(r *Result, w io.Writer) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(csvHeader); err != nil {
+		return err
+	}
+
+	for _, d := range r.GetData() {
+		var publishedDate string
+		if !d.PublishedDate.IsZero() {
+			publishedDate = d.PublishedDate.Format(time.RFC3339)
+		}
+
+		if err := cw.Write([]string{d.Engine, d.Title, d.Url, d.Content, publishedDate, strconv.Itoa(d.score)}); err != nil {
+			return err
+		}
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
@@ -0,0 +1,47 @@
+package result
+
+import (
+	"bytes"
+	"encoding/csv"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestToCSV(t *testing.T) {
+	r := CreateResult("", 1)
+	r.MergedData = []*Data{
+		{Engine: "bing", Title: "Go, the language", Url: "https://go.dev/", Content: "line one\nline \"two\", with comma", score: 7,
+			PublishedDate: time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)},
+		{Engine: "google", Title: "plain", Url: "https://example.com/", Content: "plain"},
+	}
+
+	var buf bytes.Buffer
+	if err := ToCSV(r, &buf); err != nil {
+		t.Fatal(err)
+	}
+
+	// the special characters of content are quoted instead of breaking the row.
+	if !strings.Contains(buf.String(), `"line one`+"\n"+`line ""two"", with comma"`) {
+		t.Errorf("content is not escaped:\n%s", buf.String())
+	}
+
+	rows, err := csv.NewReader(&buf).ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := [][]string{
+		csvHeader,
+		{"bing", "Go, the language", "https://go.dev/", "line one\nline \"two\", with comma", "2024-03-01T12:00:00Z", "7"},
+		{"google", "plain", "https://example.com/", "plain", "", "0"},
+	}
+	if len(rows) != len(want) {
+		t.Fatalf("got %d rows, want %d", len(rows), len(want))
+	}
+	for i := range want {
+		if !slices.Equal(rows[i], want[i]) {
+			t.Errorf("row %d = %q, want %q", i, rows[i], want[i])
+		}
+	}
+}
@@ -1,6 +1,10 @@
 package result
 
-import "regexp"
+import (
+	"encoding/json"
+	"regexp"
+	"time"
+)
 
 // Data of search result
 type Data struct {
@@ -10,6 +14,28 @@ type Data struct {
 	Content   string `json:"content"`   // Content is a short description.
 	ImgSrc    string `json:"img_src"`   // ImgSrc is an image Url, used for poster.
 	Thumbnail string `json:"thumbnail"` // Thumbnail Url for some video result.
+	Category  string `json:"category"`  // Category is the searched category of result, e.g. general, news.
+
+	ImgWidth      int       `json:"img_width,omitempty"`  // ImgWidth is the width of image result.
+	ImgHeight     int       `json:"img_height,omitempty"` // ImgHeight is the height of image result.
+	PublishedDate time.Time `json:"published_date"`       // PublishedDate is the publish time of result, e.g. news, it is omitted in json if unknown.
+	Views         int64     `json:"views,omitempty"`      // Views is the count of views, e.g. video.
+	Author        string    `json:"author,omitempty"`     // Author is the publisher or uploader of result.
+
+	DurationSeconds int    `json:"duration_seconds,omitempty"` // DurationSeconds is the length of media result, e.g. track.
+	PreviewUrl      string `json:"preview_url,omitempty"`      // PreviewUrl is a short sample of media result, e.g. 30s of track.
+
+	// Lang is the language detected from the title and content, e.g. en, empty if it is unknown.
+	Lang string `json:"lang,omitempty"`
+
+	// Tags are the badges annotated by engine, e.g. "video", "verified", "nsfw".
+	Tags []string `json:"tags,omitempty"`
+
+	// Position is the 1-based rank of data in the results of its engine, it is kept by merging and sorting.
+	Position int `json:"position,omitempty"`
+
+	// Engines are all engines found the result, they are set only if the duplicates from engines are merged.
+	Engines []string `json:"engines,omitempty"`
 
 	// Query is the query of search.
 	Query string `json:"-"`
@@ -22,6 +48,27 @@ type Data struct {
 	score int
 }
 
+// dataFields are the fields of Data without its methods, so that they are serialized by default.
+type dataFields Data
+
+// dataJSON is the json of Data, the published date is omitted if it is unknown, since a zero time is never omitted.
+type dataJSON struct {
+	dataFields
+	PublishedDate *time.Time `json:"published_date,omitempty"`
+}
+
+func newDataJSON(d *Data) dataJSON {
+	j := dataJSON{dataFields: dataFields(*d)}
+	if !d.PublishedDate.IsZero() {
+		j.PublishedDate = &d.PublishedDate
+	}
+	return j
+}
+
+func (d Data) MarshalJSON() ([]byte, error) {
+	return json.Marshal(newDataJSON(&d))
+}
+
 // unstructured converts the Data to a map.
 func (d *Data) unstructured() *Data {
 	metadata := make(map[string]string)
@@ -0,0 +1,28 @@
+package result
+
+import (
+	"math"
+	"time"
+)
+
+// ApplyTimeDecay weights the score of data by the age of published date, then the data are re-sorted.
+// The score is halved every half life, the data published in the future are not decayed,
+// and the data without published date are unaffected.
+func (r *Result) ApplyTimeDecay(halfLife time.Duration, now time.Time) {
+	if halfLife <= 0 {
+		return
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, d := range r.MergedData {
+		if d.PublishedDate.IsZero() {
+			continue
+		}
+		age := max(now.Sub(d.PublishedDate), 0)
+		factor := math.Pow(0.5, float64(age)/float64(halfLife))
+		d.score = int(math.Round(float64(d.score) * factor))
+	}
+	r.sortData()
+}
@@ -0,0 +1,38 @@
+package result
+
+import (
+	"testing"
+	"time"
+)
+
+func TestApplyTimeDecay(t *testing.T) {
+	now := time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC)
+	r := CreateResult("", 1)
+	stale := &Data{Url: "https://stale.example.com", score: 100, PublishedDate: now.Add(-48 * time.Hour)}
+	halfLife := &Data{Url: "https://half.example.com", score: 100, PublishedDate: now.Add(-24 * time.Hour)}
+	fresh := &Data{Url: "https://fresh.example.com", score: 60, PublishedDate: now}
+	future := &Data{Url: "https://future.example.com", score: 40, PublishedDate: now.Add(24 * time.Hour)}
+	undated := &Data{Url: "https://undated.example.com", score: 30}
+	r.MergedData = []*Data{stale, halfLife, fresh, future, undated}
+
+	r.ApplyTimeDecay(24*time.Hour, now)
+
+	for d, want := range map[*Data]int{stale: 25, halfLife: 50, fresh: 60, future: 40, undated: 30} {
+		if d.score != want {
+			t.Errorf("score of %s = %d, want %d", d.Url, d.score, want)
+		}
+	}
+	// the data are re-sorted by the decayed scores.
+	assertUrls(t, urls(r), "https://fresh.example.com", "https://half.example.com", "https://future.example.com",
+		"https://undated.example.com", "https://stale.example.com")
+}
+
+func TestApplyTimeDecayDisabled(t *testing.T) {
+	r := sortFixture()
+	r.ApplyTimeDecay(0, time.Now())
+	// the data are neither decayed nor sorted.
+	assertUrls(t, urls(r), "https://a.example.com", "https://b.example.com", "https://c.example.com", "https://d.example.com", "https://e.example.com")
+	if r.MergedData[0].score != 3 {
+		t.Errorf("score = %d, want 3", r.MergedData[0].score)
+	}
+}
@@ -0,0 +1,177 @@
+package result
+
+import (
+	"slices"
+	"strings"
+)
+
+// Answer is a direct answer of query from an engine, e.g. the result of a calculator.
+type Answer struct {
+	Answer string `json:"answer"`
+	Url    string `json:"url"`
+	Engine string `json:"engine"`
+}
+
+// normalizeText normalizes the text for comparing near-identical answers and infoboxes,
+// the case and whitespaces are ignored.
+func normalizeText(s string) string {
+	return strings.ToLower(strings.Join(strings.Fields(s), " "))
+}
+
+// mergeDuplicates appends the data which are not duplicated by canonical url,
+// the duplicated data are merged into the existing ones by mergeData.
+func mergeDuplicates(data []*Data, others []*Data) []*Data {
+	existing := make(map[string]*Data, len(data))
+	for _, d := range data {
+		existing[canonicalKey(d.Url)] = d
+	}
+	for _, o := range others {
+		key := canonicalKey(o.Url)
+		if d, ok := existing[key]; ok {
+			mergeData(d, o)
+			continue
+		}
+		existing[key] = o
+		data = append(data, o)
+	}
+	return data
+}
+
+// mergeData merges the duplicated data o into d, the richest combination is kept:
+// the non-empty media, the longer content, the earliest published date and the union of engines and tags.
+// The higher score of them is kept, so that a result found by more engines is never ranked lower.
+func mergeData(d, o *Data) {
+	d.Engines = union(engineNames(d), engineNames(o))
+	d.Tags = union(d.Tags, o.Tags)
+
+	if d.Title == "" {
+		d.Title = o.Title
+	}
+	if len(o.Content) > len(d.Content) {
+		d.Content = o.Content
+	}
+	if d.Thumbnail == "" {
+		d.Thumbnail = o.Thumbnail
+	}
+	if d.ImgSrc == "" {
+		d.ImgSrc, d.ImgWidth, d.ImgHeight = o.ImgSrc, o.ImgWidth, o.ImgHeight
+	}
+	if !o.PublishedDate.IsZero() && (d.PublishedDate.IsZero() || o.PublishedDate.Before(d.PublishedDate)) {
+		d.PublishedDate = o.PublishedDate
+	}
+	if d.Author == "" {
+		d.Author = o.Author
+	}
+	d.Views = max(d.Views, o.Views)
+	if d.DurationSeconds == 0 {
+		d.DurationSeconds = o.DurationSeconds
+	}
+	if d.PreviewUrl == "" {
+		d.PreviewUrl = o.PreviewUrl
+	}
+	d.score = max(d.score, o.score)
+}
+
+// engineNames gets the engines found the data.
+func engineNames(d *Data) []string {
+	if len(d.Engines) > 0 {
+		return d.Engines
+	}
+	return []string{d.Engine}
+}
+
+// union returns a new slice of the items of a then the ones of b not in a,
+// so that the slices shared by the copies of data are not modified.
+func union(a, b []string) []string {
+	if len(b) == 0 {
+		return a
+	}
+	s := slices.Clone(a)
+	for _, item := range b {
+		if !slices.Contains(s, item) {
+			s = append(s, item)
+		}
+	}
+	return s
+}
+
+// mergeAnswers appends the answers which are not duplicated,
+// the duplicated answer from the engine of higher priority replaces the existing one.
+func mergeAnswers(answers []Answer, others []Answer) []Answer {
+	for _, a := range others {
+		i := indexAnswer(answers, a)
+		if i == -1 {
+			answers = append(answers, a)
+			continue
+		}
+		if enginePriority(a.Engine) < enginePriority(answers[i].Engine) {
+			answers[i] = a
+		}
+	}
+	return answers
+}
+
+func indexAnswer(answers []Answer, a Answer) int {
+	key := normalizeText(a.Answer)
+	for i := range answers {
+		if normalizeText(answers[i].Answer) == key {
+			return i
+		}
+	}
+	return -1
+}
+
+// mergeInfoBox gets the infobox of the two ones.
+// The infoboxes with the same title are merged, the richer one is kept and the links of the other are added to it.
+// Otherwise, the infobox from the engine of higher priority is kept, the existing one is kept on a tie.
+func mergeInfoBox(box, other *InfoBox) *InfoBox {
+	if box == nil || other == nil {
+		if box == nil {
+			return other
+		}
+		return box
+	}
+
+	if normalizeText(box.Title) != normalizeText(other.Title) {
+		if enginePriority(other.Engine) < enginePriority(box.Engine) {
+			return other
+		}
+		return box
+	}
+
+	kept, dropped := box, other
+	if p, q := enginePriority(other.Engine), enginePriority(box.Engine); p < q || (p == q && other.richness() > box.richness()) {
+		kept, dropped = other, box
+	}
+
+	// the infoboxes may be shared by cached results, so a new one is returned.
+	merged := *kept
+	merged.UrlList = append([]map[string]string{}, kept.UrlList...)
+	if merged.ImgSrc == "" {
+		merged.ImgSrc = dropped.ImgSrc
+	}
+	links := map[string]bool{merged.Url: true}
+	for _, u := range merged.UrlList {
+		links[u["url"]] = true
+	}
+	if dropped.Url != "" && !links[dropped.Url] {
+		links[dropped.Url] = true
+		merged.UrlList = append(merged.UrlList, map[string]string{"title": dropped.Engine, "url": dropped.Url})
+	}
+	for _, u := range dropped.UrlList {
+		if !links[u["url"]] {
+			links[u["url"]] = true
+			merged.UrlList = append(merged.UrlList, u)
+		}
+	}
+	return &merged
+}
+
+// richness is the amount of information of infobox, used to pick the richer one of near-identical infoboxes.
+func (b *InfoBox) richness() int {
+	n := len(b.Content) + 100*len(b.UrlList)
+	if b.ImgSrc != "" {
+		n += 100
+	}
+	return n
+}
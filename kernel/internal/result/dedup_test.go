@@ -0,0 +1,155 @@
+package result
+
+import (
+	"fmt"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestMergeInfoBox(t *testing.T) {
+	old := conf.EnginePriority
+	conf.EnginePriority = []string{"wikipedia", "wikidata"}
+	t.Cleanup(func() { conf.EnginePriority = old })
+
+	wikipedia := &InfoBox{Title: "Go (programming language)", Content: "Go is a statically typed language.", Url: "https://en.wikipedia.org/wiki/Go", Engine: "wikipedia"}
+	wikidata := &InfoBox{
+		Title:   "go  (Programming Language)",
+		Content: "programming language designed at Google",
+		ImgSrc:  "https://upload.wikimedia.org/go.svg",
+		Url:     "https://www.wikidata.org/wiki/Q37227",
+		UrlList: []map[string]string{{"title": "Official website", "url": "https://go.dev/"}, {"title": "wikipedia", "url": "https://en.wikipedia.org/wiki/Go"}},
+		Engine:  "wikidata",
+	}
+	other := &InfoBox{Title: "Go (game)", Content: "Go is a board game.", Url: "https://example.com/go-game", Engine: "ddg"}
+
+	// the infobox of higher priority is kept, the image and links of the other are added to it.
+	merged := mergeInfoBox(wikidata, wikipedia)
+	if merged.Engine != "wikipedia" || merged.Content != wikipedia.Content || merged.ImgSrc != wikidata.ImgSrc {
+		t.Errorf("merged = %+v", merged)
+	}
+	want := "[map[title:wikidata url:https://www.wikidata.org/wiki/Q37227] map[title:Official website url:https://go.dev/]]"
+	if got := fmt.Sprint(merged.UrlList); got != want {
+		t.Errorf("links = %s, want %s", got, want)
+	}
+	// the merged infobox is a new one, the merged infoboxes are not changed.
+	if wikipedia.ImgSrc != "" || len(wikipedia.UrlList) != 0 {
+		t.Errorf("the merged infobox is changed: %+v", wikipedia)
+	}
+
+	// the infoboxes of different titles are not merged, the one of higher priority is kept.
+	if got := mergeInfoBox(other, wikidata); got != wikidata {
+		t.Errorf("got %+v, want the one of wikidata", got)
+	}
+	if got := mergeInfoBox(wikipedia, other); got != wikipedia {
+		t.Errorf("got %+v, want the existing one of higher priority", got)
+	}
+
+	if mergeInfoBox(nil, other) != other || mergeInfoBox(other, nil) != other || mergeInfoBox(nil, nil) != nil {
+		t.Error("the nil infobox is not ignored")
+	}
+}
+
+func TestMergeInfoBoxRicher(t *testing.T) {
+	// the engines of the same priority are merged into the richer one.
+	poor := &InfoBox{Title: "Go", Content: "a language", Engine: "a"}
+	rich := &InfoBox{Title: "Go", Content: "a language", ImgSrc: "https://example.com/go.png", Url: "https://go.dev/", Engine: "b"}
+
+	merged := mergeInfoBox(poor, rich)
+	if merged.Engine != "b" || merged.Url != "https://go.dev/" || len(merged.UrlList) != 0 {
+		t.Errorf("merged = %+v", merged)
+	}
+	// the existing one is kept on a tie.
+	if merged := mergeInfoBox(poor, &InfoBox{Title: "go", Content: "a language", Engine: "c"}); merged.Engine != "a" {
+		t.Errorf("merged = %+v, want the existing one", merged)
+	}
+}
+
+func TestMergeResultInfoBox(t *testing.T) {
+	r := CreateResult("", 1)
+	a := CreateResult("a", 1)
+	a.InfoBox = &InfoBox{Title: "Go", Url: "https://go.dev/"}
+	b := CreateResult("b", 1)
+	b.InfoBox = &InfoBox{Title: "Go", Url: "https://en.wikipedia.org/wiki/Go", ImgSrc: "https://example.com/go.png"}
+
+	r.Merge(a)
+	r.Merge(b)
+	// the infobox without engine is attributed to the engine of result.
+	if r.InfoBox.Engine != "b" || r.InfoBox.Title != "Go" || len(r.InfoBox.UrlList) != 1 || r.InfoBox.UrlList[0]["title"] != "a" {
+		t.Errorf("infobox = %+v", r.InfoBox)
+	}
+	if a.InfoBox.Engine != "" {
+		t.Error("the infobox of merged result is changed")
+	}
+}
+
+func TestMergeDuplicates(t *testing.T) {
+	early := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	newResults := func() (*Result, *Result) {
+		a := CreateResult("a", 1)
+		a.AppendData(&Data{Engine: "a", Title: "Go docs", Url: "https://go.dev/doc", Content: "docs", Tags: []string{"official"}, Views: 10, PublishedDate: early.AddDate(0, 1, 0)})
+		b := CreateResult("b", 1)
+		b.AppendData(&Data{Engine: "b", Title: "Documentation", Url: "https://Go.dev/doc/?utm_source=b", Content: "the documentation of go",
+			Thumbnail: "https://go.dev/logo.png", Tags: []string{"official", "video"}, Views: 5, PublishedDate: early, Author: "gopher"})
+		b.AppendData(&Data{Engine: "b", Title: "Tour", Url: "https://go.dev/tour"})
+		return a, b
+	}
+
+	cases := map[string]struct {
+		merge bool
+		want  int
+	}{
+		"merged":   {merge: true, want: 2},
+		"appended": {want: 3},
+	}
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			InitConfig(Config{MergeDuplicates: c.merge})
+			t.Cleanup(func() { InitConfig(Config{}) })
+
+			a, b := newResults()
+			r := CreateResult("", 1)
+			r.Merge(a)
+			r.Merge(b)
+			if n := len(r.MergedData); n != c.want {
+				t.Fatalf("got %d data, want %d", n, c.want)
+			}
+			if !c.merge {
+				if r.MergedData[0].Engines != nil {
+					t.Errorf("engines are set without merging: %v", r.MergedData[0].Engines)
+				}
+				return
+			}
+
+			// the richest combination of fields is kept.
+			d := r.MergedData[0]
+			if d.Title != "Go docs" || d.Url != "https://go.dev/doc" || d.Content != "the documentation of go" || d.Thumbnail != "https://go.dev/logo.png" || d.Author != "gopher" {
+				t.Errorf("unexpected merged data %+v", d)
+			}
+			if !d.PublishedDate.Equal(early) || d.Views != 10 {
+				t.Errorf("published date = %v, views = %d", d.PublishedDate, d.Views)
+			}
+			if !slices.Equal(d.Engines, []string{"a", "b"}) || !slices.Equal(d.Tags, []string{"official", "video"}) {
+				t.Errorf("engines = %v, tags = %v", d.Engines, d.Tags)
+			}
+		})
+	}
+}
+
+func TestMergeData(t *testing.T) {
+	tags := make([]string, 1, 4)
+	tags[0] = "official"
+	d := &Data{Engine: "a", Tags: tags, score: 10}
+	o := &Data{Engine: "b", Tags: []string{"video"}, score: 30}
+	mergeData(d, o)
+	mergeData(d, &Data{Engine: "c", score: 20})
+
+	// the higher score is kept, and the engines of merged data are accumulated.
+	if d.score != 30 || !slices.Equal(d.Engines, []string{"a", "b", "c"}) {
+		t.Errorf("score = %d, engines = %v", d.score, d.Engines)
+	}
+	// the tags shared by others are not modified.
+	if got := tags[:2]; got[1] != "" || !slices.Equal(d.Tags, []string{"official", "video"}) {
+		t.Errorf("shared tags = %v, tags = %v", got, d.Tags)
+	}
+}
@@ -0,0 +1,51 @@
+package result
+
+import (
+	"math"
+	"net/url"
+	"strings"
+)
+
+// DomainScore adjusts the score of data by the host of url.
+type DomainScore struct {
+	// Domain is the host, e.g. wikipedia.org, "www." is ignored.
+	// The wildcard matches the domain and its subdomains, e.g. *.wikipedia.org matches en.wikipedia.org.
+	Domain string `mapstructure:"domain"`
+	// Score is added to the score of data, negative score penalizes the domain.
+	// The score of data is an integer, so the sum is rounded.
+	Score float64 `mapstructure:"score"`
+}
+
+// match reports whether the host matches the domain.
+func (ds DomainScore) match(host string) bool {
+	domain := strings.ToLower(ds.Domain)
+	if suffix, ok := strings.CutPrefix(domain, "*."); ok {
+		return host == suffix || strings.HasSuffix(host, "."+suffix)
+	}
+	return host == strings.TrimPrefix(domain, "www.")
+}
+
+// ApplyDomainScores adjusts the score of data by the first matched domain, then the data are re-sorted.
+func (r *Result) ApplyDomainScores(scores []DomainScore) {
+	if len(scores) == 0 {
+		return
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, d := range r.MergedData {
+		u, err := url.Parse(d.Url)
+		if err != nil {
+			continue
+		}
+		host := strings.TrimPrefix(strings.ToLower(u.Hostname()), "www.")
+		for _, ds := range scores {
+			if ds.match(host) {
+				d.score = int(math.Round(float64(d.score) + ds.Score))
+				break
+			}
+		}
+	}
+	r.sortData()
+}
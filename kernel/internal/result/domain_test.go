@@ -0,0 +1,37 @@
+package result
+
+import "testing"
+
+func TestApplyDomainScores(t *testing.T) {
+	r := CreateResult("", 1)
+	r.MergedData = []*Data{
+		{Url: "https://www.pinterest.com/pin/1", score: 5},
+		{Url: "https://blog.example.com/go", score: 4},
+		{Url: "https://en.wikipedia.org/wiki/Go", score: 3},
+		{Url: "https://example.com/go", score: 2},
+	}
+	r.ApplyDomainScores([]DomainScore{
+		{Domain: "*.wikipedia.org", Score: 2.5},
+		{Domain: "www.pinterest.com", Score: -4},
+		{Domain: "example.com", Score: 0.4},
+	})
+
+	// the boosted domain rises, the penalized one falls, and the non-wildcard domain does not match subdomains.
+	assertUrls(t, urls(r), "https://en.wikipedia.org/wiki/Go", "https://blog.example.com/go", "https://example.com/go", "https://www.pinterest.com/pin/1")
+	scores := map[string]int{}
+	for _, d := range r.GetData() {
+		scores[d.Url] = d.score
+	}
+	if scores["https://en.wikipedia.org/wiki/Go"] != 6 || scores["https://www.pinterest.com/pin/1"] != 1 || scores["https://example.com/go"] != 2 {
+		t.Errorf("scores = %v", scores)
+	}
+}
+
+func TestApplyDomainScoresFirstMatch(t *testing.T) {
+	r := CreateResult("", 1)
+	r.MergedData = []*Data{{Url: "https://en.wikipedia.org/wiki/Go", score: 1}}
+	r.ApplyDomainScores([]DomainScore{{Domain: "en.wikipedia.org", Score: 1}, {Domain: "*.wikipedia.org", Score: 10}})
+	if s := r.GetData()[0].score; s != 2 {
+		t.Errorf("score = %d, want 2 by the first matched domain", s)
+	}
+}
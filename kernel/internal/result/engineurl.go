@@ -0,0 +1,54 @@
+package result
+
+import (
+	"net/url"
+	"path"
+)
+
+// EngineSearchURLs builds the links re-running the query of result on each engine of result alone,
+// e.g. {"google": "/api/search?category=general&engines=google&q=hello"} with the base url /api.
+// The engines of data, answers, infobox and timed out engines are linked, the query is the corrected one if the search is rerun.
+// Nil is returned if the base url is invalid or the result has no query.
+func (r *Result) EngineSearchURLs(baseURL string) map[string]string {
+	query := r.Query
+	if r.CorrectedQuery != "" {
+		query = r.CorrectedQuery
+	}
+	base, err := url.Parse(baseURL)
+	if err != nil || query == "" {
+		return nil
+	}
+	base.Path = path.Join("/", base.Path, "search")
+
+	// the category of engine is the one its first data is searched in.
+	categories := map[string]string{}
+	add := func(name, category string) {
+		if name != "" && categories[name] == "" {
+			categories[name] = category
+		}
+	}
+	for _, d := range r.GetData() {
+		add(d.Engine, d.Category)
+	}
+	for _, a := range r.Answers {
+		add(a.Engine, "")
+	}
+	if r.InfoBox != nil {
+		add(r.InfoBox.Engine, "")
+	}
+	for _, name := range r.TimedOutEngines {
+		add(name, "")
+	}
+
+	urls := make(map[string]string, len(categories))
+	for name, category := range categories {
+		params := url.Values{"q": {query}, "engines": {name}}
+		if category != "" {
+			params.Set("category", category)
+		}
+		u := *base
+		u.RawQuery = params.Encode()
+		urls[name] = u.String()
+	}
+	return urls
+}
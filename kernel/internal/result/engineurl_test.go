@@ -0,0 +1,54 @@
+package result
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestEngineSearchURLs(t *testing.T) {
+	r := CreateResult("", 1)
+	r.Query = "hello world"
+	r.AppendData(&Data{Engine: "google", Category: "general", Url: "https://a.example.com"})
+	r.AppendData(&Data{Engine: "google", Category: "news", Url: "https://b.example.com"})
+	r.AppendData(&Data{Engine: "bing_news", Category: "news", Url: "https://c.example.com"})
+	r.Answers = []Answer{{Answer: "42", Engine: "duckduckgo_answer"}, {Answer: "unknown engine"}}
+	r.InfoBox = &InfoBox{Title: "Hello", Engine: "wikipedia"}
+	r.TimedOutEngines = []string{"slow", "google"}
+
+	got := r.EngineSearchURLs("/api")
+	want := map[string]string{
+		// the category of engine is the one of its first data.
+		"google":            "/api/search?category=general&engines=google&q=hello+world",
+		"bing_news":         "/api/search?category=news&engines=bing_news&q=hello+world",
+		"duckduckgo_answer": "/api/search?engines=duckduckgo_answer&q=hello+world",
+		"wikipedia":         "/api/search?engines=wikipedia&q=hello+world",
+		"slow":              "/api/search?engines=slow&q=hello+world",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestEngineSearchURLsQuery(t *testing.T) {
+	cases := map[string]struct {
+		query, corrected, base string
+		want                   map[string]string
+	}{
+		"corrected query": {query: "helo", corrected: "hello", base: "/api", want: map[string]string{"google": "/api/search?engines=google&q=hello"}},
+		"absolute base":   {query: "hello", base: "https://searx.example.com/api/", want: map[string]string{"google": "https://searx.example.com/api/search?engines=google&q=hello"}},
+		"root base":       {query: "hello", base: "", want: map[string]string{"google": "/search?engines=google&q=hello"}},
+		"no query":        {base: "/api"},
+		"invalid base":    {query: "hello", base: "://invalid"},
+	}
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			r := CreateResult("", 1)
+			r.Query, r.CorrectedQuery = c.query, c.corrected
+			r.AppendData(&Data{Engine: "google", Url: "https://a.example.com"})
+
+			if got := r.EngineSearchURLs(c.base); !reflect.DeepEqual(got, c.want) {
+				t.Errorf("got %v, want %v", got, c.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,56 @@
+package result
+
+import (
+	"slices"
+	"sort"
+)
+
+// Interleave round-robins the data across categories in the order of categories,
+// perRound data of each category are taken in a round, so that no category is buried by others.
+// Categories exhausted are skipped, the others continue in next rounds.
+// Categories not in order are interleaved after them in alphabetical order.
+func Interleave(byCategory map[string][]*Data, order []string, perRound int) []*Data {
+	if perRound <= 0 {
+		perRound = 1
+	}
+
+	var rest []string
+	for category := range byCategory {
+		if !slices.Contains(order, category) {
+			rest = append(rest, category)
+		}
+	}
+	sort.Strings(rest)
+	order = append(slices.Clone(order), rest...)
+
+	var total int
+	for _, data := range byCategory {
+		total += len(data)
+	}
+
+	interleaved := make([]*Data, 0, total)
+	offsets := make(map[string]int, len(order))
+	for len(interleaved) < total {
+		for _, category := range order {
+			data := byCategory[category]
+			start := offsets[category]
+			end := min(start+perRound, len(data))
+			interleaved = append(interleaved, data[start:end]...)
+			offsets[category] = end
+		}
+	}
+	return interleaved
+}
+
+// InterleaveByCategory interleaves the data of result by category with the configured count per round,
+// the data of each category keep their order.
+func (r *Result) InterleaveByCategory(order []string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	byCategory := map[string][]*Data{}
+	for _, d := range r.MergedData {
+		byCategory[d.Category] = append(byCategory[d.Category], d)
+	}
+	r.MergedData = Interleave(byCategory, order, conf.InterleavePerRound)
+}
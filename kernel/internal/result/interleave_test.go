@@ -0,0 +1,74 @@
+package result
+
+import (
+	"fmt"
+	"slices"
+	"testing"
+)
+
+func categoryData(category string, n int) []*Data {
+	var data []*Data
+	for i := 0; i < n; i++ {
+		data = append(data, &Data{Category: category, Url: fmt.Sprintf("%s%d", category, i)})
+	}
+	return data
+}
+
+func interleavedUrls(data []*Data) []string {
+	var urls []string
+	for _, d := range data {
+		urls = append(urls, d.Url)
+	}
+	return urls
+}
+
+func TestInterleaveBalanced(t *testing.T) {
+	byCategory := map[string][]*Data{
+		"general": categoryData("general", 4),
+		"news":    categoryData("news", 4),
+		"images":  categoryData("images", 4),
+	}
+	got := interleavedUrls(Interleave(byCategory, []string{"general", "news", "images"}, 2))
+	want := []string{
+		"general0", "general1", "news0", "news1", "images0", "images1",
+		"general2", "general3", "news2", "news3", "images2", "images3",
+	}
+	if !slices.Equal(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestInterleaveUneven(t *testing.T) {
+	byCategory := map[string][]*Data{
+		"general": categoryData("general", 5),
+		"news":    categoryData("news", 1),
+		"videos":  categoryData("videos", 2),
+	}
+	// the exhausted categories are skipped, the categories not in order follow alphabetically.
+	got := interleavedUrls(Interleave(byCategory, []string{"news", "general"}, 1))
+	want := []string{"news0", "general0", "videos0", "general1", "videos1", "general2", "general3", "general4"}
+	if !slices.Equal(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+
+	// the count per round defaults to 1.
+	if got := interleavedUrls(Interleave(byCategory, []string{"news", "general"}, 0)); !slices.Equal(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestInterleaveByCategory(t *testing.T) {
+	conf.InterleavePerRound = 1
+	t.Cleanup(func() { conf.InterleavePerRound = 0 })
+
+	r := CreateResult("", 1)
+	for _, d := range append(categoryData("general", 3), categoryData("images", 2)...) {
+		r.AppendData(d)
+	}
+	r.InterleaveByCategory([]string{"general", "images"})
+
+	want := []string{"general0", "images0", "general1", "images1", "general2"}
+	if got := interleavedUrls(r.GetData()); !slices.Equal(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,163 @@
+package result
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/zvirgilx/searxng-go/kernel/internal/util"
+)
+
+const (
+	// SchemaVersion1 is the shape of search api before the schema is versioned, it is kept byte for byte for the existing clients.
+	SchemaVersion1 = 1
+	// SchemaVersion2 adds the version, stale and redirect url of response, and the score, position, language and engines of data.
+	SchemaVersion2 = 2
+
+	// DefaultSchemaVersion is the version served if the client does not request one,
+	// the newer versions are served only if the clients opt in.
+	DefaultSchemaVersion = SchemaVersion1
+	// LatestSchemaVersion is the newest version a client can request.
+	LatestSchemaVersion = SchemaVersion2
+)
+
+// ResponseV1 is the json of search api of version 1, it has no version field.
+// The fields are ordered by name, as the map the api used to serialize.
+type ResponseV1 struct {
+	Answers         []Answer  `json:"answers"`
+	Cached          bool      `json:"cached"`
+	CachedAt        time.Time `json:"cached_at"`
+	CorrectedQuery  string    `json:"corrected_query"`
+	Corrections     []string  `json:"corrections"`
+	InfoBox         *InfoBox  `json:"info_box"`
+	NextPageNo      int       `json:"next_page_no"`
+	Query           string    `json:"query"`
+	Results         []*dataV1 `json:"results"`
+	Suggestions     []string  `json:"suggestions"`
+	TimedOutEngines []string  `json:"timed_out_engines"`
+	Warnings        []string  `json:"warnings"`
+}
+
+// Response is the json of search api since version 2.
+type Response struct {
+	Version    int    `json:"version"`
+	Query      string `json:"query"`
+	NextPageNo int    `json:"next_page_no"`
+
+	Results     []dataV2 `json:"results"`
+	Suggestions []string `json:"suggestions"`
+	InfoBox     *InfoBox `json:"info_box"`
+
+	Answers         []Answer   `json:"answers"`
+	TimedOutEngines []string   `json:"timed_out_engines"`
+	Corrections     []string   `json:"corrections"`
+	CorrectedQuery  string     `json:"corrected_query"`
+	Warnings        []string   `json:"warnings"`
+	Cached          bool       `json:"cached"`
+	CachedAt        *time.Time `json:"cached_at,omitempty"`
+	Stale           bool       `json:"stale"`
+	RedirectURL     string     `json:"redirect_url"`
+
+	// EngineUrls are the links re-running the search on each engine of result, set by the caller.
+	EngineUrls map[string]string `json:"engine_urls,omitempty"`
+}
+
+// dataV1 is the data of version 1, the fields of data added since are not serialized.
+type dataV1 struct {
+	Engine    string `json:"engine"`
+	Title     string `json:"title"`
+	Url       string `json:"url"`
+	Content   string `json:"content"`
+	ImgSrc    string `json:"img_src"`
+	Thumbnail string `json:"thumbnail"`
+	Category  string `json:"category"`
+
+	ImgWidth      int       `json:"img_width,omitempty"`
+	ImgHeight     int       `json:"img_height,omitempty"`
+	PublishedDate time.Time `json:"published_date"`
+	Views         int64     `json:"views,omitempty"`
+	Author        string    `json:"author,omitempty"`
+
+	DurationSeconds int    `json:"duration_seconds,omitempty"`
+	PreviewUrl      string `json:"preview_url,omitempty"`
+
+	Tags []string `json:"tags,omitempty"`
+}
+
+// dataV2 is the data of version 2, the score is exposed.
+type dataV2 struct {
+	dataJSON
+	Score int `json:"score"`
+}
+
+// ToJSON converts the result to the response of the schema version, *ResponseV1 or *Response,
+// an error is returned for an unknown version. The data are serialized in their current order.
+func ToJSON(r *Result, query string, nextPageNo int, version int) (any, error) {
+	data := r.GetData()
+	switch version {
+	case SchemaVersion1:
+		resp := &ResponseV1{
+			Answers:         r.Answers,
+			Cached:          r.Cached,
+			CachedAt:        r.CachedAt,
+			CorrectedQuery:  r.CorrectedQuery,
+			Corrections:     r.Corrections,
+			InfoBox:         r.InfoBox,
+			NextPageNo:      nextPageNo,
+			Query:           query,
+			Suggestions:     util.SetToArray[string](r.Suggestions),
+			TimedOutEngines: r.TimedOutEngines,
+			Warnings:        r.Warnings,
+		}
+		// the results without data are null as before.
+		if data != nil {
+			resp.Results = make([]*dataV1, 0, len(data))
+		}
+		for _, d := range data {
+			resp.Results = append(resp.Results, &dataV1{
+				Engine:          d.Engine,
+				Title:           d.Title,
+				Url:             d.Url,
+				Content:         d.Content,
+				ImgSrc:          d.ImgSrc,
+				Thumbnail:       d.Thumbnail,
+				Category:        d.Category,
+				ImgWidth:        d.ImgWidth,
+				ImgHeight:       d.ImgHeight,
+				PublishedDate:   d.PublishedDate,
+				Views:           d.Views,
+				Author:          d.Author,
+				DurationSeconds: d.DurationSeconds,
+				PreviewUrl:      d.PreviewUrl,
+				Tags:            d.Tags,
+			})
+		}
+		return resp, nil
+	case SchemaVersion2:
+		resp := &Response{
+			Version:         version,
+			Query:           query,
+			NextPageNo:      nextPageNo,
+			Results:         make([]dataV2, 0, len(data)),
+			Suggestions:     util.SetToArray[string](r.Suggestions),
+			InfoBox:         r.InfoBox,
+			Answers:         r.Answers,
+			TimedOutEngines: r.TimedOutEngines,
+			Corrections:     r.Corrections,
+			CorrectedQuery:  r.CorrectedQuery,
+			Warnings:        r.Warnings,
+			Cached:          r.Cached,
+			Stale:           r.Stale,
+			RedirectURL:     r.RedirectURL,
+		}
+		for _, d := range data {
+			resp.Results = append(resp.Results, dataV2{dataJSON: newDataJSON(d), Score: d.score})
+		}
+		// the time of cache is omitted for the fetched results, rather than a zero time.
+		if !r.CachedAt.IsZero() {
+			resp.CachedAt = &r.CachedAt
+		}
+		return resp, nil
+	default:
+		return nil, fmt.Errorf("unknown schema version: %d", version)
+	}
+}
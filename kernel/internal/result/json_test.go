@@ -0,0 +1,143 @@
+package result
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+// jsonFixture has the data and the fields of version 2.
+func jsonFixture() *Result {
+	r := CreateResult("", 1)
+	r.MergedData = []*Data{{Engine: "bing", Title: "Go", Url: "https://go.dev/", Content: "Go", Author: "gopher", score: 7,
+		PublishedDate: time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)}}
+	r.Answers = []Answer{{Answer: "42"}}
+	r.Corrections = []string{"golang"}
+	r.Cached = true
+	r.RedirectURL = "https://go.dev/"
+	return r
+}
+
+func marshalJSON(t *testing.T, r *Result, version int) map[string]any {
+	t.Helper()
+	resp, err := ToJSON(r, "go", 2, version)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := json.Marshal(resp)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var m map[string]any
+	if err := json.Unmarshal(b, &m); err != nil {
+		t.Fatal(err)
+	}
+	return m
+}
+
+func TestToJSONVersion1(t *testing.T) {
+	r := jsonFixture()
+	// the fields of data added since version 1 are not serialized, the unknown date is serialized as before.
+	r.MergedData = append(r.MergedData, &Data{Engine: "bing", Title: "Go 2", Url: "https://go.dev/2", Lang: "en", Position: 2, Engines: []string{"bing"}})
+
+	resp, err := ToJSON(r, "go", 2, SchemaVersion1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := json.Marshal(resp)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// the json is kept byte for byte as the api served before the schema is versioned.
+	want := `{"answers":[{"answer":"42","url":"","engine":""}],"cached":true,"cached_at":"0001-01-01T00:00:00Z","corrected_query":"",` +
+		`"corrections":["golang"],"info_box":null,"next_page_no":2,"query":"go","results":[` +
+		`{"engine":"bing","title":"Go","url":"https://go.dev/","content":"Go","img_src":"","thumbnail":"","category":"","published_date":"2024-03-01T00:00:00Z","author":"gopher"},` +
+		`{"engine":"bing","title":"Go 2","url":"https://go.dev/2","content":"","img_src":"","thumbnail":"","category":"","published_date":"0001-01-01T00:00:00Z"}],` +
+		`"suggestions":[],"timed_out_engines":null,"warnings":null}`
+	if string(b) != want {
+		t.Errorf("json = %s\nwant %s", b, want)
+	}
+
+	// the results without data are null as before.
+	if m := marshalJSON(t, CreateResult("", 1), SchemaVersion1); m["results"] != nil {
+		t.Errorf("results = %v, want null", m["results"])
+	}
+}
+
+func TestToJSONVersion2(t *testing.T) {
+	m := marshalJSON(t, jsonFixture(), SchemaVersion2)
+
+	if m["version"] != float64(SchemaVersion2) || m["cached"] != true || m["redirect_url"] != "https://go.dev/" {
+		t.Errorf("unexpected response %v", m)
+	}
+	// the empty fields of version 2 are serialized as well, so that clients get a stable shape.
+	for _, field := range []string{"answers", "timed_out_engines", "corrections", "corrected_query", "warnings", "stale"} {
+		if _, ok := m[field]; !ok {
+			t.Errorf("field %s of version 2 is missing", field)
+		}
+	}
+	d := m["results"].([]any)[0].(map[string]any)
+	if d["score"] != float64(7) || d["author"] != "gopher" || d["published_date"] != "2024-03-01T00:00:00Z" {
+		t.Errorf("unexpected data %v", d)
+	}
+}
+
+func TestToJSONUnknownTimes(t *testing.T) {
+	r := jsonFixture()
+	r.MergedData[0].PublishedDate = time.Time{}
+	r.Cached = false
+
+	// the unknown times are omitted rather than serialized as zero times.
+	m := marshalJSON(t, r, SchemaVersion2)
+	if _, ok := m["cached_at"]; ok {
+		t.Errorf("cached at of fetched results is serialized: %v", m["cached_at"])
+	}
+	d := m["results"].([]any)[0].(map[string]any)
+	if _, ok := d["published_date"]; ok || d["score"] != float64(7) {
+		t.Errorf("unexpected data %v", d)
+	}
+
+	r.Cached, r.CachedAt = true, time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	if m = marshalJSON(t, r, SchemaVersion2); m["cached_at"] != "2024-03-01T00:00:00Z" {
+		t.Errorf("cached at = %v", m["cached_at"])
+	}
+}
+
+func TestDataJSON(t *testing.T) {
+	date := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	cases := map[string]struct {
+		data Data
+		want string
+	}{
+		"unknown date": {data: Data{Engine: "bing", Url: "https://go.dev/"},
+			want: `{"engine":"bing","title":"","url":"https://go.dev/","content":"","img_src":"","thumbnail":"","category":""}`},
+		"published date": {data: Data{Engine: "bing", Url: "https://go.dev/", PublishedDate: date, Lang: "en"},
+			want: `{"engine":"bing","title":"","url":"https://go.dev/","content":"","img_src":"","thumbnail":"","category":"","lang":"en","published_date":"2024-03-01T00:00:00Z"}`},
+	}
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			b, err := json.Marshal(&c.data)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(b) != c.want {
+				t.Errorf("json = %s, want %s", b, c.want)
+			}
+
+			// the json is parsed back to the data, the missing date is zero.
+			var d Data
+			if err = json.Unmarshal(b, &d); err != nil {
+				t.Fatal(err)
+			}
+			if !d.PublishedDate.Equal(c.data.PublishedDate) || d.Url != c.data.Url {
+				t.Errorf("parsed data %+v", d)
+			}
+		})
+	}
+}
+
+func TestToJSONUnknownVersion(t *testing.T) {
+	if _, err := ToJSON(jsonFixture(), "go", 2, 3); err == nil {
+		t.Error("the unknown version is serialized")
+	}
+}
@@ -0,0 +1,35 @@
+package result
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+)
+
+// flusher is implemented by writers buffering data, e.g. http.ResponseWriter.
+type flusher interface {
+	Flush()
+}
+
+// StreamJSONL writes each data from ch to w as a line of json, w is flushed after each line if possible.
+// It returns when ch is closed or ctx is done.
+func StreamJSONL(ctx context.Context, ch <-chan *Data, w io.Writer) error {
+	enc := json.NewEncoder(w)
+	f, _ := w.(flusher)
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case d, ok := <-ch:
+			if !ok {
+				return nil
+			}
+			if err := enc.Encode(d); err != nil {
+				return err
+			}
+			if f != nil {
+				f.Flush()
+			}
+		}
+	}
+}
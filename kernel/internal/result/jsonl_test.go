@@ -0,0 +1,70 @@
+package result
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+// flushWriter reports the lines written at each flush.
+type flushWriter struct {
+	buf     bytes.Buffer
+	flushed chan string
+}
+
+func (w *flushWriter) Write(p []byte) (int, error) { return w.buf.Write(p) }
+
+func (w *flushWriter) Flush() {
+	w.flushed <- w.buf.String()
+	w.buf.Reset()
+}
+
+func TestStreamJSONLFlushesIncrementally(t *testing.T) {
+	ch := make(chan *Data)
+	w := &flushWriter{flushed: make(chan string)}
+	done := make(chan error, 1)
+	go func() { done <- StreamJSONL(context.Background(), ch, w) }()
+
+	for _, u := range []string{"https://a.example.com", "https://b.example.com"} {
+		ch <- &Data{Engine: "a", Url: u}
+		// the line is flushed before the next data is sent.
+		select {
+		case line := <-w.flushed:
+			var d Data
+			if err := json.Unmarshal([]byte(line), &d); err != nil || d.Url != u || !strings.HasSuffix(line, "\n") {
+				t.Fatalf("flushed %q, err %v", line, err)
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("%s is not flushed", u)
+		}
+	}
+
+	close(ch)
+	if err := <-done; err != nil {
+		t.Errorf("err = %v, want nil once the channel is closed", err)
+	}
+}
+
+func TestStreamJSONLCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	var buf bytes.Buffer
+	go func() { done <- StreamJSONL(ctx, make(chan *Data), &buf) }()
+
+	cancel()
+	select {
+	case err := <-done:
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("err = %v, want canceled", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("the stream is not terminated on cancel")
+	}
+	if buf.Len() != 0 {
+		t.Errorf("written %q", buf.String())
+	}
+}
@@ -0,0 +1,28 @@
+package result
+
+import (
+	"slices"
+	"strings"
+)
+
+// FilterByLanguage keeps the data in any of allowed languages, e.g. en or en-US.
+// The data of unknown language are kept, since the detection is not confident on short texts.
+func (r *Result) FilterByLanguage(allowed []string) {
+	if len(allowed) == 0 {
+		return
+	}
+
+	langs := make([]string, 0, len(allowed))
+	for _, l := range allowed {
+		langs = append(langs, baseLanguage(l))
+	}
+	r.Filter(func(d *Data) bool {
+		return d.Lang == "" || slices.Contains(langs, baseLanguage(d.Lang))
+	})
+}
+
+// baseLanguage gets the base language of locale, e.g. en-US -> en.
+func baseLanguage(locale string) string {
+	lang, _, _ := strings.Cut(strings.ReplaceAll(locale, "_", "-"), "-")
+	return strings.ToLower(lang)
+}
@@ -0,0 +1,38 @@
+package result
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestFilterByLanguage(t *testing.T) {
+	cases := map[string]struct {
+		allowed []string
+		want    []string
+	}{
+		"base language":  {allowed: []string{"en-US"}, want: []string{"https://en.example.com", "https://gb.example.com", "https://unknown.example.com"}},
+		"underscore":     {allowed: []string{"de_DE"}, want: []string{"https://de.example.com", "https://unknown.example.com"}},
+		"any of allowed": {allowed: []string{"de", "FR"}, want: []string{"https://de.example.com", "https://fr.example.com", "https://unknown.example.com"}},
+		"no language":    {want: []string{"https://en.example.com", "https://gb.example.com", "https://de.example.com", "https://fr.example.com", "https://unknown.example.com"}},
+	}
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			r := CreateResult("a", 1)
+			r.AppendData(&Data{Url: "https://en.example.com", Lang: "en"})
+			r.AppendData(&Data{Url: "https://gb.example.com", Lang: "en-GB"})
+			r.AppendData(&Data{Url: "https://de.example.com", Lang: "de"})
+			r.AppendData(&Data{Url: "https://fr.example.com", Lang: "fr"})
+			// the data of unknown language are kept.
+			r.AppendData(&Data{Url: "https://unknown.example.com"})
+
+			r.FilterByLanguage(c.allowed)
+			var got []string
+			for _, d := range r.MergedData {
+				got = append(got, d.Url)
+			}
+			if !slices.Equal(got, c.want) {
+				t.Errorf("got %v, want %v", got, c.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,67 @@
+package result
+
+import (
+	"bufio"
+	"io"
+	"strings"
+)
+
+// markdownEscaper escapes the characters of markdown syntax in text.
+var markdownEscaper = strings.NewReplacer(
+	`\`, `\\`, "`", "\\`", "*", `\*`, "_", `\_`, "[", `\[`, "]", `\]`,
+	"<", `\<`, ">", `\>`, "#", `\#`, "|", `\|`,
+)
+
+// markdownUrlEscaper escapes the characters of url which end the link destination.
+var markdownUrlEscaper = strings.NewReplacer("(", "%28", ")", "%29", " ", "%20")
+
+// escapeMarkdown escapes the text for markdown, the newlines are folded so that an item keeps in one line.
+func escapeMarkdown(s string) string {
+	return markdownEscaper.Replace(strings.Join(strings.Fields(s), " "))
+}
+
+// ToMarkdown writes the data of result as a markdown list, e.g. - [Title](URL) — snippet.
+// The answers and infobox are written on top of the list if any.
+func ToMarkdown(r *Result, w io.Writer) error {
+	bw := bufio.NewWriter(w)
+	link := func(title, url string) string {
+		return "[" + escapeMarkdown(title) + "](" + markdownUrlEscaper.Replace(url) + ")"
+	}
+
+	if len(r.Answers) > 0 {
+		bw.WriteString("## Answers\n\n")
+		for _, a := range r.Answers {
+			bw.WriteString("- " + escapeMarkdown(a.Answer))
+			if a.Url != "" {
+				bw.WriteString(" (" + link(a.Engine, a.Url) + ")")
+			}
+			bw.WriteString("\n")
+		}
+		bw.WriteString("\n")
+	}
+
+	if b := r.InfoBox; b != nil {
+		bw.WriteString("## " + escapeMarkdown(b.Title) + "\n\n")
+		if b.Content != "" {
+			bw.WriteString(escapeMarkdown(b.Content) + "\n\n")
+		}
+		for _, u := range b.UrlList {
+			bw.WriteString("- " + link(u["title"], u["url"]) + "\n")
+		}
+		if len(b.UrlList) > 0 {
+			bw.WriteString("\n")
+		}
+	}
+
+	if len(r.Answers) > 0 || r.InfoBox != nil {
+		bw.WriteString("## Results\n\n")
+	}
+	for _, d := range r.GetData() {
+		bw.WriteString("- " + link(d.Title, d.Url))
+		if content := escapeMarkdown(d.Content); content != "" {
+			bw.WriteString(" — " + content)
+		}
+		bw.WriteString("\n")
+	}
+	return bw.Flush()
+}
@@ -0,0 +1,65 @@
+package result
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestEscapeMarkdown(t *testing.T) {
+	cases := map[string]string{
+		"plain text":                  "plain text",
+		"*bold* and _italic_":         `\*bold\* and \_italic\_`,
+		"[link](https://example.com)": `\[link\](https://example.com)`,
+		"a | b # c":                   `a \| b \# c`,
+		"<script>`code`</script>":     "\\<script\\>\\`code\\`\\</script\\>",
+		`back\slash`:                  `back\\slash`,
+		"line one\n\n  line two\t":    "line one line two",
+	}
+	for s, want := range cases {
+		if got := escapeMarkdown(s); got != want {
+			t.Errorf("escapeMarkdown(%q) = %q, want %q", s, got, want)
+		}
+	}
+}
+
+func TestToMarkdown(t *testing.T) {
+	r := CreateResult("", 1)
+	r.Answers = []Answer{{Answer: "1 + 1 = 2"}, {Answer: "Go is a *language*", Url: "https://go.dev/", Engine: "duckduckgo_answer"}}
+	r.InfoBox = &InfoBox{Title: "Go (programming language)", Content: "Go is\nstatically typed.",
+		UrlList: []map[string]string{{"title": "Wikipedia", "url": "https://en.wikipedia.org/wiki/Go_(programming_language)"}}}
+	r.MergedData = []*Data{
+		{Title: "The Go [Programming] Language", Url: "https://go.dev/doc?q=a b", Content: "Build *simple*,\nsecure systems."},
+		{Title: "No content", Url: "https://example.com/"},
+	}
+
+	var buf bytes.Buffer
+	if err := ToMarkdown(r, &buf); err != nil {
+		t.Fatal(err)
+	}
+	want := "## Answers\n\n" +
+		"- 1 + 1 = 2\n" +
+		"- Go is a \\*language\\* ([duckduckgo\\_answer](https://go.dev/))\n\n" +
+		"## Go (programming language)\n\n" +
+		"Go is statically typed.\n\n" +
+		"- [Wikipedia](https://en.wikipedia.org/wiki/Go_%28programming_language%29)\n\n" +
+		"## Results\n\n" +
+		"- [The Go \\[Programming\\] Language](https://go.dev/doc?q=a%20b) — Build \\*simple\\*, secure systems.\n" +
+		"- [No content](https://example.com/)\n"
+	if buf.String() != want {
+		t.Errorf("got:\n%s\nwant:\n%s", buf.String(), want)
+	}
+}
+
+func TestToMarkdownResultsOnly(t *testing.T) {
+	r := CreateResult("", 1)
+	r.MergedData = []*Data{{Title: "Go", Url: "https://go.dev/", Content: "Go"}}
+
+	var buf bytes.Buffer
+	if err := ToMarkdown(r, &buf); err != nil {
+		t.Fatal(err)
+	}
+	// the heading of results is left out without answers or infobox.
+	if want := "- [Go](https://go.dev/) — Go\n"; buf.String() != want {
+		t.Errorf("got %q, want %q", buf.String(), want)
+	}
+}
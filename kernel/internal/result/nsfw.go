@@ -0,0 +1,86 @@
+package result
+
+import (
+	"slices"
+	"strings"
+	"unicode"
+)
+
+// TagNSFW is the tag of adult data, annotated by engines or the classifier.
+const TagNSFW = "nsfw"
+
+// defaultNSFWKeywords are the keywords of default classifier, they are rarely seen in the titles of safe results.
+var defaultNSFWKeywords = []string{"porn", "porno", "pornhub", "xxx", "nsfw", "hentai", "nude", "nudes", "onlyfans", "camgirl", "milf", "xvideos"}
+
+// NSFWConfig is the configuration of classifying the adult data that slipped past the safe search of engines.
+type NSFWConfig struct {
+	Enable bool `mapstructure:"enable"`
+
+	// Keywords are the words of default classifier, the default keywords are used if empty.
+	Keywords []string `mapstructure:"keywords"`
+}
+
+// NSFWClassifier classifies the adult data, e.g. by keywords or an external model.
+type NSFWClassifier interface {
+	IsNSFW(d *Data) bool
+}
+
+// KeywordClassifier classifies the data with any of keywords as a word in title, content or url as adult.
+type KeywordClassifier struct {
+	keywords map[string]bool
+}
+
+func NewKeywordClassifier(keywords []string) *KeywordClassifier {
+	c := &KeywordClassifier{keywords: map[string]bool{}}
+	for _, k := range keywords {
+		c.keywords[strings.ToLower(strings.TrimSpace(k))] = true
+	}
+	return c
+}
+
+func (c *KeywordClassifier) IsNSFW(d *Data) bool {
+	for _, text := range []string{d.Title, d.Content, d.Url} {
+		words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+			return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+		})
+		if slices.ContainsFunc(words, func(w string) bool { return c.keywords[w] }) {
+			return true
+		}
+	}
+	return false
+}
+
+// nsfwClassifier is the classifier used by FilterNSFW, nil means the data are not classified.
+var nsfwClassifier NSFWClassifier
+
+// SetNSFWClassifier sets the classifier of adult data, it replaces the keyword classifier of config.
+func SetNSFWClassifier(c NSFWClassifier) {
+	nsfwClassifier = c
+}
+
+func initNSFWClassifier(c NSFWConfig) {
+	if !c.Enable {
+		nsfwClassifier = nil
+		return
+	}
+	keywords := c.Keywords
+	if len(keywords) == 0 {
+		keywords = defaultNSFWKeywords
+	}
+	nsfwClassifier = NewKeywordClassifier(keywords)
+}
+
+// FilterNSFW tags the adult data by the classifier with nsfw, and drops them if strict.
+// The data tagged by engines are dropped if strict as well. It is a no-op without classifier.
+func (r *Result) FilterNSFW(strict bool) {
+	c := nsfwClassifier
+	if c == nil {
+		return
+	}
+	r.Filter(func(d *Data) bool {
+		if !slices.Contains(d.Tags, TagNSFW) && c.IsNSFW(d) {
+			d.Tags = append(slices.Clip(d.Tags), TagNSFW)
+		}
+		return !strict || !slices.Contains(d.Tags, TagNSFW)
+	})
+}
@@ -0,0 +1,97 @@
+package result
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestKeywordClassifier(t *testing.T) {
+	k := NewKeywordClassifier([]string{" XXX ", "nude"})
+	cases := map[string]struct {
+		data *Data
+		want bool
+	}{
+		"title":       {data: &Data{Title: "The XXX collection"}, want: true},
+		"content":     {data: &Data{Content: "nude, beaches of europe"}, want: true},
+		"url":         {data: &Data{Url: "https://xxx.example.com/video"}, want: true},
+		"safe":        {data: &Data{Title: "Golang tutorial", Url: "https://go.dev"}},
+		"within word": {data: &Data{Title: "Nudel recipes", Content: "a tuxxxedo"}},
+	}
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			if got := k.IsNSFW(c.data); got != c.want {
+				t.Errorf("nsfw = %v, want %v", got, c.want)
+			}
+		})
+	}
+}
+
+func nsfwFixture() *Result {
+	r := CreateResult("", 1)
+	r.MergedData = []*Data{
+		{Url: "https://go.dev", Title: "Golang"},
+		{Url: "https://a.example.com", Title: "porn videos"},
+		{Url: "https://b.example.com", Title: "Tagged by engine", Tags: []string{"video", TagNSFW}},
+	}
+	return r
+}
+
+func TestFilterNSFW(t *testing.T) {
+	t.Cleanup(func() { InitConfig(Config{}) })
+
+	cases := map[string]struct {
+		conf   NSFWConfig
+		strict bool
+		want   []string
+		tagged []string
+	}{
+		"moderate": {conf: NSFWConfig{Enable: true}, want: []string{"https://go.dev", "https://a.example.com", "https://b.example.com"},
+			tagged: []string{"https://a.example.com", "https://b.example.com"}},
+		"strict":          {conf: NSFWConfig{Enable: true}, strict: true, want: []string{"https://go.dev"}},
+		"custom keywords": {conf: NSFWConfig{Enable: true, Keywords: []string{"golang"}}, strict: true, want: []string{"https://a.example.com"}},
+		"disabled":        {strict: true, want: []string{"https://go.dev", "https://a.example.com", "https://b.example.com"}, tagged: []string{"https://b.example.com"}},
+	}
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			InitConfig(Config{NSFW: c.conf})
+			r := nsfwFixture()
+			r.FilterNSFW(c.strict)
+
+			var got, tagged []string
+			for _, d := range r.GetData() {
+				got = append(got, d.Url)
+				if slices.Contains(d.Tags, TagNSFW) {
+					tagged = append(tagged, d.Url)
+				}
+			}
+			if !slices.Equal(got, c.want) || !slices.Equal(tagged, c.tagged) {
+				t.Errorf("got %v tagged %v, want %v tagged %v", got, tagged, c.want, c.tagged)
+			}
+		})
+	}
+}
+
+// urlClassifier classifies the data of urls as adult.
+type urlClassifier []string
+
+func (c urlClassifier) IsNSFW(d *Data) bool { return slices.Contains(c, d.Url) }
+
+func TestSetNSFWClassifier(t *testing.T) {
+	t.Cleanup(func() { InitConfig(Config{}) })
+
+	InitConfig(Config{NSFW: NSFWConfig{Enable: true}})
+	SetNSFWClassifier(urlClassifier{"https://go.dev"})
+	r := nsfwFixture()
+	r.FilterNSFW(true)
+	assertUrls(t, urls(r), "https://a.example.com")
+
+	// the tags of engine are not shared with the original data.
+	tags := make([]string, 1, 2)
+	tags[0] = "video"
+	r = CreateResult("", 1)
+	r.MergedData = []*Data{{Url: "https://go.dev", Tags: tags}}
+	r.FilterNSFW(false)
+	if tags[:2][1] == TagNSFW {
+		t.Error("the tags of data are appended in place")
+	}
+}
@@ -1,7 +1,10 @@
 package result
 
 import (
+	"slices"
 	"sort"
+	"sync"
+	"time"
 
 	"github.com/zvirgilx/searxng-go/kernel/internal/util"
 )
@@ -9,16 +12,50 @@ import (
 type Config struct {
 	Score  Score                     `mapstructure:"score"`
 	Limits map[string]map[string]int `mapstructure:"limits"`
+
+	// EnginePriority is the engine names ordered by priority, used by engine-priority sorting.
+	EnginePriority []string `mapstructure:"engine_priority"`
+
+	// MergeDuplicates merges the data of the same canonical url from different engines into one,
+	// the richest combination of their fields is kept.
+	MergeDuplicates bool `mapstructure:"merge_duplicates"`
+
+	// InterleavePerRound is the count of data taken from each category in a round when searching multiple categories.
+	InterleavePerRound int `mapstructure:"interleave_per_round"`
+
+	// NSFW classifies the adult data, they are tagged with nsfw for moderate safe search and dropped for strict one.
+	NSFW NSFWConfig `mapstructure:"nsfw"`
 }
 
-// Result of search
+// Result of search, the methods building the result are safe for concurrent use.
 type Result struct {
 	MergedData  []*Data   `json:"merged_data"` // MergedData store result from different search engines.
 	Suggestions *util.Set `json:"suggestions"` // Suggestions store suggestion from different search engines.
 	InfoBox     *InfoBox  `json:"infoBox"`     // InfoBox store information from wikipedia of query
+	Answers     []Answer  `json:"answers"`     // Answers are the direct answers of query, the duplicated ones are merged.
+
+	TimedOutEngines []string `json:"timed_out_engines"` // TimedOutEngines are engines not finished before the search deadline.
+
+	Corrections    []string `json:"corrections"`     // Corrections are the "did you mean" queries from engines, ordered by arrival.
+	CorrectedQuery string   `json:"corrected_query"` // CorrectedQuery is the correction the search is rerun with.
+
+	Warnings []string `json:"warnings"` // Warnings are the diagnostics of engines, e.g. the items skipped during parsing.
+
+	Cached   bool      `json:"cached"`    // Cached reports whether any results are served from cache.
+	CachedAt time.Time `json:"cached_at"` // CachedAt is the time the oldest cached results were fetched.
+	Stale    bool      `json:"stale"`     // Stale reports whether the expired cached results are served since all engines failed.
 
+	// KeepOrder keeps the data in the order they are appended rather than sorted by relevance,
+	// e.g. the results of a single engine in its own order. The other strategies of SortBy still apply.
+	KeepOrder bool `json:"-"`
+
+	RedirectURL string `json:"redirect_url"` // RedirectURL is the site a navigational query is redirected to, empty if the query is not navigational.
+
+	Query  string `json:"-"` // Query is the query of search, it is only set on the merged result.
 	From   string `json:"-"` // From means the engine name of the search results.
 	PageNo int    `json:"-"` // PageNo means the page number of result. PageNo = 1 means first page.
+
+	mu sync.Mutex // mu guards MergedData.
 }
 
 // InfoBox of search query from wikipedia(temporary)
@@ -28,6 +65,7 @@ type InfoBox struct {
 	ImgSrc  string              `json:"img_src"`
 	Url     string              `json:"url"`
 	UrlList []map[string]string `json:"url_list"`
+	Engine  string              `json:"engine"` // Engine is the engine of infobox, it is set to the engine of result by merging if empty.
 }
 
 var conf Config
@@ -36,6 +74,7 @@ func InitConfig(c Config) {
 	conf = c
 
 	loadRule()
+	initNSFWClassifier(c.NSFW)
 }
 
 func CreateResult(from string, page int) *Result {
@@ -48,28 +87,80 @@ func CreateResult(from string, page int) *Result {
 
 // Merge engine search result
 func (r *Result) Merge(result *Result) {
+	result.mu.Lock()
 	result.sortData()
+	data := result.MergedData
+	result.mu.Unlock()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
 
 	page := ""
 	if r.isFirstPage() {
 		page = "first"
 	}
 
-	limit := len(result.MergedData)
+	limit := len(data)
 	if maxSize, ok := conf.Limits[page]; ok {
 		if m, have := maxSize[result.From]; have && m < limit {
 			limit = m
 		}
 	}
 
-	r.MergedData = append(r.MergedData, result.MergedData[:limit]...)
+	if conf.MergeDuplicates {
+		r.MergedData = mergeDuplicates(r.MergedData, data[:limit])
+	} else {
+		r.MergedData = append(r.MergedData, data[:limit]...)
+	}
 
 	util.SetMerge[string](r.Suggestions, result.Suggestions)
+	r.TimedOutEngines = append(r.TimedOutEngines, result.TimedOutEngines...)
 
-	if r.InfoBox == nil && result.InfoBox != nil {
-		r.InfoBox = result.InfoBox
+	for _, c := range result.Corrections {
+		if !slices.Contains(r.Corrections, c) {
+			r.Corrections = append(r.Corrections, c)
+		}
 	}
 
+	r.Answers = mergeAnswers(r.Answers, result.Answers)
+	r.Warnings = append(r.Warnings, result.Warnings...)
+
+	infoBox := result.InfoBox
+	if infoBox != nil && infoBox.Engine == "" {
+		b := *infoBox
+		b.Engine = result.From
+		infoBox = &b
+	}
+	r.InfoBox = mergeInfoBox(r.InfoBox, infoBox)
+
+	if result.Cached {
+		if !r.Cached || result.CachedAt.Before(r.CachedAt) {
+			r.CachedAt = result.CachedAt
+		}
+		r.Cached = true
+	}
+
+}
+
+// Clone returns a copy of result, the data are copied so that changing them does not affect the origin.
+func (r *Result) Clone() *Result {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	c := CreateResult(r.From, r.PageNo)
+	util.SetMerge[string](c.Suggestions, r.Suggestions)
+	c.InfoBox = r.InfoBox
+	c.Corrections = slices.Clone(r.Corrections)
+	c.Answers = slices.Clone(r.Answers)
+	c.Warnings = slices.Clone(r.Warnings)
+	c.Cached, c.CachedAt = r.Cached, r.CachedAt
+	c.KeepOrder = r.KeepOrder
+	c.MergedData = make([]*Data, 0, len(r.MergedData))
+	for _, d := range r.MergedData {
+		data := *d
+		c.MergedData = append(c.MergedData, &data)
+	}
+	return c
 }
 
 func (r *Result) isFirstPage() bool {
@@ -77,23 +168,131 @@ func (r *Result) isFirstPage() bool {
 }
 
 func (r *Result) AppendData(d *Data) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
 	r.MergedData = append(r.MergedData, d.unstructured().doScore())
 }
 
+// AppendDataUnique appends the data only if no data with the same canonical url exists in the result.
+// It reports whether the data is appended.
+func (r *Result) AppendDataUnique(d *Data) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	key := canonicalKey(d.Url)
+	for _, data := range r.MergedData {
+		if canonicalKey(data.Url) == key {
+			return false
+		}
+	}
+	r.MergedData = append(r.MergedData, d.unstructured().doScore())
+	return true
+}
+
+// canonicalKey is the canonical url used for deduplication, the raw url is used if it is not canonicalizable.
+func canonicalKey(raw string) string {
+	if u, err := CanonicalizeURL(raw); err == nil {
+		return u
+	}
+	return raw
+}
+
+// AssignPositions sets the positions of data without one by their order, the data are expected to be of a single engine.
+func (r *Result) AssignPositions() {
+	if r == nil {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for i, d := range r.MergedData {
+		if d.Position == 0 {
+			d.Position = i + 1
+		}
+	}
+}
+
+// Filter keeps the data of result which keep reports true.
+func (r *Result) Filter(keep func(d *Data) bool) {
+	if r == nil {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	data := r.MergedData[:0]
+	for _, d := range r.MergedData {
+		if keep(d) {
+			data = append(data, d)
+		}
+	}
+	r.MergedData = data
+}
+
+// FilterByTag keeps the data with any tag of include and none of exclude, empty include keeps data of any tag.
+func (r *Result) FilterByTag(include, exclude []string) {
+	if len(include) == 0 && len(exclude) == 0 {
+		return
+	}
+	r.Filter(func(d *Data) bool {
+		if len(include) > 0 && !slices.ContainsFunc(d.Tags, func(t string) bool { return slices.Contains(include, t) }) {
+			return false
+		}
+		return !slices.ContainsFunc(d.Tags, func(t string) bool { return slices.Contains(exclude, t) })
+	})
+}
+
+// Truncate keeps the first n data of result.
+func (r *Result) Truncate(n int) {
+	if r == nil {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if n < 0 || len(r.MergedData) <= n {
+		return
+	}
+	r.MergedData = r.MergedData[:n]
+}
+
+// GetData returns the data of result, it is nil-safe.
+func (r *Result) GetData() []*Data {
+	if r == nil {
+		return nil
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.MergedData
+}
+
 func (r *Result) GetDataSize() int {
 	if r == nil {
 		return 0
 	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
 	return len(r.MergedData)
 }
 
 func (r *Result) GetSortedData() []*Data {
+	r.mu.Lock()
+	defer r.mu.Unlock()
 	r.sortData()
 	return r.MergedData
 }
 
+// sortData sorts the data by score, the caller must hold the lock.
+// The data of equal score are ordered by engine priority then url, so that the order is stable across searches.
+// The data of result keeping order are not sorted.
 func (r *Result) sortData() {
+	if r.KeepOrder {
+		return
+	}
 	sort.Slice(r.MergedData, func(i, j int) bool {
-		return r.MergedData[i].score > r.MergedData[j].score
+		di, dj := r.MergedData[i], r.MergedData[j]
+		if di.score != dj.score {
+			return di.score > dj.score
+		}
+		if pi, pj := enginePriority(di.Engine), enginePriority(dj.Engine); pi != pj {
+			return pi < pj
+		}
+		return di.Url < dj.Url
 	})
 }
@@ -0,0 +1,110 @@
+package result
+
+import (
+	"fmt"
+	"slices"
+	"sync"
+	"testing"
+)
+
+func TestAppendDataUnique(t *testing.T) {
+	r := CreateResult("bing_videos", 1)
+	for _, u := range []string{
+		"https://www.youtube.com/watch?v=a",
+		"https://WWW.youtube.com/watch?v=a&utm_source=bing",
+		"https://www.youtube.com/watch?v=b",
+	} {
+		r.AppendDataUnique(&Data{Engine: "bing_videos", Url: u})
+	}
+
+	data := r.GetData()
+	if len(data) != 2 {
+		t.Fatalf("got %d data, want 2", len(data))
+	}
+	if data[0].Url != "https://www.youtube.com/watch?v=a" {
+		t.Errorf("the first variant is not kept: %s", data[0].Url)
+	}
+	if r.AppendDataUnique(&Data{Url: "https://www.youtube.com/watch?v=b#t=10"}) {
+		t.Error("the duplicate with fragment is appended")
+	}
+}
+
+// TestResultConcurrentUse is meaningful with -race, the result is built by several goroutines.
+func TestResultConcurrentUse(t *testing.T) {
+	r := CreateResult("", 1)
+	w := &sync.WaitGroup{}
+	for i := 0; i < 8; i++ {
+		w.Add(1)
+		go func(i int) {
+			defer w.Done()
+			engine := fmt.Sprintf("engine%d", i)
+			res := CreateResult(engine, 1)
+			for j := 0; j < 10; j++ {
+				res.AppendData(&Data{Engine: engine, Url: fmt.Sprintf("https://%s.example.com/%d", engine, j)})
+				r.AppendDataUnique(&Data{Engine: engine, Url: fmt.Sprintf("https://unique.example.com/%d/%d", i, j)})
+				r.GetDataSize()
+			}
+			r.Merge(res)
+			r.GetData()
+		}(i)
+	}
+	w.Wait()
+
+	if n := r.GetDataSize(); n != 160 {
+		t.Errorf("got %d data, want 160", n)
+	}
+}
+
+func TestFilterByTag(t *testing.T) {
+	cases := map[string]struct {
+		include, exclude []string
+		want             []string
+	}{
+		"no filter":          {want: []string{"video", "verified video", "nsfw video", "untagged"}},
+		"include":            {include: []string{"verified"}, want: []string{"verified video"}},
+		"include any":        {include: []string{"verified", "nsfw"}, want: []string{"verified video", "nsfw video"}},
+		"exclude":            {exclude: []string{"nsfw"}, want: []string{"video", "verified video", "untagged"}},
+		"include, exclude":   {include: []string{"video"}, exclude: []string{"nsfw"}, want: []string{"video", "verified video"}},
+		"include of no data": {include: []string{"live"}},
+	}
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			r := CreateResult("bing_videos", 1)
+			r.AppendData(&Data{Title: "video", Tags: []string{"video"}})
+			r.AppendData(&Data{Title: "verified video", Tags: []string{"video", "verified"}})
+			r.AppendData(&Data{Title: "nsfw video", Tags: []string{"video", "nsfw"}})
+			r.AppendData(&Data{Title: "untagged"})
+
+			r.FilterByTag(c.include, c.exclude)
+			var titles []string
+			for _, d := range r.GetData() {
+				titles = append(titles, d.Title)
+			}
+			if !slices.Equal(titles, c.want) {
+				t.Errorf("got %v, want %v", titles, c.want)
+			}
+		})
+	}
+}
+
+func TestMergeWarnings(t *testing.T) {
+	r := CreateResult("", 1)
+	a := CreateResult("a", 1)
+	a.Warnings = []string{"a: skipped 1 of 2 items, missing title"}
+	b := CreateResult("b", 1)
+	b.Warnings = []string{"b: skipped 2 of 3 items, missing url"}
+	r.Merge(a)
+	r.Merge(b)
+
+	want := []string{"a: skipped 1 of 2 items, missing title", "b: skipped 2 of 3 items, missing url"}
+	if !slices.Equal(r.Warnings, want) {
+		t.Errorf("warnings = %q, want %q", r.Warnings, want)
+	}
+
+	// the warnings of clone are not shared.
+	c := r.Clone()
+	c.Warnings[0] = "changed"
+	if r.Warnings[0] != want[0] {
+		t.Error("the warnings of clone are shared with the result")
+	}
+}
@@ -0,0 +1,61 @@
+package result
+
+import (
+	"encoding/xml"
+	"io"
+	"time"
+)
+
+type rss struct {
+	XMLName xml.Name   `xml:"rss"`
+	Version string     `xml:"version,attr"`
+	Channel rssChannel `xml:"channel"`
+}
+
+type rssChannel struct {
+	Title       string    `xml:"title"`
+	Link        string    `xml:"link"`
+	Description string    `xml:"description"`
+	Items       []rssItem `xml:"item"`
+}
+
+type rssItem struct {
+	Title       string `xml:"title"`
+	Link        string `xml:"link"`
+	Description string `xml:"description"`
+	PubDate     string `xml:"pubDate,omitempty"`
+	Category    string `xml:"category,omitempty"`
+}
+
+// ToRSS writes the data of result as a rss 2.0 feed of the query, link is the url of the search.
+func ToRSS(r *Result, query, link string, w io.Writer) error {
+	feed := rss{
+		Version: "2.0",
+		Channel: rssChannel{
+			Title:       "searxng-go search: " + query,
+			Link:        link,
+			Description: "Search results for \"" + query + "\" - searxng-go",
+		},
+	}
+
+	for _, d := range r.GetData() {
+		var pubDate string
+		if !d.PublishedDate.IsZero() {
+			pubDate = d.PublishedDate.Format(time.RFC1123Z)
+		}
+		feed.Channel.Items = append(feed.Channel.Items, rssItem{
+			Title:       d.Title,
+			Link:        d.Url,
+			Description: d.Content,
+			PubDate:     pubDate,
+			Category:    d.Category,
+		})
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	return enc.Encode(feed)
+}
@@ -0,0 +1,46 @@
+package result
+
+import (
+	"bytes"
+	"encoding/xml"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestToRSS(t *testing.T) {
+	r := CreateResult("", 1)
+	r.MergedData = []*Data{
+		{Title: "Go <1.22>", Url: "https://go.dev/?a=1&b=2", Content: "loop & vars", Category: "it",
+			PublishedDate: time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)},
+		{Title: "plain", Url: "https://example.com/"},
+	}
+
+	var buf bytes.Buffer
+	if err := ToRSS(r, "go & rust", "/search?q=go&format=rss", &buf); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(buf.String(), xml.Header) {
+		t.Errorf("the xml header is missing:\n%s", buf.String())
+	}
+
+	var feed rss
+	if err := xml.Unmarshal(buf.Bytes(), &feed); err != nil {
+		t.Fatalf("the feed is not well-formed: %v", err)
+	}
+	if feed.Version != "2.0" || feed.Channel.Title != "searxng-go search: go & rust" || feed.Channel.Link != "/search?q=go&format=rss" {
+		t.Errorf("channel = %+v", feed.Channel)
+	}
+	want := []rssItem{
+		{Title: "Go <1.22>", Link: "https://go.dev/?a=1&b=2", Description: "loop & vars", PubDate: "Fri, 01 Mar 2024 12:00:00 +0000", Category: "it"},
+		{Title: "plain", Link: "https://example.com/"},
+	}
+	if len(feed.Channel.Items) != len(want) {
+		t.Fatalf("got %d items, want %d", len(feed.Channel.Items), len(want))
+	}
+	for i := range want {
+		if feed.Channel.Items[i] != want[i] {
+			t.Errorf("item %d = %+v, want %+v", i, feed.Channel.Items[i], want[i])
+		}
+	}
+}
@@ -0,0 +1,49 @@
+package result
+
+import (
+	"slices"
+	"sort"
+)
+
+const (
+	// SortByRelevance sorts data by score, it is the default strategy.
+	SortByRelevance = "relevance"
+	// SortByDate sorts data by published date desc, data without published date are pushed to the end.
+	SortByDate = "date"
+	// SortByEnginePriority sorts data by the configured priority of engines.
+	SortByEnginePriority = "engine-priority"
+)
+
+// SortBy sorts the data of result according to the strategy.
+// Unknown strategy is sorted by relevance.
+func (r *Result) SortBy(strategy string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	// data are sorted by relevance first, so that the equal data of other strategies keep the relevance order.
+	r.sortData()
+
+	switch strategy {
+	case SortByDate:
+		sort.SliceStable(r.MergedData, func(i, j int) bool {
+			di, dj := r.MergedData[i].PublishedDate, r.MergedData[j].PublishedDate
+			if di.IsZero() || dj.IsZero() {
+				return !di.IsZero()
+			}
+			return di.After(dj)
+		})
+	case SortByEnginePriority:
+		sort.SliceStable(r.MergedData, func(i, j int) bool {
+			return enginePriority(r.MergedData[i].Engine) < enginePriority(r.MergedData[j].Engine)
+		})
+	}
+}
+
+// enginePriority returns the index of engine in the configured priority list.
+// Engines not in the list have the lowest priority.
+func enginePriority(engine string) int {
+	if i := slices.Index(conf.EnginePriority, engine); i != -1 {
+		return i
+	}
+	return len(conf.EnginePriority)
+}
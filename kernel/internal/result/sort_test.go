@@ -0,0 +1,115 @@
+package result
+
+import (
+	"testing"
+	"time"
+)
+
+// sortFixture has the data of different scores, engines and published dates.
+func sortFixture() *Result {
+	day := func(d int) time.Time { return time.Date(2024, 1, d, 0, 0, 0, 0, time.UTC) }
+	r := CreateResult("", 1)
+	r.MergedData = []*Data{
+		{Engine: "bing", Url: "https://a.example.com", score: 3, PublishedDate: day(1)},
+		{Engine: "google", Url: "https://b.example.com", score: 5},
+		{Engine: "bing", Url: "https://c.example.com", score: 1, PublishedDate: day(20)},
+		{Engine: "yahoo", Url: "https://d.example.com", score: 4},
+		{Engine: "google", Url: "https://e.example.com", score: 2, PublishedDate: day(10)},
+	}
+	return r
+}
+
+func urls(r *Result) []string {
+	var us []string
+	for _, d := range r.GetData() {
+		us = append(us, d.Url)
+	}
+	return us
+}
+
+func assertUrls(t *testing.T, got []string, want ...string) {
+	t.Helper()
+	if len(got) != len(want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+	for i := range got {
+		if got[i] != want[i] {
+			t.Fatalf("got %v, want %v", got, want)
+		}
+	}
+}
+
+func TestSortByRelevance(t *testing.T) {
+	r := sortFixture()
+	r.SortBy(SortByRelevance)
+	assertUrls(t, urls(r), "https://b.example.com", "https://d.example.com", "https://a.example.com", "https://e.example.com", "https://c.example.com")
+
+	// unknown strategy is sorted by relevance.
+	r = sortFixture()
+	r.SortBy("unknown")
+	assertUrls(t, urls(r), "https://b.example.com", "https://d.example.com", "https://a.example.com", "https://e.example.com", "https://c.example.com")
+}
+
+func TestSortByDate(t *testing.T) {
+	r := sortFixture()
+	r.SortBy(SortByDate)
+	// the data without published date are pushed to the end in the relevance order.
+	assertUrls(t, urls(r), "https://c.example.com", "https://e.example.com", "https://a.example.com", "https://b.example.com", "https://d.example.com")
+}
+
+func TestSortByEnginePriority(t *testing.T) {
+	old := conf.EnginePriority
+	conf.EnginePriority = []string{"bing", "google"}
+	t.Cleanup(func() { conf.EnginePriority = old })
+
+	r := sortFixture()
+	r.SortBy(SortByEnginePriority)
+	// the data of the same engine keep the relevance order, engines not in the list are the last.
+	assertUrls(t, urls(r), "https://a.example.com", "https://c.example.com", "https://b.example.com", "https://e.example.com", "https://d.example.com")
+}
+
+func TestSortTieBreak(t *testing.T) {
+	old := conf.EnginePriority
+	conf.EnginePriority = []string{"google", "bing"}
+	t.Cleanup(func() { conf.EnginePriority = old })
+
+	tied := []*Data{
+		{Engine: "yahoo", Url: "https://b.example.com", score: 3},
+		{Engine: "bing", Url: "https://d.example.com", score: 3},
+		{Engine: "yahoo", Url: "https://a.example.com", score: 3},
+		{Engine: "google", Url: "https://e.example.com", score: 3},
+		{Engine: "bing", Url: "https://c.example.com", score: 3},
+		{Engine: "ddg", Url: "https://f.example.com", score: 4},
+	}
+	// the data of equal score are in the same order however they are arrived.
+	for i := 0; i < len(tied); i++ {
+		r := CreateResult("", 1)
+		r.MergedData = append(append([]*Data{}, tied[i:]...), tied[:i]...)
+		var sorted []string
+		for _, d := range r.GetSortedData() {
+			sorted = append(sorted, d.Url)
+		}
+		assertUrls(t, sorted, "https://f.example.com", "https://e.example.com", "https://c.example.com", "https://d.example.com",
+			"https://a.example.com", "https://b.example.com")
+	}
+}
+
+func TestSortKeepOrder(t *testing.T) {
+	r := sortFixture()
+	r.KeepOrder = true
+	r.SortBy(SortByRelevance)
+	assertUrls(t, urls(r), "https://a.example.com", "https://b.example.com", "https://c.example.com", "https://d.example.com", "https://e.example.com")
+
+	// the other strategies still apply, the data without published date keep the order.
+	r.SortBy(SortByDate)
+	assertUrls(t, urls(r), "https://c.example.com", "https://e.example.com", "https://a.example.com", "https://b.example.com", "https://d.example.com")
+
+	// the data merged into the result keep the order as well.
+	merged := CreateResult("", 1)
+	merged.KeepOrder = true
+	merged.Merge(r)
+	merged.Merge(sortFixture())
+	if got := merged.GetSortedData(); len(got) != 10 || got[0].Url != "https://c.example.com" || got[5].Url != "https://b.example.com" {
+		t.Errorf("unexpected merged data %v", urls(merged))
+	}
+}
@@ -0,0 +1,120 @@
+package result
+
+import (
+	"errors"
+	"net"
+	"net/url"
+	"strings"
+)
+
+// trackingParams are the query params only used for tracking, they are stripped by CanonicalizeURL.
+// A param ending with "*" matches the prefix, e.g. utm_*.
+var trackingParams = []string{"utm_*", "gclid", "dclid", "fbclid", "msclkid", "yclid", "mc_cid", "mc_eid", "_ga", "igshid"}
+
+// defaultPorts are the default ports of schemes, they are stripped by CanonicalizeURL.
+var defaultPorts = map[string]string{"http": "80", "https": "443"}
+
+// CanonicalizeURL normalizes the url, so that the same page linked differently by engines is considered equal.
+// The scheme and host are lowercased, the default port, fragment, tracking params and trailing slash are removed,
+// and the remaining query params are sorted. It is idempotent.
+func CanonicalizeURL(raw string) (string, error) {
+	u, err := url.Parse(strings.TrimSpace(raw))
+	if err != nil {
+		return "", err
+	}
+	if u.Host == "" {
+		return "", errors.New("url without host")
+	}
+
+	u.Scheme = strings.ToLower(u.Scheme)
+	host := strings.ToLower(u.Hostname())
+	port := u.Port()
+	if port == defaultPorts[u.Scheme] {
+		port = ""
+	}
+	switch {
+	case port != "":
+		u.Host = net.JoinHostPort(host, port)
+	case strings.Contains(host, ":"):
+		// the brackets of ipv6 are removed by Hostname.
+		u.Host = "[" + host + "]"
+	default:
+		u.Host = host
+	}
+
+	u.Fragment = ""
+	u.RawFragment = ""
+
+	// the root path is always "/", the trailing slash of other paths is removed.
+	escaped := strings.TrimSuffix(u.EscapedPath(), "/")
+	if escaped == "" {
+		escaped = "/"
+	}
+	if u.Path, err = url.PathUnescape(escaped); err != nil {
+		return "", err
+	}
+	u.RawPath = escaped
+
+	query := u.Query()
+	for k := range query {
+		if isTrackingParam(k) {
+			query.Del(k)
+		}
+	}
+	// Encode sorts the params by key.
+	u.RawQuery = query.Encode()
+
+	return u.String(), nil
+}
+
+func isTrackingParam(param string) bool {
+	param = strings.ToLower(param)
+	for _, p := range trackingParams {
+		if prefix, ok := strings.CutSuffix(p, "*"); ok && strings.HasPrefix(param, prefix) || param == p {
+			return true
+		}
+	}
+	return false
+}
+
+// AbsolutizeURLs resolves the relative urls of data against the base url,
+// the protocol-relative urls, e.g. //host/img.jpg, are resolved with the scheme of base, https by default.
+// The relative urls are kept if the base is empty or invalid.
+func (r *Result) AbsolutizeURLs(base string) {
+	if r == nil {
+		return
+	}
+
+	b, err := url.Parse(base)
+	if err != nil || !b.IsAbs() {
+		b = nil
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, d := range r.MergedData {
+		d.Url = absolutizeURL(b, d.Url)
+		d.Thumbnail = absolutizeURL(b, d.Thumbnail)
+		d.ImgSrc = absolutizeURL(b, d.ImgSrc)
+		d.PreviewUrl = absolutizeURL(b, d.PreviewUrl)
+	}
+}
+
+func absolutizeURL(base *url.URL, ref string) string {
+	if strings.HasPrefix(ref, "//") {
+		scheme := "https"
+		if base != nil {
+			scheme = base.Scheme
+		}
+		return scheme + ":" + ref
+	}
+	if base == nil || ref == "" {
+		return ref
+	}
+
+	u, err := base.Parse(ref)
+	if err != nil {
+		return ref
+	}
+	return u.String()
+}
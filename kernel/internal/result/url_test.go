@@ -0,0 +1,94 @@
+package result
+
+import "testing"
+
+func TestCanonicalizeURL(t *testing.T) {
+	cases := map[string]string{
+		"HTTPS://Example.COM/Go":                            "https://example.com/Go",
+		"https://example.com:443/go":                        "https://example.com/go",
+		"http://example.com:80/go":                          "http://example.com/go",
+		"http://example.com:8080/go":                        "http://example.com:8080/go",
+		"https://example.com/go/":                           "https://example.com/go",
+		"https://example.com":                               "https://example.com/",
+		"https://example.com/go#install":                    "https://example.com/go",
+		"https://example.com/go?utm_source=x&b=2&a=1&gclid": "https://example.com/go?a=1&b=2",
+		"https://example.com/go?UTM_Medium=x&fbclid=y":      "https://example.com/go",
+		"https://[::1]:443/go":                              "https://[::1]/go",
+		"https://[::1]:8443/go":                             "https://[::1]:8443/go",
+		"https://example.com/a%2Fb/":                        "https://example.com/a%2Fb",
+		"  https://example.com/go  ":                        "https://example.com/go",
+	}
+	for raw, want := range cases {
+		got, err := CanonicalizeURL(raw)
+		if err != nil {
+			t.Errorf("CanonicalizeURL(%q): %v", raw, err)
+			continue
+		}
+		if got != want {
+			t.Errorf("CanonicalizeURL(%q) = %q, want %q", raw, got, want)
+		}
+		// canonicalizing again does not change the url.
+		if again, _ := CanonicalizeURL(got); again != got {
+			t.Errorf("CanonicalizeURL(%q) = %q, it is not idempotent", got, again)
+		}
+	}
+}
+
+func TestCanonicalizeURLInvalid(t *testing.T) {
+	for _, raw := range []string{"/relative/path", "not a url", "https://example.com/%zz"} {
+		if u, err := CanonicalizeURL(raw); err == nil {
+			t.Errorf("CanonicalizeURL(%q) = %q, want an error", raw, u)
+		}
+	}
+}
+
+func TestAppendDataUniqueCanonical(t *testing.T) {
+	r := CreateResult("a", 1)
+	if !r.AppendDataUnique(&Data{Url: "https://example.com/go?utm_source=a"}) {
+		t.Fatal("the first data is not appended")
+	}
+	for _, u := range []string{"https://EXAMPLE.com/go/", "https://example.com:443/go#top", "https://example.com/go"} {
+		if r.AppendDataUnique(&Data{Url: u}) {
+			t.Errorf("the duplicate %s is appended", u)
+		}
+	}
+	if !r.AppendDataUnique(&Data{Url: "https://example.com/go?page=2"}) {
+		t.Error("the data of different query is not appended")
+	}
+	// the urls not canonicalizable are compared as they are.
+	if !r.AppendDataUnique(&Data{Url: "/go"}) || r.AppendDataUnique(&Data{Url: "/go"}) {
+		t.Error("the relative urls are not deduplicated as they are")
+	}
+	if n := r.GetDataSize(); n != 3 {
+		t.Errorf("count of data = %d, want 3", n)
+	}
+}
+
+func TestAbsolutizeURLs(t *testing.T) {
+	cases := map[string]struct {
+		base, url, want string
+	}{
+		"relative path":              {base: "https://www.bing.com", url: "/videos/watch?id=1", want: "https://www.bing.com/videos/watch?id=1"},
+		"relative to path":           {base: "https://example.com/search/", url: "page/2", want: "https://example.com/search/page/2"},
+		"protocol relative":          {base: "http://example.com", url: "//cdn.example.com/a.jpg", want: "http://cdn.example.com/a.jpg"},
+		"protocol relative, no base": {url: "//cdn.example.com/a.jpg", want: "https://cdn.example.com/a.jpg"},
+		"absolute":                   {base: "https://www.bing.com", url: "https://example.com/go", want: "https://example.com/go"},
+		"relative without base":      {url: "/go", want: "/go"},
+		"relative to invalid":        {base: "not a base", url: "/go", want: "/go"},
+		"empty":                      {base: "https://www.bing.com", url: "", want: ""},
+	}
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			r := CreateResult("a", 1)
+			r.AppendData(&Data{Url: c.url, Thumbnail: c.url, ImgSrc: c.url, PreviewUrl: c.url})
+			r.AbsolutizeURLs(c.base)
+
+			d := r.GetData()[0]
+			for field, got := range map[string]string{"url": d.Url, "thumbnail": d.Thumbnail, "img_src": d.ImgSrc, "preview_url": d.PreviewUrl} {
+				if got != c.want {
+					t.Errorf("%s = %q, want %q", field, got, c.want)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,127 @@
+package search
+
+import (
+	"math"
+	"slices"
+	"sync"
+	"time"
+)
+
+const (
+	defaultAdaptiveFactor     = 1.5
+	defaultAdaptiveWindow     = 100
+	defaultAdaptiveMinSamples = 10
+
+	// adaptivePercentile is the percentile of recent latencies an engine timeout is based on.
+	adaptivePercentile = 0.95
+)
+
+// AdaptiveTimeoutConfig is the configuration of timing out each engine by its recent latencies.
+// The timeout of an engine is the p95 of its recent latencies times factor, bounded by min and max,
+// so that the consistently fast engines fail fast and the slow but working ones get slack.
+type AdaptiveTimeoutConfig struct {
+	Enable bool `mapstructure:"enable"`
+
+	// Factor is the multiple of p95 latency of an engine its timeout is.
+	Factor float64 `mapstructure:"factor"`
+	// MinTimeout and MaxTimeout bound the timeout of an engine, 0 means unbounded.
+	MinTimeout time.Duration `mapstructure:"min_timeout"`
+	MaxTimeout time.Duration `mapstructure:"max_timeout"`
+	// Window is the count of recent latencies of each engine kept.
+	Window int `mapstructure:"window"`
+	// MinSamples is the count of latencies an engine requires before it is timed out adaptively.
+	MinSamples int `mapstructure:"min_samples"`
+}
+
+// latencyWindow keeps the recent latencies of each engine in a ring.
+type latencyWindow struct {
+	mu      sync.Mutex
+	size    int
+	samples map[string]*latencyRing
+}
+
+type latencyRing struct {
+	samples []time.Duration
+	next    int
+}
+
+var latencySamples = newLatencyWindow(defaultAdaptiveWindow)
+
+func newLatencyWindow(size int) *latencyWindow {
+	if size <= 0 {
+		size = defaultAdaptiveWindow
+	}
+	return &latencyWindow{size: size, samples: map[string]*latencyRing{}}
+}
+
+// reset drops the latencies, and keeps the size recent latencies of each engine from now on.
+// The window is reset in place, so that the searches in flight keep recording to it safely.
+func (w *latencyWindow) reset(size int) {
+	if size <= 0 {
+		size = defaultAdaptiveWindow
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.size = size
+	w.samples = map[string]*latencyRing{}
+}
+
+func (w *latencyWindow) record(name string, d time.Duration) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	r, ok := w.samples[name]
+	if !ok {
+		r = &latencyRing{samples: make([]time.Duration, 0, w.size)}
+		w.samples[name] = r
+	}
+	if len(r.samples) < w.size {
+		r.samples = append(r.samples, d)
+		return
+	}
+	r.samples[r.next] = d
+	r.next = (r.next + 1) % w.size
+}
+
+// percentile gets the percentile p of recent latencies of engine and the count of them,
+// false is returned if none is recorded.
+func (w *latencyWindow) percentile(name string, p float64) (time.Duration, int, bool) {
+	w.mu.Lock()
+	r, ok := w.samples[name]
+	if !ok || len(r.samples) == 0 {
+		w.mu.Unlock()
+		return 0, 0, false
+	}
+	sorted := slices.Clone(r.samples)
+	w.mu.Unlock()
+
+	slices.Sort(sorted)
+	// the nearest rank, e.g. p95 of 20 samples is the 19th.
+	rank := max(0, min(int(math.Ceil(float64(len(sorted))*p))-1, len(sorted)-1))
+	return sorted[rank], len(sorted), true
+}
+
+// adaptiveTimeout gets the timeout of engine by its recent latencies,
+// false is returned if the engine has fewer latencies than min samples.
+func adaptiveTimeout(c AdaptiveTimeoutConfig, w *latencyWindow, name string) (time.Duration, bool) {
+	if c.Factor <= 0 {
+		c.Factor = defaultAdaptiveFactor
+	}
+	if c.MinSamples <= 0 {
+		c.MinSamples = defaultAdaptiveMinSamples
+	}
+
+	p95, n, ok := w.percentile(name, adaptivePercentile)
+	if !ok || n < c.MinSamples {
+		return 0, false
+	}
+
+	timeout := time.Duration(float64(p95) * c.Factor)
+	if c.MinTimeout > 0 {
+		timeout = max(timeout, c.MinTimeout)
+	}
+	if c.MaxTimeout > 0 {
+		timeout = min(timeout, c.MaxTimeout)
+	}
+	return timeout, true
+}
@@ -0,0 +1,123 @@
+package search
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/zvirgilx/searxng-go/kernel/internal/engine"
+)
+
+func TestLatencyWindow(t *testing.T) {
+	w := newLatencyWindow(20)
+	if _, _, ok := w.percentile("a", adaptivePercentile); ok {
+		t.Error("the percentile of engine without latencies is got")
+	}
+
+	for i := 1; i <= 20; i++ {
+		w.record("a", time.Duration(i)*time.Millisecond)
+	}
+	// the nearest rank, p95 of 20 samples is the 19th.
+	if p95, n, ok := w.percentile("a", adaptivePercentile); !ok || n != 20 || p95 != 19*time.Millisecond {
+		t.Errorf("p95 = %v of %d samples, want 19ms of 20", p95, n)
+	}
+	if p50, _, _ := w.percentile("a", 0.5); p50 != 10*time.Millisecond {
+		t.Errorf("p50 = %v, want 10ms", p50)
+	}
+
+	// the oldest latencies are replaced once the window is full.
+	for i := 0; i < 10; i++ {
+		w.record("a", 100*time.Millisecond)
+	}
+	if p95, n, _ := w.percentile("a", adaptivePercentile); n != 20 || p95 != 100*time.Millisecond {
+		t.Errorf("p95 = %v of %d samples, want 100ms of 20", p95, n)
+	}
+	if p0, _, _ := w.percentile("a", 0); p0 != 11*time.Millisecond {
+		t.Errorf("minimum = %v, want 11ms after the oldest are replaced", p0)
+	}
+}
+
+func TestLatencyWindowReset(t *testing.T) {
+	w := newLatencyWindow(20)
+	w.record("a", time.Millisecond)
+
+	// the latencies are dropped, and the window keeps the new size.
+	w.reset(2)
+	if _, _, ok := w.percentile("a", adaptivePercentile); ok {
+		t.Error("the latencies are kept after reset")
+	}
+	for i := 1; i <= 3; i++ {
+		w.record("a", time.Duration(i)*time.Millisecond)
+	}
+	if p0, n, _ := w.percentile("a", 0); n != 2 || p0 != 2*time.Millisecond {
+		t.Errorf("minimum = %v of %d samples, want 2ms of 2", p0, n)
+	}
+}
+
+func TestAdaptiveTimeout(t *testing.T) {
+	w := newLatencyWindow(100)
+	for i := 0; i < 10; i++ {
+		w.record("a", 100*time.Millisecond)
+	}
+	w.record("few", 100*time.Millisecond)
+
+	cases := map[string]struct {
+		c    AdaptiveTimeoutConfig
+		name string
+		want time.Duration
+		ok   bool
+	}{
+		"default factor":   {name: "a", want: 150 * time.Millisecond, ok: true},
+		"factor":           {c: AdaptiveTimeoutConfig{Factor: 3}, name: "a", want: 300 * time.Millisecond, ok: true},
+		"bounded by min":   {c: AdaptiveTimeoutConfig{MinTimeout: time.Second}, name: "a", want: time.Second, ok: true},
+		"bounded by max":   {c: AdaptiveTimeoutConfig{MaxTimeout: 120 * time.Millisecond}, name: "a", want: 120 * time.Millisecond, ok: true},
+		"too few samples":  {name: "few"},
+		"min samples":      {c: AdaptiveTimeoutConfig{MinSamples: 1}, name: "few", want: 150 * time.Millisecond, ok: true},
+		"without samples":  {c: AdaptiveTimeoutConfig{MinSamples: 1}, name: "none"},
+		"min samples high": {c: AdaptiveTimeoutConfig{MinSamples: 11}, name: "a"},
+	}
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			got, ok := adaptiveTimeout(c.c, w, c.name)
+			if got != c.want || ok != c.ok {
+				t.Errorf("timeout = %v, %v, want %v, %v", got, ok, c.want, c.ok)
+			}
+		})
+	}
+}
+
+func TestSearchAdaptiveTimeout(t *testing.T) {
+	fast := &mockEngine{name: "adaptive_fast", urls: []string{"https://fast.example.com/1"}}
+	other := &mockEngine{name: "adaptive_other", urls: []string{"https://other.example.com/1"}, delay: 100 * time.Millisecond}
+	setupSearch(t, Config{Timeout: 5 * time.Second, AdaptiveTimeout: AdaptiveTimeoutConfig{Enable: true, MinSamples: 3, MinTimeout: 20 * time.Millisecond}},
+		map[string][]engine.Engine{engine.CategoryGeneral: {fast, other}})
+	for i := 0; i < 3; i++ {
+		latencySamples.record(fast.name, time.Millisecond)
+	}
+
+	// the engine timing out its recent latencies is cut, while the engine without enough samples waits for the global timeout.
+	fast.delay = 200 * time.Millisecond
+	res := Search(context.Background(), engine.Options{Query: "go", PageNo: 1, Category: engine.CategoryGeneral})
+	if urls := dataUrls(res); len(urls) != 1 || urls[0] != "https://other.example.com/1" {
+		t.Errorf("got %v, want the data of other engine only", urls)
+	}
+}
+
+func TestLatencySamplesOfUpstreamOnly(t *testing.T) {
+	e := &mockEngine{name: "adaptive_cached", urls: []string{"https://cached.example.com/1"}}
+	skipped := &mockEngine{name: "adaptive_skipped", urls: []string{"https://skipped.example.com/1"}}
+	setupSearch(t, Config{Cache: CacheConfig{TTL: time.Minute}}, map[string][]engine.Engine{engine.CategoryGeneral: {e, &pagelessEngine{skipped}}})
+
+	opts := engine.Options{Query: "go", PageNo: 2, Category: engine.CategoryGeneral}
+	for i := 0; i < 3; i++ {
+		Search(context.Background(), opts)
+	}
+	// the results served by the cache are not recorded.
+	if _, n, _ := latencySamples.percentile(e.name, adaptivePercentile); n != 1 {
+		t.Errorf("got %d latencies, want the one of upstream request", n)
+	}
+	// the engine skipped by its capabilities is not recorded.
+	if _, _, ok := latencySamples.percentile(skipped.name, adaptivePercentile); ok {
+		t.Error("the latency of skipped engine is recorded")
+	}
+}
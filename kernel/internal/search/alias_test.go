@@ -0,0 +1,36 @@
+package search
+
+import (
+	"context"
+	"testing"
+
+	"github.com/zvirgilx/searxng-go/kernel/internal/engine"
+)
+
+func TestSearchEnginesParam(t *testing.T) {
+	a := &mockEngine{name: "alias_a", urls: []string{"https://a.example.com/1"}}
+	b := &mockEngine{name: "alias_b", urls: []string{"https://b.example.com/1"}}
+	c := &mockEngine{name: "alias_c", urls: []string{"https://c.example.com/1"}}
+	setupSearch(t, Config{Aliases: map[string]string{"aa": "alias_a", "dd": "alias_d"}},
+		map[string][]engine.Engine{engine.CategoryGeneral: {a, b, c}})
+
+	opts, err := verifySearchOptions(queryParams(map[string]string{"q": "go", "engines": "aa, alias_b"}), "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(opts.Engines) != 2 || opts.Engines[0] != "alias_a" || opts.Engines[1] != "alias_b" {
+		t.Fatalf("engines = %v, the alias is not resolved", opts.Engines)
+	}
+
+	Search(context.Background(), opts)
+	if a.calls.Load() != 1 || b.calls.Load() != 1 || c.calls.Load() != 0 {
+		t.Errorf("calls = %d, %d, %d, want only the engines of param requested", a.calls.Load(), b.calls.Load(), c.calls.Load())
+	}
+
+	// the alias of engine not enabled and the unknown engine are rejected.
+	for _, engines := range []string{"dd", "alias_a,unknown"} {
+		if _, err := verifySearchOptions(queryParams(map[string]string{"q": "go", "engines": engines}), ""); err == nil {
+			t.Errorf("engines %q are not rejected", engines)
+		}
+	}
+}
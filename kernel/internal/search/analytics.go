@@ -0,0 +1,50 @@
+package search
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/zvirgilx/searxng-go/kernel/internal/analytics"
+	"github.com/zvirgilx/searxng-go/kernel/internal/engine"
+	"github.com/zvirgilx/searxng-go/kernel/internal/result"
+)
+
+// recordSearch searches with progress, and records the event of search with the results and durations of engines.
+func recordSearch(ctx context.Context, options engine.Options, progress Progress) *result.Result {
+	start := time.Now()
+
+	categories := options.Categories
+	if len(categories) == 0 {
+		categories = []string{options.Category}
+	}
+	e := analytics.SearchEvent{
+		Time:              start,
+		Query:             options.Query,
+		Categories:        categories,
+		PageNo:            options.PageNo,
+		EngineResults:     map[string]int{},
+		EngineDurationsMs: map[string]int64{},
+	}
+
+	// the progress is never called concurrently, so the event is not guarded.
+	res := searchWithProgress(ctx, options, func(name string, partial *result.Result, err error) {
+		if err == nil {
+			e.Engines = append(e.Engines, name)
+			e.EngineResults[name] += partial.GetDataSize()
+			e.EngineDurationsMs[name] = time.Since(start).Milliseconds()
+		} else if !errors.Is(err, context.DeadlineExceeded) && !errors.Is(err, context.Canceled) {
+			// the engines cut by the deadline are reported as timed out instead.
+			e.FailedEngines = append(e.FailedEngines, name)
+		}
+		if progress != nil {
+			progress(name, partial, err)
+		}
+	})
+
+	e.TimedOutEngines = res.TimedOutEngines
+	e.Results = res.GetDataSize()
+	e.DurationMs = time.Since(start).Milliseconds()
+	analytics.Record(e)
+	return res
+}
@@ -0,0 +1,66 @@
+package search
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/zvirgilx/searxng-go/kernel/internal/analytics"
+	"github.com/zvirgilx/searxng-go/kernel/internal/engine"
+)
+
+func TestSearchAnalytics(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "events.jsonl")
+	if err := analytics.InitConfig(analytics.Config{Sink: analytics.SinkJSONL, Path: path}); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { analytics.InitConfig(analytics.Config{}) })
+
+	ok := &mockEngine{name: "analytics_ok", urls: []string{"https://a.example.com/1", "https://a.example.com/2"}}
+	failed := &mockEngine{name: "analytics_failed", err: errors.New("upstream outage")}
+	slow := &mockEngine{name: "analytics_slow", delay: time.Second}
+	setupSearch(t, Config{}, map[string][]engine.Engine{engine.CategoryGeneral: {ok, failed, slow}})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	res := Search(ctx, engine.Options{Query: "go", PageNo: 1, Category: engine.CategoryGeneral})
+
+	// the event is recorded in the background.
+	var e analytics.SearchEvent
+	deadline := time.Now().Add(time.Second)
+	for {
+		b, _ := os.ReadFile(path)
+		if len(b) > 0 {
+			if err := json.Unmarshal(b, &e); err != nil {
+				t.Fatalf("%v: %s", err, b)
+			}
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("the event is not recorded")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	if e.Query != "go" || !slices.Equal(e.Categories, []string{engine.CategoryGeneral}) || e.PageNo != 1 {
+		t.Errorf("unexpected event %+v", e)
+	}
+	if !slices.Equal(e.Engines, []string{"analytics_ok"}) || e.EngineResults["analytics_ok"] != 2 {
+		t.Errorf("engines = %v, results = %v", e.Engines, e.EngineResults)
+	}
+	if _, ok := e.EngineDurationsMs["analytics_ok"]; !ok {
+		t.Errorf("the duration of engine is not recorded: %v", e.EngineDurationsMs)
+	}
+	// the engine cut by the deadline is timed out rather than failed.
+	if !slices.Equal(e.FailedEngines, []string{"analytics_failed"}) || !slices.Equal(e.TimedOutEngines, []string{"analytics_slow"}) {
+		t.Errorf("failed = %v, timed out = %v", e.FailedEngines, e.TimedOutEngines)
+	}
+	if e.Results != res.GetDataSize() || e.Results != 2 {
+		t.Errorf("results = %d, want %d", e.Results, res.GetDataSize())
+	}
+}
@@ -0,0 +1,138 @@
+package search
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/zvirgilx/searxng-go/kernel/internal/engine"
+	"github.com/zvirgilx/searxng-go/kernel/internal/metrics"
+	"github.com/zvirgilx/searxng-go/kernel/internal/result"
+)
+
+const (
+	defaultAutoDisableWindow    = 50
+	defaultAutoDisableErrorRate = 0.5
+)
+
+// AutoDisableConfig is the configuration of disabling the engines chronically failing,
+// an engine is disabled once its error rate over the last window of requests exceeds the threshold.
+type AutoDisableConfig struct {
+	Enable bool `mapstructure:"enable"`
+
+	// Window is the count of last requests of engine the error rate is computed over.
+	Window int `mapstructure:"window"`
+	// ErrorRate is the threshold of error rate, e.g. 0.5.
+	ErrorRate float64 `mapstructure:"error_rate"`
+	// ReenableAfter is the duration the engine is enabled again after, 0 means it is only enabled manually.
+	ReenableAfter time.Duration `mapstructure:"reenable_after"`
+}
+
+// AlertEvent is emitted once an engine is disabled by its error rate.
+type AlertEvent struct {
+	Engine    string
+	ErrorRate float64
+	Requests  int
+	// ReenableAt is the time the engine is enabled again, zero if it is only enabled manually.
+	ReenableAt time.Time
+}
+
+// alertHandler is called with the alert events, the events are logged by default.
+var alertHandler = func(e AlertEvent) {
+	slog.Warn("engine disabled by error rate",
+		slog.String("engine", e.Engine), slog.Float64("error_rate", e.ErrorRate),
+		slog.Int("requests", e.Requests), slog.Time("reenable_at", e.ReenableAt))
+}
+
+// SetAlertHandler sets the handler of alert events, e.g. notifying the operators.
+func SetAlertHandler(h func(AlertEvent)) {
+	alertHandler = h
+}
+
+// failureTracker keeps the outcomes of the last requests of each engine, and disables the engines failing too often.
+type failureTracker struct {
+	conf AutoDisableConfig
+
+	mu       sync.Mutex
+	outcomes map[string]*outcomeRing
+}
+
+type outcomeRing struct {
+	failed []bool
+	next   int
+	errors int
+}
+
+func newFailureTracker(c AutoDisableConfig) *failureTracker {
+	if c.Window <= 0 {
+		c.Window = defaultAutoDisableWindow
+	}
+	if c.ErrorRate <= 0 || c.ErrorRate > 1 {
+		c.ErrorRate = defaultAutoDisableErrorRate
+	}
+	return &failureTracker{conf: c, outcomes: map[string]*outcomeRing{}}
+}
+
+// record records the outcome of a request of engine, the engine is disabled and the alert is emitted
+// if the window is full and its error rate exceeds the threshold. It reports whether the engine is disabled.
+func (t *failureTracker) record(name string, failed bool) bool {
+	t.mu.Lock()
+	r, ok := t.outcomes[name]
+	if !ok {
+		r = &outcomeRing{failed: make([]bool, 0, t.conf.Window)}
+		t.outcomes[name] = r
+	}
+	if len(r.failed) < t.conf.Window {
+		r.failed = append(r.failed, failed)
+	} else {
+		if r.failed[r.next] {
+			r.errors--
+		}
+		r.failed[r.next] = failed
+		r.next = (r.next + 1) % t.conf.Window
+	}
+	if failed {
+		r.errors++
+	}
+
+	rate := float64(r.errors) / float64(len(r.failed))
+	trip := len(r.failed) == t.conf.Window && rate > t.conf.ErrorRate
+	if trip {
+		// the engine starts over once it is enabled again.
+		delete(t.outcomes, name)
+	}
+	t.mu.Unlock()
+
+	if trip {
+		t.disable(name, rate)
+	}
+	return trip
+}
+
+func (t *failureTracker) disable(name string, rate float64) {
+	engine.SetEnabled(name, false)
+	metrics.EnginesAutoDisabledCounter.WithLabelValues(name).Inc()
+
+	e := AlertEvent{Engine: name, ErrorRate: rate, Requests: t.conf.Window}
+	if t.conf.ReenableAfter > 0 {
+		e.ReenableAt = time.Now().Add(t.conf.ReenableAfter)
+		time.AfterFunc(t.conf.ReenableAfter, func() {
+			slog.Info("engine enabled again", slog.String("engine", name))
+			engine.SetEnabled(name, true)
+		})
+	}
+	alertHandler(e)
+}
+
+// middleware records the outcome of each request of engine, the searches canceled by the client are not counted.
+func (t *failureTracker) middleware(e engine.Engine, next engine.Handler) engine.Handler {
+	return func(ctx context.Context, opts *engine.Options) (res *result.Result, err error) {
+		res, err = next(ctx, opts)
+		if !errors.Is(err, context.Canceled) {
+			t.record(e.GetName(), err != nil)
+		}
+		return res, err
+	}
+}
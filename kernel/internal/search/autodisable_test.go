@@ -0,0 +1,144 @@
+package search
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/zvirgilx/searxng-go/kernel/internal/engine"
+)
+
+// captureAlerts sets the alert handler collecting the events, the handler and the engines are restored after the test.
+func captureAlerts(t *testing.T, names ...string) func() []AlertEvent {
+	t.Helper()
+	var (
+		mu     sync.Mutex
+		events []AlertEvent
+	)
+	old := alertHandler
+	SetAlertHandler(func(e AlertEvent) {
+		mu.Lock()
+		defer mu.Unlock()
+		events = append(events, e)
+	})
+	t.Cleanup(func() {
+		SetAlertHandler(old)
+		for _, name := range names {
+			engine.SetEnabled(name, true)
+		}
+	})
+	return func() []AlertEvent {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]AlertEvent{}, events...)
+	}
+}
+
+func TestFailureTracker(t *testing.T) {
+	cases := map[string]struct {
+		outcomes []bool
+		wantTrip int // wantTrip is the index of outcome tripping the engine, -1 if it is never tripped.
+	}{
+		"window not full":     {outcomes: []bool{true, true, true}, wantTrip: -1},
+		"error rate exceeded": {outcomes: []bool{true, false, true, true}, wantTrip: 3},
+		// the error rate must exceed the threshold rather than reach it.
+		"error rate reached": {outcomes: []bool{true, false, true, false}, wantTrip: -1},
+		// the oldest outcomes slide out of the window.
+		"sliding window": {outcomes: []bool{false, false, true, false, true, true, true}, wantTrip: 5},
+	}
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			alerts := captureAlerts(t, "tracker_engine")
+			tracker := newFailureTracker(AutoDisableConfig{Window: 4, ErrorRate: 0.5})
+
+			trip := -1
+			for i, failed := range c.outcomes {
+				if tracker.record("tracker_engine", failed) {
+					trip = i
+					break
+				}
+			}
+			if trip != c.wantTrip {
+				t.Fatalf("tripped at %d, want %d", trip, c.wantTrip)
+			}
+			if enabled := engine.IsEnabled("tracker_engine"); enabled != (c.wantTrip < 0) {
+				t.Errorf("enabled = %v", enabled)
+			}
+			if c.wantTrip < 0 {
+				if len(alerts()) != 0 {
+					t.Errorf("unexpected alerts %+v", alerts())
+				}
+				return
+			}
+			events := alerts()
+			if len(events) != 1 || events[0].Engine != "tracker_engine" || events[0].ErrorRate != 0.75 || events[0].Requests != 4 || !events[0].ReenableAt.IsZero() {
+				t.Errorf("unexpected alerts %+v", events)
+			}
+			// the engine starts over once it is tripped.
+			if tracker.record("tracker_engine", true) {
+				t.Error("the engine is tripped again before the window is full")
+			}
+		})
+	}
+}
+
+func TestFailureTrackerDefaults(t *testing.T) {
+	c := newFailureTracker(AutoDisableConfig{ErrorRate: 2}).conf
+	if c.Window != defaultAutoDisableWindow || c.ErrorRate != defaultAutoDisableErrorRate {
+		t.Errorf("unexpected config %+v", c)
+	}
+}
+
+func TestFailureTrackerReenable(t *testing.T) {
+	alerts := captureAlerts(t, "reenable_engine")
+	tracker := newFailureTracker(AutoDisableConfig{Window: 1, ErrorRate: 0.5, ReenableAfter: 30 * time.Millisecond})
+
+	before := time.Now()
+	if !tracker.record("reenable_engine", true) || engine.IsEnabled("reenable_engine") {
+		t.Fatal("the engine is not disabled")
+	}
+	if events := alerts(); len(events) != 1 || events[0].ReenableAt.Before(before.Add(30*time.Millisecond)) {
+		t.Errorf("unexpected alerts %+v", events)
+	}
+
+	deadline := time.Now().Add(time.Second)
+	for !engine.IsEnabled("reenable_engine") {
+		if time.Now().After(deadline) {
+			t.Fatal("the engine is not enabled again")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestSearchAutoDisable(t *testing.T) {
+	alerts := captureAlerts(t, "autodisable_failed", "autodisable_ok")
+	failed := &mockEngine{name: "autodisable_failed", err: errors.New("upstream outage")}
+	ok := &mockEngine{name: "autodisable_ok", urls: []string{"https://ok.example.com/1"}}
+	setupSearch(t, Config{AutoDisable: AutoDisableConfig{Enable: true, Window: 2, ErrorRate: 0.5}}, map[string][]engine.Engine{engine.CategoryGeneral: {failed, ok}})
+
+	opts := engine.Options{Query: "go", PageNo: 1, Category: engine.CategoryGeneral}
+	// the searches canceled by the client are not counted.
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	Search(ctx, opts)
+	if !engine.IsEnabled("autodisable_failed") || len(alerts()) != 0 {
+		t.Fatal("the canceled search is counted")
+	}
+
+	Search(context.Background(), opts)
+	Search(context.Background(), opts)
+	if engine.IsEnabled("autodisable_failed") || !engine.IsEnabled("autodisable_ok") {
+		t.Errorf("enabled = %v and %v", engine.IsEnabled("autodisable_failed"), engine.IsEnabled("autodisable_ok"))
+	}
+	if events := alerts(); len(events) != 1 || events[0].Engine != "autodisable_failed" {
+		t.Errorf("unexpected alerts %+v", events)
+	}
+
+	// the disabled engine is not requested any more.
+	calls := failed.calls.Load()
+	if res := Search(context.Background(), opts); res.GetDataSize() != 1 || failed.calls.Load() != calls {
+		t.Errorf("got %d data, the disabled engine is requested %d times", res.GetDataSize(), failed.calls.Load()-calls)
+	}
+}
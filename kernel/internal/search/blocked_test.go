@@ -0,0 +1,64 @@
+package search
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/zvirgilx/searxng-go/kernel/internal/engine"
+)
+
+// blockedEngine detects every response as its challenge page.
+type blockedEngine struct {
+	*mockEngine
+}
+
+func (e *blockedEngine) Blocked([]byte) bool { return true }
+
+// engineResponses gets the count of responses of engine with status observed by the metrics middleware.
+func engineResponses(t *testing.T, name, status string) uint64 {
+	t.Helper()
+	families, err := prometheus.DefaultGatherer.Gather()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, f := range families {
+		if f.GetName() != "engines_response_total" {
+			continue
+		}
+		for _, m := range f.GetMetric() {
+			labels := map[string]string{}
+			for _, l := range m.GetLabel() {
+				labels[l.GetName()] = l.GetValue()
+			}
+			if labels["engine"] == name && labels["status"] == status {
+				return m.GetHistogram().GetSampleCount()
+			}
+		}
+	}
+	return 0
+}
+
+func TestSearchBlocked(t *testing.T) {
+	blocked := &blockedEngine{&mockEngine{name: "blocked_engine", urls: []string{"https://blocked.example.com/1"}}}
+	a := &mockEngine{name: "blocked_a", urls: []string{"https://a.example.com/1"}}
+	setupSearch(t, Config{}, map[string][]engine.Engine{engine.CategoryGeneral: {blocked, a}})
+	// the metrics are global, so the responses of this search are counted by the delta.
+	blockedBefore, okBefore := engineResponses(t, "blocked_engine", "blocked"), engineResponses(t, "blocked_a", "ok")
+
+	res := Search(context.Background(), engine.Options{Query: "go", PageNo: 1, Category: engine.CategoryGeneral})
+	if urls := dataUrls(res); len(urls) != 1 || urls[0] != "https://a.example.com/1" {
+		t.Errorf("got %v, want the results of blocked engine dropped", urls)
+	}
+	if n := engineResponses(t, "blocked_engine", "blocked") - blockedBefore; n != 1 {
+		t.Errorf("got %d blocked responses, want 1", n)
+	}
+	if n := engineResponses(t, "blocked_a", "ok") - okBefore; n != 1 {
+		t.Errorf("got %d ok responses, want 1", n)
+	}
+
+	if _, err := SearchEngine(context.Background(), engine.Options{Query: "go", PageNo: 1}, blocked); !errors.Is(err, engine.ErrBlocked) {
+		t.Errorf("err = %v, want blocked", err)
+	}
+}
@@ -0,0 +1,93 @@
+package search
+
+import (
+	"slices"
+	"sync"
+	"time"
+)
+
+const (
+	defaultBudgetSlowFactor = 2
+	defaultBudgetSlowRatio  = 0.5
+
+	// latencyWeight is the weight of the latest latency in the moving average of engine latency.
+	latencyWeight = 0.3
+)
+
+// BudgetConfig is the configuration of allocating the deadline of a search among engines.
+// The engines historically much slower than the others get a shorter timeout,
+// so that the search is not held until the deadline by engines that rarely make it.
+type BudgetConfig struct {
+	Enable bool `mapstructure:"enable"`
+
+	// SlowFactor is the multiple of median latency of engines, the engines slower than it are slow.
+	SlowFactor float64 `mapstructure:"slow_factor"`
+	// SlowRatio is the ratio of remaining deadline the slow engines get.
+	SlowRatio float64 `mapstructure:"slow_ratio"`
+	// MinTimeout is the minimum timeout of an engine.
+	MinTimeout time.Duration `mapstructure:"min_timeout"`
+}
+
+// latencyRecorder records the moving average of latency of each engine.
+type latencyRecorder struct {
+	mu        sync.Mutex
+	latencies map[string]time.Duration
+}
+
+var latencies = &latencyRecorder{latencies: map[string]time.Duration{}}
+
+func (r *latencyRecorder) record(name string, d time.Duration) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if old, ok := r.latencies[name]; ok {
+		d = time.Duration(latencyWeight*float64(d) + (1-latencyWeight)*float64(old))
+	}
+	r.latencies[name] = d
+}
+
+func (r *latencyRecorder) get(name string) (time.Duration, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	d, ok := r.latencies[name]
+	return d, ok
+}
+
+// allocateBudget gets the timeout of each engine within the remaining deadline.
+// The engines without recorded latency, or not slower than slow factor times the median, get all the remaining,
+// the slow engines get slow ratio of it, but not less than min timeout.
+func allocateBudget(c BudgetConfig, remaining time.Duration, names []string, latencyOf func(string) (time.Duration, bool)) map[string]time.Duration {
+	if c.SlowFactor <= 0 {
+		c.SlowFactor = defaultBudgetSlowFactor
+	}
+	if c.SlowRatio <= 0 || c.SlowRatio > 1 {
+		c.SlowRatio = defaultBudgetSlowRatio
+	}
+
+	recorded := map[string]time.Duration{}
+	var sorted []time.Duration
+	for _, name := range names {
+		if d, ok := latencyOf(name); ok {
+			recorded[name] = d
+			sorted = append(sorted, d)
+		}
+	}
+
+	var median time.Duration
+	if len(sorted) > 0 {
+		slices.Sort(sorted)
+		median = sorted[(len(sorted)-1)/2]
+	}
+
+	slow := max(time.Duration(float64(remaining)*c.SlowRatio), min(c.MinTimeout, remaining))
+	timeouts := make(map[string]time.Duration, len(names))
+	for _, name := range names {
+		timeouts[name] = remaining
+		// a single recorded engine is not compared with itself.
+		if d, ok := recorded[name]; ok && len(sorted) > 1 && float64(d) > c.SlowFactor*float64(median) {
+			timeouts[name] = slow
+		}
+	}
+	return timeouts
+}
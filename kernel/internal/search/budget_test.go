@@ -0,0 +1,117 @@
+package search
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/zvirgilx/searxng-go/kernel/internal/engine"
+)
+
+func TestAllocateBudget(t *testing.T) {
+	recorded := map[string]time.Duration{
+		"fast":   100 * time.Millisecond,
+		"medium": 150 * time.Millisecond,
+		"slow":   time.Second,
+	}
+	latencyOf := func(name string) (time.Duration, bool) {
+		d, ok := recorded[name]
+		return d, ok
+	}
+
+	cases := map[string]struct {
+		c     BudgetConfig
+		names []string
+		want  map[string]time.Duration
+	}{
+		"slow engine": {
+			names: []string{"fast", "medium", "slow", "new"},
+			want:  map[string]time.Duration{"fast": 2 * time.Second, "medium": 2 * time.Second, "slow": time.Second, "new": 2 * time.Second},
+		},
+		"slow ratio and factor": {
+			c:     BudgetConfig{SlowFactor: 6, SlowRatio: 0.25},
+			names: []string{"fast", "medium", "slow"},
+			want:  map[string]time.Duration{"fast": 2 * time.Second, "medium": 2 * time.Second, "slow": 500 * time.Millisecond},
+		},
+		"min timeout": {
+			c:     BudgetConfig{SlowRatio: 0.1, MinTimeout: 800 * time.Millisecond},
+			names: []string{"fast", "medium", "slow"},
+			want:  map[string]time.Duration{"fast": 2 * time.Second, "medium": 2 * time.Second, "slow": 800 * time.Millisecond},
+		},
+		"single recorded engine": {
+			names: []string{"slow", "new"},
+			want:  map[string]time.Duration{"slow": 2 * time.Second, "new": 2 * time.Second},
+		},
+	}
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := allocateBudget(c.c, 2*time.Second, c.names, latencyOf)
+			if len(got) != len(c.want) {
+				t.Fatalf("got %v, want %v", got, c.want)
+			}
+			for n, d := range c.want {
+				if got[n] != d {
+					t.Errorf("timeout of %s = %v, want %v", n, got[n], d)
+				}
+			}
+		})
+	}
+}
+
+func TestLatencyRecorder(t *testing.T) {
+	r := &latencyRecorder{latencies: map[string]time.Duration{}}
+	if _, ok := r.get("bing"); ok {
+		t.Error("the latency of engine not recorded is got")
+	}
+	r.record("bing", 100*time.Millisecond)
+	r.record("bing", 200*time.Millisecond)
+	// the moving average weights the latest latency by 0.3.
+	if d, _ := r.get("bing"); d != 130*time.Millisecond {
+		t.Errorf("latency = %v, want 130ms", d)
+	}
+}
+
+func TestSearchBudget(t *testing.T) {
+	fast := &mockEngine{name: "budget_fast", urls: []string{"https://fast.example.com/1"}}
+	alsoFast := &mockEngine{name: "budget_also_fast", urls: []string{"https://also-fast.example.com/1"}}
+	slow := &mockEngine{name: "budget_slow", urls: []string{"https://slow.example.com/1"}, delay: 5 * time.Second}
+	setupSearch(t, Config{Budget: BudgetConfig{Enable: true, SlowRatio: 0.2}}, map[string][]engine.Engine{engine.CategoryGeneral: {fast, alsoFast, slow}})
+	latencies.record("budget_fast", 10*time.Millisecond)
+	latencies.record("budget_also_fast", 10*time.Millisecond)
+	latencies.record("budget_slow", time.Second)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	// the historically slow engine gets 20% of the deadline, the search is not held by it until the deadline.
+	start := time.Now()
+	res := Search(ctx, engine.Options{Query: "go", PageNo: 1, Category: engine.CategoryGeneral})
+	if elapsed := time.Since(start); elapsed > 600*time.Millisecond {
+		t.Errorf("search waited %v for the slow engine", elapsed)
+	}
+	if res.GetDataSize() != 2 {
+		t.Errorf("got %v", dataUrls(res))
+	}
+	waitLatencyRecorded(t, "budget_slow")
+}
+
+func TestSearchLatencyOfCacheHits(t *testing.T) {
+	a := &mockEngine{name: "latency_cached", urls: []string{"https://a.example.com/1"}, delay: 50 * time.Millisecond}
+	b := &mockEngine{name: "latency_cached_b", urls: []string{"https://b.example.com/1"}}
+	setupSearch(t, Config{Cache: CacheConfig{TTL: time.Minute}}, map[string][]engine.Engine{engine.CategoryGeneral: {a, b}})
+
+	opts := engine.Options{Query: "go", PageNo: 1, Category: engine.CategoryGeneral}
+	Search(context.Background(), opts)
+	fetched, ok := latencies.get("latency_cached")
+	if !ok || fetched < 50*time.Millisecond {
+		t.Fatalf("the latency of fetch = %v, recorded %v", fetched, ok)
+	}
+
+	// the results served by the cache are not the latency of engine.
+	for i := 0; i < 3; i++ {
+		Search(context.Background(), opts)
+	}
+	if d, _ := latencies.get("latency_cached"); d != fetched {
+		t.Errorf("latency = %v after cache hits, want %v of the fetch", d, fetched)
+	}
+}
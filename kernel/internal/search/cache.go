@@ -0,0 +1,147 @@
+package search
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/zvirgilx/searxng-go/kernel/internal/engine"
+	"github.com/zvirgilx/searxng-go/kernel/internal/result"
+)
+
+type CacheConfig struct {
+	// TTL is the expiration of cached results of engines, 0 disables the cache.
+	TTL time.Duration `mapstructure:"ttl"`
+	// MaxEntries is the maximum of cached results, expired entries are evicted once it is reached.
+	MaxEntries int `mapstructure:"max_entries"`
+	// StaleTTL keeps the expired results for it after TTL, they are served only if all engines of a search failed,
+	// e.g. during an upstream outage. 0 disables it.
+	StaleTTL time.Duration `mapstructure:"stale_ttl"`
+}
+
+type cacheEntry struct {
+	res      *result.Result
+	cachedAt time.Time
+}
+
+// resultCache caches the results of engines in memory.
+type resultCache struct {
+	conf CacheConfig
+
+	mu      sync.Mutex
+	entries map[string]cacheEntry
+}
+
+func newResultCache(c CacheConfig) *resultCache {
+	return &resultCache{conf: c, entries: map[string]cacheEntry{}}
+}
+
+// cacheKey is the key of the result of engine searched with options.
+func cacheKey(e engine.Engine, opts *engine.Options) string {
+	return fmt.Sprintf("%s|%s|%s|%d|%s|%s|%d|%d|%t|%s", e.GetName(), opts.Category, opts.Query, opts.PageNo,
+		opts.Locale, opts.TimeRange, opts.SafeSearch, opts.MaxResultsPerEngine, opts.Verbatim, opts.ThumbnailSize)
+}
+
+func (c *resultCache) get(key string, maxAge time.Duration) (cacheEntry, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, ok := c.entries[key]
+	if !ok {
+		return cacheEntry{}, false
+	}
+	age := time.Since(entry.cachedAt)
+	if age > c.conf.TTL {
+		// the expired entry is kept for the fallback of failed searches.
+		if age > c.conf.TTL+c.conf.StaleTTL {
+			delete(c.entries, key)
+		}
+		return cacheEntry{}, false
+	}
+	// the entry older than max age is refetched.
+	if maxAge > 0 && age > maxAge {
+		return cacheEntry{}, false
+	}
+	return entry, true
+}
+
+// stale gets the entry of key expired no longer than the stale ttl, the fresh entry is got as well.
+func (c *resultCache) stale(key string) (cacheEntry, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, ok := c.entries[key]
+	if !ok || time.Since(entry.cachedAt) > c.conf.TTL+c.conf.StaleTTL {
+		return cacheEntry{}, false
+	}
+	return entry, true
+}
+
+// staleResult merges the stale results of engines searched with options, nil is returned if none is cached.
+// The results keep the order of engines if keepOrder, e.g. the search of a single engine.
+func (c *resultCache) staleResult(options engine.Options, enableEngines []categoryEngine, keepOrder bool) *result.Result {
+	var res *result.Result
+	for _, ce := range enableEngines {
+		// the key is the one of options each engine is processed with.
+		opts := options
+		opts.Category = ce.category
+		entry, ok := c.stale(cacheKey(ce.engine, &opts))
+		if !ok {
+			continue
+		}
+
+		if res == nil {
+			res = result.CreateResult("", options.PageNo)
+		}
+		r := entry.res.Clone()
+		r.KeepOrder = keepOrder
+		r.Cached = true
+		r.CachedAt = entry.cachedAt
+		res.Merge(r)
+	}
+	if res != nil {
+		res.Stale = true
+		res.KeepOrder = keepOrder
+	}
+	return res
+}
+
+func (c *resultCache) set(key string, res *result.Result) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.conf.MaxEntries > 0 && len(c.entries) >= c.conf.MaxEntries {
+		for k, entry := range c.entries {
+			if time.Since(entry.cachedAt) > c.conf.TTL {
+				delete(c.entries, k)
+			}
+		}
+		// the cache is still full of fresh entries, the result is not cached.
+		if len(c.entries) >= c.conf.MaxEntries {
+			return
+		}
+	}
+	c.entries[key] = cacheEntry{res: res, cachedAt: time.Now()}
+}
+
+// middleware serves the results of engines from cache, and caches the results fetched.
+// A copy of cached result is returned, so that the cached data are not changed by the search.
+func (c *resultCache) middleware(e engine.Engine, next engine.Handler) engine.Handler {
+	return func(ctx context.Context, opts *engine.Options) (*result.Result, error) {
+		key := cacheKey(e, opts)
+		if entry, ok := c.get(key, opts.MaxAge); ok {
+			res := entry.res.Clone()
+			res.Cached = true
+			res.CachedAt = entry.cachedAt
+			return res, nil
+		}
+
+		res, err := next(ctx, opts)
+		if err != nil || res == nil {
+			return res, err
+		}
+		c.set(key, res.Clone())
+		return res, nil
+	}
+}
@@ -0,0 +1,175 @@
+package search
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/zvirgilx/searxng-go/kernel/internal/engine"
+	"github.com/zvirgilx/searxng-go/kernel/internal/result"
+)
+
+func TestSearchCache(t *testing.T) {
+	a := &mockEngine{name: "a", urls: []string{"https://a.example.com/1"}}
+	b := &mockEngine{name: "b", urls: []string{"https://b.example.com/1"}}
+	setupSearch(t, Config{Cache: CacheConfig{TTL: time.Minute}}, map[string][]engine.Engine{engine.CategoryGeneral: {a, b}})
+
+	opts := engine.Options{Query: "go", PageNo: 1, Category: engine.CategoryGeneral}
+	first := Search(context.Background(), opts)
+	if first.Cached {
+		t.Error("the fetched results are cached")
+	}
+
+	second := Search(context.Background(), opts)
+	if a.calls.Load() != 1 || b.calls.Load() != 1 {
+		t.Errorf("the engines are requested %d and %d times, want once", a.calls.Load(), b.calls.Load())
+	}
+	if !second.Cached || second.CachedAt.IsZero() || time.Since(second.CachedAt) > time.Minute {
+		t.Errorf("cached = %v at %v", second.Cached, second.CachedAt)
+	}
+	if second.GetDataSize() != 2 {
+		t.Errorf("count of cached data = %d, want 2", second.GetDataSize())
+	}
+
+	// the options of a different search are not served from cache.
+	opts.PageNo = 2
+	Search(context.Background(), opts)
+	if a.calls.Load() != 2 {
+		t.Errorf("the engine is requested %d times for another page, want 2", a.calls.Load())
+	}
+}
+
+func TestSearchCacheMaxAge(t *testing.T) {
+	a := &mockEngine{name: "a", urls: []string{"https://a.example.com/1"}}
+	b := &mockEngine{name: "b", urls: []string{"https://b.example.com/1"}}
+	setupSearch(t, Config{Cache: CacheConfig{TTL: time.Minute}}, map[string][]engine.Engine{engine.CategoryGeneral: {a, b}})
+
+	opts := engine.Options{Query: "go", PageNo: 1, Category: engine.CategoryGeneral}
+	Search(context.Background(), opts)
+	time.Sleep(20 * time.Millisecond)
+
+	// the results younger than max age are served from cache.
+	opts.MaxAge = time.Minute
+	if res := Search(context.Background(), opts); !res.Cached || a.calls.Load() != 1 {
+		t.Errorf("cached = %v, the engine is requested %d times", res.Cached, a.calls.Load())
+	}
+
+	// the results older than max age are refetched, and the cache is refreshed.
+	opts.MaxAge = 10 * time.Millisecond
+	if res := Search(context.Background(), opts); res.Cached || a.calls.Load() != 2 {
+		t.Errorf("cached = %v, the engine is requested %d times", res.Cached, a.calls.Load())
+	}
+	opts.MaxAge = 0
+	if res := Search(context.Background(), opts); !res.Cached || a.calls.Load() != 2 {
+		t.Errorf("cached = %v, the engine is requested %d times", res.Cached, a.calls.Load())
+	}
+}
+
+func TestResultCacheExpiration(t *testing.T) {
+	c := newResultCache(CacheConfig{TTL: 10 * time.Millisecond, MaxEntries: 2})
+	res := result.CreateResult("a", 1)
+
+	c.set("a", res)
+	c.set("b", res)
+	// the cache is full of fresh entries, so c is not cached.
+	c.set("c", res)
+	if _, ok := c.get("c", 0); ok {
+		t.Error("the entry beyond max entries is cached")
+	}
+	if _, ok := c.get("a", 0); !ok {
+		t.Error("the fresh entry is not got")
+	}
+
+	time.Sleep(20 * time.Millisecond)
+	if _, ok := c.get("a", 0); ok {
+		t.Error("the expired entry is got")
+	}
+	// the expired entries are evicted for the new one.
+	c.set("c", res)
+	if _, ok := c.get("c", 0); !ok {
+		t.Error("the entry is not cached after the expired ones are evicted")
+	}
+}
+
+func TestVerifySearchOptionsMaxAge(t *testing.T) {
+	setupSearch(t, Config{}, nil)
+
+	opts, err := verifySearchOptions(queryParams(map[string]string{"q": "go", "max_age": "10m"}), "")
+	if err != nil || opts.MaxAge != 10*time.Minute {
+		t.Errorf("max age = %v, err %v", opts.MaxAge, err)
+	}
+	for _, ma := range []string{"ten minutes", "-1m"} {
+		if _, err := verifySearchOptions(queryParams(map[string]string{"q": "go", "max_age": ma}), ""); err == nil {
+			t.Errorf("max age %q is accepted", ma)
+		}
+	}
+}
+
+func TestSearchStaleCache(t *testing.T) {
+	cases := map[string]struct {
+		failed    []string
+		staleTTL  time.Duration
+		wantStale bool
+		want      int
+	}{
+		"all failed":         {failed: []string{"stale_a", "stale_b"}, staleTTL: time.Minute, wantStale: true, want: 2},
+		"some succeeded":     {failed: []string{"stale_a"}, staleTTL: time.Minute, want: 1},
+		"beyond stale ttl":   {failed: []string{"stale_a", "stale_b"}, staleTTL: time.Millisecond},
+		"stale ttl disabled": {failed: []string{"stale_a", "stale_b"}},
+	}
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			a := &mockEngine{name: "stale_a", urls: []string{"https://a.example.com/1"}}
+			b := &mockEngine{name: "stale_b", urls: []string{"https://b.example.com/1"}}
+			setupSearch(t, Config{Cache: CacheConfig{TTL: 20 * time.Millisecond, StaleTTL: c.staleTTL}}, map[string][]engine.Engine{engine.CategoryGeneral: {a, b}})
+
+			opts := engine.Options{Query: "go", PageNo: 1, Category: engine.CategoryGeneral}
+			Search(context.Background(), opts)
+			time.Sleep(30 * time.Millisecond)
+
+			for _, e := range []*mockEngine{a, b} {
+				for _, failed := range c.failed {
+					if e.name == failed {
+						e.err = errors.New("upstream outage")
+					}
+				}
+			}
+			res := Search(context.Background(), opts)
+			if res.Stale != c.wantStale || res.Cached != c.wantStale {
+				t.Errorf("stale = %v, cached = %v, want %v", res.Stale, res.Cached, c.wantStale)
+			}
+			if n := res.GetDataSize(); n != c.want {
+				t.Errorf("got %d data, want %d", n, c.want)
+			}
+			// the expired results are refetched rather than served from cache.
+			if a.calls.Load() != 2 || b.calls.Load() != 2 {
+				t.Errorf("the engines are requested %d and %d times, want twice", a.calls.Load(), b.calls.Load())
+			}
+		})
+	}
+}
+
+func TestResultCacheStale(t *testing.T) {
+	c := newResultCache(CacheConfig{TTL: 10 * time.Millisecond, StaleTTL: 30 * time.Millisecond, MaxEntries: 2})
+	c.set("a", result.CreateResult("a", 1))
+
+	// the fresh entry is got as the stale one as well.
+	if _, ok := c.stale("a"); !ok {
+		t.Error("the fresh entry is not got as stale")
+	}
+
+	time.Sleep(20 * time.Millisecond)
+	if _, ok := c.get("a", 0); ok {
+		t.Error("the expired entry is got")
+	}
+	// the expired entry is kept for the stale ttl.
+	if _, ok := c.stale("a"); !ok {
+		t.Error("the expired entry is not kept for the stale ttl")
+	}
+
+	time.Sleep(30 * time.Millisecond)
+	if _, ok := c.stale("a"); ok {
+		t.Error("the entry beyond the stale ttl is got")
+	}
+}
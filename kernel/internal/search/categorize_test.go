@@ -0,0 +1,53 @@
+package search
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/zvirgilx/searxng-go/kernel/internal/engine"
+)
+
+func TestSuggestCategories(t *testing.T) {
+	web := &mockEngine{name: "suggest_web"}
+	repos := &mockEngine{name: "suggest_repos"}
+
+	cases := map[string]struct {
+		engines map[string][]engine.Engine
+		query   map[string]string
+		want    []string
+	}{
+		"suggested": {
+			engines: map[string][]engine.Engine{engine.CategoryGeneral: {web}, engine.CategoryIT: {repos}},
+			query:   map[string]string{"q": "github.com/spf13/cobra"},
+			want:    []string{engine.CategoryIT, engine.CategoryGeneral},
+		},
+		"category without engines dropped": {
+			engines: map[string][]engine.Engine{engine.CategoryGeneral: {web}},
+			query:   map[string]string{"q": "github.com/spf13/cobra"},
+			want:    []string{engine.CategoryGeneral},
+		},
+		"category specified": {
+			engines: map[string][]engine.Engine{engine.CategoryGeneral: {web}, engine.CategoryIT: {repos}},
+			query:   map[string]string{"q": "github.com/spf13/cobra", "category": engine.CategoryGeneral},
+			want:    []string{engine.CategoryGeneral},
+		},
+		"no suggestion": {
+			engines: map[string][]engine.Engine{engine.CategoryGeneral: {web}, engine.CategoryIT: {repos}},
+			query:   map[string]string{"q": "best pizza"},
+			want:    []string{engine.CategoryGeneral},
+		},
+	}
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			setupSearch(t, Config{AutodetectCategories: true}, c.engines)
+
+			opts, err := verifySearchOptions(queryParams(c.query), "")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !slices.Equal(opts.Categories, c.want) || opts.Category != c.want[0] {
+				t.Errorf("categories = %v, category = %q, want %v", opts.Categories, opts.Category, c.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,108 @@
+package search
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"github.com/zvirgilx/searxng-go/kernel/internal/engine"
+)
+
+func TestSearchCategories(t *testing.T) {
+	web := &mockEngine{name: "web", urls: []string{"https://web.example.com/a"}}
+	news := &mockEngine{name: "news", urls: []string{"https://news.example.com/a"}}
+	both := &mockEngine{name: "both", urls: []string{"https://both.example.com/a"}}
+	images := &mockEngine{name: "images", urls: []string{"https://images.example.com/a"}}
+	setupSearch(t, Config{}, map[string][]engine.Engine{
+		engine.CategoryGeneral: {web, both},
+		engine.CategoryNews:    {news, both},
+		engine.CategoryImage:   {images},
+	})
+
+	res := Search(context.Background(), engine.Options{
+		Query:      "go",
+		PageNo:     1,
+		Categories: []string{engine.CategoryGeneral, engine.CategoryNews},
+	})
+
+	categories := map[string]string{}
+	for _, d := range res.GetData() {
+		categories[d.Engine] = d.Category
+	}
+	want := map[string]string{"web": engine.CategoryGeneral, "news": engine.CategoryNews, "both": engine.CategoryGeneral}
+	if len(categories) != len(want) {
+		t.Fatalf("got data of engines %v, want %v", categories, want)
+	}
+	for name, category := range want {
+		if categories[name] != category {
+			t.Errorf("category of %s = %q, want %q", name, categories[name], category)
+		}
+	}
+
+	// the engine of both categories is searched only once, for the first category.
+	if n := both.calls.Load(); n != 1 {
+		t.Errorf("engine of both categories is searched %d times", n)
+	}
+	if n := images.calls.Load(); n != 0 {
+		t.Errorf("engine of other category is searched %d times", n)
+	}
+}
+
+func TestSearchCategory(t *testing.T) {
+	web := &mockEngine{name: "web", urls: []string{"https://web.example.com/a"}}
+	news := &mockEngine{name: "news", urls: []string{"https://news.example.com/a"}}
+	setupSearch(t, Config{}, map[string][]engine.Engine{
+		engine.CategoryGeneral: {web},
+		engine.CategoryNews:    {news},
+	})
+
+	// the single category is searched without categories.
+	res := Search(context.Background(), engine.Options{Query: "go", PageNo: 1, Category: engine.CategoryNews})
+	if urls := dataUrls(res); !slices.Equal(urls, []string{"https://news.example.com/a"}) {
+		t.Errorf("got %v", urls)
+	}
+	if c := res.GetData()[0].Category; c != engine.CategoryNews {
+		t.Errorf("category = %q, want news", c)
+	}
+}
+
+func TestVerifySearchOptionsLists(t *testing.T) {
+	web := &mockEngine{name: "list_web", urls: []string{"https://web.example.com/a"}}
+	news := &mockEngine{name: "list_news", urls: []string{"https://news.example.com/a"}}
+	setupSearch(t, Config{Aliases: map[string]string{"lw": "list_web"}}, map[string][]engine.Engine{
+		engine.CategoryGeneral: {web},
+		engine.CategoryNews:    {news},
+	})
+
+	// the items of lists are trimmed, and the empty and repeated ones are dropped.
+	cases := map[string]struct {
+		params         map[string]string
+		categories     []string
+		engines        []string
+		tags, excludes []string
+	}{
+		"categories":       {params: map[string]string{"categories": " news, ,general,news,"}, categories: []string{"news", "general"}},
+		"empty categories": {params: map[string]string{"categories": " , "}, categories: []string{engine.CategoryGeneral}},
+		"engines":          {params: map[string]string{"engines": "list_web, lw,,list_news "}, categories: []string{engine.CategoryGeneral}, engines: []string{"list_web", "list_news"}},
+		"tags": {params: map[string]string{"tags": "video, ,verified,video", "exclude_tags": " nsfw,nsfw "},
+			categories: []string{engine.CategoryGeneral}, tags: []string{"video", "verified"}, excludes: []string{"nsfw"}},
+	}
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			c.params["q"] = "go"
+			opts, err := verifySearchOptions(queryParams(c.params), "")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !slices.Equal(opts.Categories, c.categories) || opts.Category != c.categories[0] {
+				t.Errorf("categories = %v, category = %q, want %v", opts.Categories, opts.Category, c.categories)
+			}
+			if !slices.Equal(opts.Engines, c.engines) {
+				t.Errorf("engines = %v, want %v", opts.Engines, c.engines)
+			}
+			if !slices.Equal(opts.Tags, c.tags) || !slices.Equal(opts.ExcludeTags, c.excludes) {
+				t.Errorf("tags = %v, exclude tags = %v, want %v, %v", opts.Tags, opts.ExcludeTags, c.tags, c.excludes)
+			}
+		})
+	}
+}
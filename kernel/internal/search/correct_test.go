@@ -0,0 +1,51 @@
+package search
+
+import (
+	"context"
+	"testing"
+
+	"github.com/zvirgilx/searxng-go/kernel/internal/engine"
+)
+
+func TestSearchAutoCorrect(t *testing.T) {
+	a := &mockEngine{name: "a", urls: []string{"https://a.example.com/1"}, corrections: []string{"golang"}}
+	b := &mockEngine{name: "b"}
+	setupSearch(t, Config{AutoCorrectMinResults: 3}, map[string][]engine.Engine{engine.CategoryGeneral: {a, b}})
+
+	res := Search(context.Background(), engine.Options{Query: "golnag", PageNo: 1, Category: engine.CategoryGeneral, AutoCorrect: true})
+	if res.CorrectedQuery != "golang" {
+		t.Errorf("corrected query = %q, want golang", res.CorrectedQuery)
+	}
+	// the rerun corrects only once, even though the correction is returned again.
+	if a.calls.Load() != 2 || b.calls.Load() != 2 {
+		t.Errorf("the engines are requested %d and %d times, want 2", a.calls.Load(), b.calls.Load())
+	}
+	if q := a.lastOptions().Query; q != "golang" {
+		t.Errorf("the rerun query = %q, want golang", q)
+	}
+}
+
+func TestSearchAutoCorrectSkipped(t *testing.T) {
+	cases := map[string]struct {
+		minResults int
+		opts       engine.Options
+	}{
+		"disabled":       {minResults: 3, opts: engine.Options{}},
+		"verbatim":       {minResults: 3, opts: engine.Options{AutoCorrect: true, Verbatim: true}},
+		"enough results": {minResults: 1, opts: engine.Options{AutoCorrect: true}},
+	}
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			a := &mockEngine{name: "a", urls: []string{"https://a.example.com/1"}, corrections: []string{"golang"}}
+			b := &mockEngine{name: "b"}
+			setupSearch(t, Config{AutoCorrectMinResults: c.minResults}, map[string][]engine.Engine{engine.CategoryGeneral: {a, b}})
+
+			opts := c.opts
+			opts.Query, opts.PageNo, opts.Category = "golnag", 1, engine.CategoryGeneral
+			res := Search(context.Background(), opts)
+			if res.CorrectedQuery != "" || a.calls.Load() != 1 {
+				t.Errorf("the search is rerun with %q, the engine is requested %d times", res.CorrectedQuery, a.calls.Load())
+			}
+		})
+	}
+}
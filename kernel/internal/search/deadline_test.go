@@ -0,0 +1,50 @@
+package search
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/zvirgilx/searxng-go/kernel/internal/engine"
+)
+
+func TestSearchDeadline(t *testing.T) {
+	fast := &mockEngine{name: "fast", urls: []string{"https://fast.example.com/a"}}
+	alsoFast := &mockEngine{name: "also_fast", urls: []string{"https://also-fast.example.com/a"}, delay: 10 * time.Millisecond}
+	slow := &mockEngine{name: "slow", urls: []string{"https://slow.example.com/a"}, delay: 5 * time.Second}
+	setupSearch(t, Config{}, map[string][]engine.Engine{engine.CategoryGeneral: {fast, alsoFast, slow}})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	res := Search(ctx, engine.Options{Query: "go", PageNo: 1, Category: engine.CategoryGeneral})
+	if elapsed := time.Since(start); elapsed > 2*time.Second {
+		t.Fatalf("search waited %v for the slow engine", elapsed)
+	}
+
+	urls := dataUrls(res)
+	slices.Sort(urls)
+	if want := []string{"https://also-fast.example.com/a", "https://fast.example.com/a"}; !slices.Equal(urls, want) {
+		t.Errorf("got %v, want the results arrived before the deadline %v", urls, want)
+	}
+	if !slices.Equal(res.TimedOutEngines, []string{"slow"}) {
+		t.Errorf("timed out engines = %v, want [slow]", res.TimedOutEngines)
+	}
+}
+
+func TestSearchConfigTimeout(t *testing.T) {
+	fast := &mockEngine{name: "fast", urls: []string{"https://fast.example.com/a"}}
+	slow := &mockEngine{name: "slow", urls: []string{"https://slow.example.com/a"}, delay: 5 * time.Second}
+	setupSearch(t, Config{Timeout: 100 * time.Millisecond}, map[string][]engine.Engine{engine.CategoryGeneral: {fast, slow}})
+
+	// the configured timeout is the deadline of search without the deadline of request.
+	res := Search(context.Background(), engine.Options{Query: "go", PageNo: 1, Category: engine.CategoryGeneral})
+	if urls := dataUrls(res); !slices.Equal(urls, []string{"https://fast.example.com/a"}) {
+		t.Errorf("got %v", urls)
+	}
+	if !slices.Equal(res.TimedOutEngines, []string{"slow"}) {
+		t.Errorf("timed out engines = %v, want [slow]", res.TimedOutEngines)
+	}
+}
@@ -0,0 +1,54 @@
+package search
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/zvirgilx/searxng-go/kernel/internal/engine"
+	"github.com/zvirgilx/searxng-go/kernel/internal/result"
+)
+
+// datedEngine returns a stale result and a fresh one, the stale one is ranked first by url without decay.
+type datedEngine struct {
+	*mockEngine
+}
+
+func (e *datedEngine) Response(_ context.Context, opts *engine.Options, _ []byte) (*result.Result, error) {
+	res := result.CreateResult(e.name, opts.PageNo)
+	res.AppendData(&result.Data{Engine: e.name, Title: "stale", Url: "https://a.stale.example.com", PublishedDate: time.Now().AddDate(0, 0, -30)})
+	res.AppendData(&result.Data{Engine: e.name, Title: "fresh", Url: "https://b.fresh.example.com", PublishedDate: time.Now()})
+	return res, nil
+}
+
+func TestSearchTimeDecay(t *testing.T) {
+	// the results of engine are scored equally rather than at random.
+	result.InitConfig(result.Config{Score: result.Score{Scorer: "rule", MetadataFields: []string{"engine"}, Rules: []result.Rule{
+		{Name: "dated", Enable: true, Score: 100, Conditions: []result.Condition{{Field: "engine", Operator: "in", Expects: []string{"dated"}}}},
+	}}})
+	t.Cleanup(func() { result.InitConfig(result.Config{}) })
+
+	cases := map[string]struct {
+		category string
+		want     []string
+	}{
+		"decayed":     {category: engine.CategoryNews, want: []string{"https://b.fresh.example.com", "https://a.stale.example.com"}},
+		"not decayed": {category: engine.CategoryGeneral, want: []string{"https://a.stale.example.com", "https://b.fresh.example.com"}},
+	}
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			dated := &datedEngine{&mockEngine{name: "dated"}}
+			setupSearch(t, Config{TimeDecay: map[string]time.Duration{engine.CategoryNews: 24 * time.Hour}},
+				map[string][]engine.Engine{c.category: {dated}})
+
+			res := Search(context.Background(), engine.Options{Query: "go", PageNo: 1, Category: c.category})
+			var got []string
+			for _, d := range res.GetSortedData() {
+				got = append(got, d.Url)
+			}
+			if len(got) != 2 || got[0] != c.want[0] || got[1] != c.want[1] {
+				t.Errorf("got %v, want %v", got, c.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,141 @@
+package search
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"log/slog"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/zvirgilx/searxng-go/kernel/internal/network"
+	"github.com/zvirgilx/searxng-go/kernel/internal/result"
+)
+
+const (
+	defaultEnrichTopN           = 5
+	defaultEnrichConcurrency    = 3
+	defaultEnrichTimeout        = 2 * time.Second
+	defaultEnrichMinContentSize = 50
+
+	// enrichMaxBodySize is the bytes of pages read, the open graph metadata are in the head of pages.
+	enrichMaxBodySize = 512 << 10
+)
+
+// EnrichConfig is the configuration of enriching the top results by the open graph metadata of their pages.
+// The results missing a thumbnail or with a short content are enriched by og:image and og:description.
+type EnrichConfig struct {
+	Enable bool `mapstructure:"enable"`
+
+	TopN           int           `mapstructure:"top_n"`            // TopN is the count of top results enriched.
+	Concurrency    int           `mapstructure:"concurrency"`      // Concurrency is the maximum of pages fetched at once.
+	Timeout        time.Duration `mapstructure:"timeout"`          // Timeout is the deadline of enriching all results.
+	MinContentSize int           `mapstructure:"min_content_size"` // MinContentSize is the length of content below which the content is replaced.
+}
+
+// enrichClient fetches the pages of results, which are chosen by third parties,
+// so that it is not allowed to reach the internal addresses.
+var enrichClient = network.NewClient(&network.Config{PublicOnly: true})
+
+// enrich fetches the open graph metadata of the pages of top results, and fills out their thumbnail and content.
+// The results failed to fetch in time are kept as they are.
+func enrich(ctx context.Context, c EnrichConfig, res *result.Result) {
+	if c.TopN <= 0 {
+		c.TopN = defaultEnrichTopN
+	}
+	if c.Concurrency <= 0 {
+		c.Concurrency = defaultEnrichConcurrency
+	}
+	if c.Timeout <= 0 {
+		c.Timeout = defaultEnrichTimeout
+	}
+	if c.MinContentSize <= 0 {
+		c.MinContentSize = defaultEnrichMinContentSize
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, c.Timeout)
+	defer cancel()
+
+	data := res.GetSortedData()
+	data = data[:min(len(data), c.TopN)]
+
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, c.Concurrency)
+	for _, d := range data {
+		if d.Thumbnail != "" && len([]rune(d.Content)) >= c.MinContentSize {
+			continue
+		}
+
+		wg.Add(1)
+		go func(d *result.Data) {
+			defer wg.Done()
+
+			select {
+			case sem <- struct{}{}:
+				defer func() { <-sem }()
+			case <-ctx.Done():
+				return
+			}
+
+			image, description, err := fetchOpenGraph(ctx, d.Url)
+			if err != nil {
+				slog.DebugContext(ctx, "failed to fetch open graph", slog.String("url", d.Url), slog.String("err", err.Error()))
+				return
+			}
+			// each goroutine owns its data, so the data are written without lock.
+			if d.Thumbnail == "" {
+				d.Thumbnail = image
+			}
+			if len([]rune(d.Content)) < c.MinContentSize && len([]rune(description)) > len([]rune(d.Content)) {
+				d.Content = description
+			}
+		}(d)
+	}
+	wg.Wait()
+}
+
+// fetchOpenGraph fetches the page and gets its og:image and og:description, the image is resolved against the page.
+func fetchOpenGraph(ctx context.Context, rawUrl string) (string, string, error) {
+	u, err := url.Parse(rawUrl)
+	if err != nil {
+		return "", "", err
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return "", "", fmt.Errorf("unsupported scheme of page: %s", u.Scheme)
+	}
+
+	req := enrichClient.Get().Base(u).Path(u.Path).MaxBodySize(enrichMaxBodySize)
+	for k, vs := range u.Query() {
+		req.Param(k, vs[0])
+	}
+	r := req.Do(ctx)
+	if r.Err != nil {
+		return "", "", r.Err
+	}
+
+	doc, err := goquery.NewDocumentFromReader(bytes.NewReader(r.Body))
+	if err != nil {
+		return "", "", err
+	}
+
+	meta := func(selectors ...string) string {
+		for _, s := range selectors {
+			if v, ok := doc.Find(s).First().Attr("content"); ok && strings.TrimSpace(v) != "" {
+				return strings.TrimSpace(v)
+			}
+		}
+		return ""
+	}
+
+	image := meta(`meta[property="og:image"]`, `meta[name="twitter:image"]`)
+	if image != "" {
+		if ref, err := u.Parse(image); err == nil {
+			image = ref.String()
+		}
+	}
+	description := meta(`meta[property="og:description"]`, `meta[name="description"]`)
+	return image, description, nil
+}
@@ -0,0 +1,127 @@
+package search
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/zvirgilx/searxng-go/kernel/internal/engine"
+	"github.com/zvirgilx/searxng-go/kernel/internal/network"
+	"github.com/zvirgilx/searxng-go/kernel/internal/result"
+)
+
+// newOpenGraphServer serves the pages with open graph metadata, /large has the metadata beyond the max body size.
+func newOpenGraphServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		switch r.URL.Path {
+		case "/og":
+			w.Write([]byte(`<html><head>
+<meta property="og:image" content="/images/go.png">
+<meta property="og:description" content="Go is an open source programming language that makes it simple to build secure, scalable systems.">
+</head><body></body></html>`))
+		case "/twitter":
+			w.Write([]byte(`<html><head>
+<meta name="twitter:image" content="https://cdn.example.com/card.png">
+<meta name="description" content="  ">
+</head></html>`))
+		case "/large":
+			w.Write([]byte("<html><head><!-- " + strings.Repeat("x", enrichMaxBodySize) + ` -->
+<meta property="og:image" content="https://cdn.example.com/large.png"></head></html>`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+// allowLoopback fetches the pages of test server on the loopback address during the test.
+func allowLoopback(t *testing.T) {
+	t.Helper()
+	guarded := enrichClient
+	enrichClient = network.NewClient(&network.Config{})
+	t.Cleanup(func() { enrichClient = guarded })
+}
+
+func TestEnrich(t *testing.T) {
+	srv := newOpenGraphServer(t)
+	allowLoopback(t)
+
+	const content = "short"
+	res := result.CreateResult("a", 1)
+	og := &result.Data{Url: srv.URL + "/og", Content: content}
+	twitter := &result.Data{Url: srv.URL + "/twitter", Content: content}
+	complete := &result.Data{Url: srv.URL + "/og?complete", Thumbnail: "https://example.com/t.png",
+		Content: strings.Repeat("c", defaultEnrichMinContentSize)}
+	missing := &result.Data{Url: srv.URL + "/missing", Content: content}
+	large := &result.Data{Url: srv.URL + "/large"}
+	for _, d := range []*result.Data{og, twitter, complete, missing, large} {
+		res.AppendData(d)
+	}
+
+	enrich(context.Background(), EnrichConfig{Enable: true}, res)
+
+	if og.Thumbnail != srv.URL+"/images/go.png" || !strings.HasPrefix(og.Content, "Go is an open source") {
+		t.Errorf("og: thumbnail = %q, content = %q", og.Thumbnail, og.Content)
+	}
+	// the blank description does not replace the content.
+	if twitter.Thumbnail != "https://cdn.example.com/card.png" || twitter.Content != content {
+		t.Errorf("twitter: thumbnail = %q, content = %q", twitter.Thumbnail, twitter.Content)
+	}
+	if complete.Thumbnail != "https://example.com/t.png" || len(complete.Content) != defaultEnrichMinContentSize {
+		t.Errorf("the complete data are enriched: %+v", complete)
+	}
+	if missing.Thumbnail != "" || missing.Content != content {
+		t.Errorf("the data of failed page are changed: %+v", missing)
+	}
+	if large.Thumbnail != "" {
+		t.Errorf("the metadata beyond the max body size are read: %q", large.Thumbnail)
+	}
+}
+
+func TestEnrichTopN(t *testing.T) {
+	srv := newOpenGraphServer(t)
+	allowLoopback(t)
+
+	res := result.CreateResult("a", 1)
+	res.AppendData(&result.Data{Url: srv.URL + "/og"})
+	res.AppendData(&result.Data{Url: srv.URL + "/og?rest"})
+
+	enrich(context.Background(), EnrichConfig{Enable: true, TopN: 1}, res)
+	data := res.GetSortedData()
+	if data[0].Thumbnail == "" || data[1].Thumbnail != "" {
+		t.Errorf("top = %q, rest = %q, want only the top result enriched", data[0].Thumbnail, data[1].Thumbnail)
+	}
+}
+
+func TestFetchOpenGraphNonPublic(t *testing.T) {
+	srv := newOpenGraphServer(t)
+
+	// the guarded client rejects the page on the loopback address.
+	if _, _, err := fetchOpenGraph(context.Background(), srv.URL+"/og"); !errors.Is(err, network.ErrNonPublicAddress) {
+		t.Errorf("err = %v, want the loopback address rejected", err)
+	}
+	for _, u := range []string{"file:///etc/passwd", "gopher://example.com/"} {
+		if _, _, err := fetchOpenGraph(context.Background(), u); err == nil {
+			t.Errorf("the page %s is fetched", u)
+		}
+	}
+}
+
+func TestSearchEnrich(t *testing.T) {
+	srv := newOpenGraphServer(t)
+	allowLoopback(t)
+
+	a := &mockEngine{name: "enrich_a", urls: []string{srv.URL + "/og"}}
+	setupSearch(t, Config{Enrich: EnrichConfig{Enable: true}}, map[string][]engine.Engine{engine.CategoryGeneral: {a}})
+
+	data := Search(context.Background(), engine.Options{Query: "go", PageNo: 1, Category: engine.CategoryGeneral}).GetData()
+	if len(data) != 1 || data[0].Thumbnail != srv.URL+"/images/go.png" {
+		t.Errorf("the result is not enriched: %+v", data)
+	}
+}
@@ -0,0 +1,46 @@
+package search
+
+import (
+	"context"
+	"testing"
+
+	"github.com/zvirgilx/searxng-go/kernel/internal/engine"
+)
+
+// expandingEngine supports the OR syntax of query expansion.
+type expandingEngine struct {
+	*mockEngine
+}
+
+func (e *expandingEngine) Capabilities() engine.Capabilities {
+	return engine.Capabilities{Categories: []string{engine.CategoryGeneral}, Verbatim: true, QueryExpansion: true}
+}
+
+func TestSearchExpansion(t *testing.T) {
+	cases := map[string]struct {
+		enable      bool
+		verbatim    bool
+		want, plain string
+	}{
+		"expanded": {enable: true, want: "(js OR javascript) tutorial", plain: "js tutorial"},
+		"verbatim": {enable: true, verbatim: true, want: "js tutorial", plain: "js tutorial"},
+		"disabled": {want: "js tutorial", plain: "js tutorial"},
+	}
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			expanding := &expandingEngine{&mockEngine{name: "expanding", urls: []string{"https://expanding.example.com/1"}}}
+			plain := &mockEngine{name: "expansion_plain", urls: []string{"https://plain.example.com/1"}}
+			setupSearch(t, Config{Expansion: ExpansionConfig{Enable: c.enable, Dictionary: map[string][]string{"js": {"javascript"}}}},
+				map[string][]engine.Engine{engine.CategoryGeneral: {expanding, plain}})
+
+			Search(context.Background(), engine.Options{Query: "js tutorial", PageNo: 1, Category: engine.CategoryGeneral, Verbatim: c.verbatim})
+			if got := expanding.lastOptions().Query; got != c.want {
+				t.Errorf("query of expanding engine = %q, want %q", got, c.want)
+			}
+			// the engines not declaring query expansion get the query as typed.
+			if got := plain.lastOptions().Query; got != c.plain {
+				t.Errorf("query of plain engine = %q, want %q", got, c.plain)
+			}
+		})
+	}
+}
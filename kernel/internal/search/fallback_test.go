@@ -0,0 +1,59 @@
+package search
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"github.com/zvirgilx/searxng-go/kernel/internal/engine"
+)
+
+func TestSearchFallbackEngines(t *testing.T) {
+	cases := map[string]struct {
+		minResults    int
+		optMinResults int
+		wantFallback  bool
+	}{
+		"too few results":      {minResults: 3, wantFallback: true},
+		"enough results":       {minResults: 2},
+		"disabled":             {minResults: 0},
+		"minimum of request":   {minResults: 0, optMinResults: 3, wantFallback: true},
+		"request overrides it": {minResults: 3, optMinResults: 1},
+	}
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			a := &mockEngine{name: "a", urls: []string{"https://a.example.com/1"}}
+			b := &mockEngine{name: "b", urls: []string{"https://b.example.com/1"}}
+			fb := &mockEngine{name: "fb", urls: []string{"https://fb.example.com/1"}}
+			setupSearch(t, Config{
+				MinResults:      c.minResults,
+				FallbackEngines: map[string][]string{engine.CategoryGeneral: {"fb"}},
+			}, map[string][]engine.Engine{engine.CategoryGeneral: {a, b, fb}})
+
+			res := Search(context.Background(), engine.Options{Query: "go", PageNo: 1, Category: engine.CategoryGeneral, MinResults: c.optMinResults})
+			if got := fb.calls.Load() == 1; got != c.wantFallback {
+				t.Fatalf("the fallback engine is requested %d times", fb.calls.Load())
+			}
+			if a.calls.Load() != 1 || b.calls.Load() != 1 {
+				t.Errorf("the primary engines are requested %d and %d times, want once", a.calls.Load(), b.calls.Load())
+			}
+			if slices.Contains(dataUrls(res), "https://fb.example.com/1") != c.wantFallback {
+				t.Errorf("the results of fallback engine: got %v", dataUrls(res))
+			}
+		})
+	}
+}
+
+func TestSearchFallbackEnginesOnly(t *testing.T) {
+	fb := &mockEngine{name: "fb", urls: []string{"https://fb.example.com/1"}}
+	setupSearch(t, Config{
+		MinResults:      1,
+		FallbackEngines: map[string][]string{engine.CategoryGeneral: {"fb"}},
+	}, map[string][]engine.Engine{engine.CategoryGeneral: {fb}})
+
+	// the category of fallback engines only is searched rather than reported as without engines.
+	res := Search(context.Background(), engine.Options{Query: "go", PageNo: 1, Category: engine.CategoryGeneral})
+	if urls := dataUrls(res); len(urls) != 1 || urls[0] != "https://fb.example.com/1" {
+		t.Errorf("the results of fallback engine: got %v", urls)
+	}
+}
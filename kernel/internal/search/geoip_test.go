@@ -0,0 +1,35 @@
+package search
+
+import (
+	"net/netip"
+	"testing"
+
+	"github.com/zvirgilx/searxng-go/kernel/internal/locale"
+)
+
+type countryResolver string
+
+func (r countryResolver) Country(netip.Addr) (string, bool) { return string(r), true }
+
+func TestVerifySearchOptionsLanguageFromIP(t *testing.T) {
+	setupSearch(t, Config{}, nil)
+	locale.SetGeoIPResolver(countryResolver("DE"))
+	t.Cleanup(func() { locale.SetGeoIPResolver(nil) })
+
+	opts, err := verifySearchOptions(queryParams(map[string]string{"q": "wetter"}), "5.1.2.3")
+	if err != nil || opts.Locale != "de-DE" {
+		t.Errorf("locale = %q, err %v, want de-DE from the client ip", opts.Locale, err)
+	}
+
+	// the language of request wins over the client ip.
+	opts, err = verifySearchOptions(queryParams(map[string]string{"q": "wetter", "language": "fr-FR"}), "5.1.2.3")
+	if err != nil || opts.Locale != "fr-FR" {
+		t.Errorf("locale = %q, err %v, want fr-FR", opts.Locale, err)
+	}
+
+	// the lookup failure falls back to the global default.
+	opts, _ = verifySearchOptions(queryParams(map[string]string{"q": "wetter"}), "")
+	if opts.Locale != "en-US" {
+		t.Errorf("locale = %q, want en-US", opts.Locale)
+	}
+}
@@ -0,0 +1,126 @@
+package search
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/zvirgilx/searxng-go/kernel/internal/engine"
+	"github.com/zvirgilx/searxng-go/kernel/internal/network"
+	"github.com/zvirgilx/searxng-go/kernel/internal/result"
+)
+
+// mockBase is the url of server answering the requests of mock engines, the mock engines build the results themselves.
+var mockBase = sync.OnceValue(func() *url.URL {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain")
+		w.Write([]byte("ok"))
+	}))
+	u, _ := url.Parse(srv.URL)
+	return u
+})
+
+var mockClient = network.NewClient(&network.Config{})
+
+// mockEngine returns the urls as data after the delay, or the error if it is set.
+type mockEngine struct {
+	name        string
+	urls        []string
+	delay       time.Duration
+	err         error
+	corrections []string
+
+	// calls is the count of requests of engine.
+	calls atomic.Int32
+	// options are the options of the last request.
+	mu      sync.Mutex
+	options engine.Options
+}
+
+func (m *mockEngine) Request(ctx context.Context, opts *engine.Options) error {
+	m.calls.Add(1)
+	m.mu.Lock()
+	m.options = *opts
+	m.mu.Unlock()
+
+	if m.delay > 0 {
+		select {
+		case <-time.After(m.delay):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	if m.err != nil {
+		return m.err
+	}
+	opts.Request = mockClient.Get().Base(mockBase()).Path("/"+m.name).Param("q", opts.Query)
+	return nil
+}
+
+func (m *mockEngine) Response(_ context.Context, opts *engine.Options, _ []byte) (*result.Result, error) {
+	res := result.CreateResult(m.name, opts.PageNo)
+	for _, u := range m.urls {
+		res.AppendData(&result.Data{Engine: m.name, Title: m.name + " " + u, Url: u, Query: opts.Query})
+	}
+	res.Corrections = m.corrections
+	return res, nil
+}
+
+func (m *mockEngine) GetName() string { return m.name }
+
+func (m *mockEngine) ApplyConfig(engine.Config) error { return nil }
+
+// lastOptions gets the options of the last request of engine.
+func (m *mockEngine) lastOptions() engine.Options {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.options
+}
+
+// setupSearch registers the engines by category and initializes the search with c,
+// the engines, middlewares and config are reset after the test once the engines running are finished.
+func setupSearch(t *testing.T, c Config, engines map[string][]engine.Engine) {
+	t.Helper()
+
+	registry := map[string]map[string]engine.Engine{}
+	for category, es := range engines {
+		for _, e := range es {
+			engine.RegisterTo(registry, e, category)
+		}
+	}
+	engine.SetGlobalEngines(registry)
+
+	middlewares = []engine.Middleware{metricsMiddleware}
+	running := &sync.WaitGroup{}
+	c.Running = running
+	InitConfig(c)
+
+	t.Cleanup(func() {
+		running.Wait()
+		engine.SetGlobalEngines(map[string]map[string]engine.Engine{})
+		middlewares = []engine.Middleware{metricsMiddleware}
+		InitConfig(Config{})
+	})
+}
+
+// dataUrls gets the urls of data in order.
+func dataUrls(res *result.Result) []string {
+	var urls []string
+	for _, d := range res.GetData() {
+		urls = append(urls, d.Url)
+	}
+	return urls
+}
+
+// queryParams gets the params of values like the query of request.
+func queryParams(values map[string]string) func(string) (string, bool) {
+	return func(name string) (string, bool) {
+		v, ok := values[name]
+		return v, ok
+	}
+}
@@ -0,0 +1,29 @@
+package search
+
+import (
+	"github.com/zvirgilx/searxng-go/kernel/internal/engine"
+	"github.com/zvirgilx/searxng-go/kernel/internal/result"
+)
+
+// LanguageFilterConfig is the configuration of detecting the languages of results.
+type LanguageFilterConfig struct {
+	Enable bool `mapstructure:"enable"`
+
+	// Threshold is the minimum confidence of detected language, the language of result is unknown below it.
+	Threshold float64 `mapstructure:"threshold"`
+	// MaxLength is the maximum of characters of title and content detected, 0 means unlimited.
+	MaxLength int `mapstructure:"max_length"`
+}
+
+// detectResultLanguages detects the language of each data by its title and content.
+func detectResultLanguages(c LanguageFilterConfig, res *result.Result) {
+	for _, d := range res.GetData() {
+		text := []rune(d.Title + " " + d.Content)
+		if c.MaxLength > 0 && len(text) > c.MaxLength {
+			text = text[:c.MaxLength]
+		}
+		if lang, confidence := engine.DetectLanguage(string(text)); lang != "" && confidence >= c.Threshold {
+			d.Lang = lang
+		}
+	}
+}
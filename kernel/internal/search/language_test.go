@@ -0,0 +1,109 @@
+package search
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"github.com/zvirgilx/searxng-go/kernel/internal/engine"
+	"github.com/zvirgilx/searxng-go/kernel/internal/result"
+)
+
+// titledEngine sets the titles of its data by urls.
+type titledEngine struct {
+	*mockEngine
+	titles map[string]string
+}
+
+func (e *titledEngine) Response(ctx context.Context, opts *engine.Options, body []byte) (*result.Result, error) {
+	res, err := e.mockEngine.Response(ctx, opts, body)
+	if err != nil {
+		return nil, err
+	}
+	for _, d := range res.GetData() {
+		d.Title = e.titles[d.Url]
+	}
+	return res, nil
+}
+
+func TestDetectResultLanguages(t *testing.T) {
+	cases := map[string]struct {
+		c     LanguageFilterConfig
+		title string
+		want  string
+	}{
+		"detected":          {c: LanguageFilterConfig{Threshold: 0.7}, title: "Как выучить язык программирования", want: "ru"},
+		"not confident":     {c: LanguageFilterConfig{Threshold: 0.7}, title: "Golang tour"},
+		"bounded by length": {c: LanguageFilterConfig{Threshold: 0.7, MaxLength: 6}, title: "Golang: the best way to learn how to code"},
+		"within length":     {c: LanguageFilterConfig{Threshold: 0.7, MaxLength: 30}, title: "Golang: the best way to learn how to code", want: "en"},
+	}
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			res := result.CreateResult("a", 1)
+			res.AppendData(&result.Data{Title: c.title, Url: "https://a.example.com"})
+
+			detectResultLanguages(c.c, res)
+			if got := res.GetData()[0].Lang; got != c.want {
+				t.Errorf("lang = %q, want %q", got, c.want)
+			}
+		})
+	}
+}
+
+func TestSearchLanguageFilter(t *testing.T) {
+	e := &titledEngine{
+		mockEngine: &mockEngine{name: "lang_filter", urls: []string{"https://en.example.com", "https://ru.example.com", "https://short.example.com"}},
+		titles: map[string]string{
+			"https://en.example.com":    "How to learn the go language",
+			"https://ru.example.com":    "Как выучить язык программирования",
+			"https://short.example.com": "Golang",
+		},
+	}
+
+	cases := map[string]struct {
+		enable    bool
+		specified bool
+		want      []string
+	}{
+		"language specified": {enable: true, specified: true, want: []string{"https://en.example.com", "https://short.example.com"}},
+		// the language detected from the query or derived from the client ip is not enforced.
+		"language not specified": {enable: true, want: []string{"https://en.example.com", "https://ru.example.com", "https://short.example.com"}},
+		"disabled":               {specified: true, want: []string{"https://en.example.com", "https://ru.example.com", "https://short.example.com"}},
+	}
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			setupSearch(t, Config{LanguageFilter: LanguageFilterConfig{Enable: c.enable, Threshold: 0.7}}, map[string][]engine.Engine{engine.CategoryGeneral: {e}})
+
+			res := Search(context.Background(), engine.Options{Query: "go", PageNo: 1, Category: engine.CategoryGeneral, Locale: "en-US", LanguageSpecified: c.specified})
+			var got []string
+			for _, d := range res.GetData() {
+				got = append(got, d.Url)
+				if want := map[string]string{"https://en.example.com": "en", "https://ru.example.com": "ru"}[d.Url]; c.enable && d.Lang != want {
+					t.Errorf("lang of %s = %q, want %q", d.Url, d.Lang, want)
+				}
+			}
+			slices.Sort(got)
+			if !slices.Equal(got, c.want) {
+				t.Errorf("got %v, want %v", got, c.want)
+			}
+		})
+	}
+}
+
+func TestVerifySearchOptionsLanguageSpecified(t *testing.T) {
+	setupSearch(t, Config{}, nil)
+
+	for params, want := range map[string]bool{"": false, "de-DE": true} {
+		query := map[string]string{"q": "go"}
+		if params != "" {
+			query["language"] = params
+		}
+		opts, err := verifySearchOptions(queryParams(query), "")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if opts.LanguageSpecified != want {
+			t.Errorf("language %q: specified = %v, want %v", params, opts.LanguageSpecified, want)
+		}
+	}
+}
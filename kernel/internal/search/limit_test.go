@@ -0,0 +1,50 @@
+package search
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/zvirgilx/searxng-go/kernel/internal/engine"
+)
+
+func urlsOf(host string, n int) []string {
+	var urls []string
+	for i := 0; i < n; i++ {
+		urls = append(urls, fmt.Sprintf("https://%s/%d", host, i))
+	}
+	return urls
+}
+
+func TestSearchMaxResultsPerEngine(t *testing.T) {
+	a := &mockEngine{name: "a", urls: urlsOf("a.example.com", 5)}
+	b := &mockEngine{name: "b", urls: urlsOf("b.example.com", 5)}
+	setupSearch(t, Config{MaxResultsPerEngine: 2}, map[string][]engine.Engine{engine.CategoryGeneral: {a, b}})
+
+	res := Search(context.Background(), engine.Options{Query: "go", PageNo: 1, Category: engine.CategoryGeneral})
+	counts := map[string]int{}
+	for _, d := range res.GetData() {
+		counts[d.Engine]++
+	}
+	if counts["a"] != 2 || counts["b"] != 2 {
+		t.Errorf("counts of engines = %v, want 2 of each engine", counts)
+	}
+
+	// the limit of request overrides the config.
+	res = Search(context.Background(), engine.Options{Query: "go", PageNo: 1, Category: engine.CategoryGeneral, MaxResultsPerEngine: 1})
+	if n := res.GetDataSize(); n != 2 {
+		t.Errorf("got %d data, want 1 of each engine", n)
+	}
+}
+
+func TestSearchMaxResults(t *testing.T) {
+	a := &mockEngine{name: "a", urls: urlsOf("a.example.com", 5)}
+	b := &mockEngine{name: "b", urls: urlsOf("b.example.com", 5)}
+	setupSearch(t, Config{MaxResultsPerEngine: 4, MaxResults: 3}, map[string][]engine.Engine{engine.CategoryGeneral: {a, b}})
+
+	// the global cap is applied after the results of engines are merged.
+	res := Search(context.Background(), engine.Options{Query: "go", PageNo: 1, Category: engine.CategoryGeneral})
+	if n := res.GetDataSize(); n != 3 {
+		t.Errorf("got %d data, want 3", n)
+	}
+}
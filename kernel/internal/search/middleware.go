@@ -0,0 +1,40 @@
+package search
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/zvirgilx/searxng-go/kernel/internal/engine"
+	"github.com/zvirgilx/searxng-go/kernel/internal/metrics"
+	"github.com/zvirgilx/searxng-go/kernel/internal/result"
+)
+
+// middlewares are composed around the search of each engine, the first middleware is the outermost.
+var middlewares = []engine.Middleware{metricsMiddleware}
+
+// Use appends middlewares to the search of each engine.
+func Use(mw ...engine.Middleware) {
+	middlewares = append(middlewares, mw...)
+}
+
+// metricsMiddleware observes the latency, status and count of results of engine.
+func metricsMiddleware(e engine.Engine, next engine.Handler) engine.Handler {
+	return func(ctx context.Context, opts *engine.Options) (res *result.Result, err error) {
+		start := time.Now()
+
+		defer func() {
+			status := "ok"
+			if errors.Is(err, engine.ErrBlocked) {
+				status = "blocked"
+			} else if err != nil {
+				status = "error"
+			}
+
+			metrics.EnginesResponseCounter.WithLabelValues(e.GetName(), status).Observe(time.Since(start).Seconds())
+			metrics.EnginesSearchResultCounter.WithLabelValues(e.GetName()).Add(float64(res.GetDataSize()))
+		}()
+
+		return next(ctx, opts)
+	}
+}
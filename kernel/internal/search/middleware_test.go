@@ -0,0 +1,41 @@
+package search
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+
+	"github.com/zvirgilx/searxng-go/kernel/internal/engine"
+	"github.com/zvirgilx/searxng-go/kernel/internal/result"
+)
+
+func TestUseMiddlewareShortCircuits(t *testing.T) {
+	e := &mockEngine{name: "a", urls: []string{"https://a.example.com/1"}}
+	setupSearch(t, Config{}, map[string][]engine.Engine{engine.CategoryGeneral: {e}})
+
+	var hits atomic.Int32
+	Use(func(e engine.Engine, next engine.Handler) engine.Handler {
+		return func(ctx context.Context, opts *engine.Options) (*result.Result, error) {
+			if opts.Query == "cached" {
+				hits.Add(1)
+				res := result.CreateResult(e.GetName(), opts.PageNo)
+				res.AppendData(&result.Data{Engine: e.GetName(), Url: "https://cached.example.com"})
+				return res, nil
+			}
+			return next(ctx, opts)
+		}
+	})
+
+	res := Search(context.Background(), engine.Options{Query: "cached", PageNo: 1, Category: engine.CategoryGeneral})
+	if urls := dataUrls(res); len(urls) != 1 || urls[0] != "https://cached.example.com" || e.calls.Load() != 0 {
+		t.Errorf("got %v, the engine is requested %d times on a hit", urls, e.calls.Load())
+	}
+
+	res = Search(context.Background(), engine.Options{Query: "go", PageNo: 1, Category: engine.CategoryGeneral})
+	if urls := dataUrls(res); len(urls) != 1 || urls[0] != "https://a.example.com/1" || e.calls.Load() != 1 {
+		t.Errorf("got %v, the engine is requested %d times on a miss", urls, e.calls.Load())
+	}
+	if hits.Load() != 1 {
+		t.Errorf("the middleware is hit %d times, want 1", hits.Load())
+	}
+}
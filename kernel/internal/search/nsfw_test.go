@@ -0,0 +1,43 @@
+package search
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"github.com/zvirgilx/searxng-go/kernel/internal/engine"
+	"github.com/zvirgilx/searxng-go/kernel/internal/result"
+)
+
+func TestSearchNSFW(t *testing.T) {
+	result.InitConfig(result.Config{NSFW: result.NSFWConfig{Enable: true}})
+	t.Cleanup(func() { result.InitConfig(result.Config{}) })
+
+	cases := map[string]struct {
+		safeSearch int
+		want       []string
+		tagged     bool
+	}{
+		"none":     {safeSearch: engine.SafeSearchNone, want: []string{"https://a.example.com/porn", "https://go.dev"}},
+		"moderate": {safeSearch: engine.SafeSearchModerate, want: []string{"https://a.example.com/porn", "https://go.dev"}, tagged: true},
+		"strict":   {safeSearch: engine.SafeSearchStrict, want: []string{"https://go.dev"}},
+	}
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := &mockEngine{name: "adult_mock", urls: []string{"https://go.dev", "https://a.example.com/porn"}}
+			setupSearch(t, Config{}, map[string][]engine.Engine{engine.CategoryGeneral: {e}})
+
+			res := Search(context.Background(), engine.Options{Query: "go", PageNo: 1, Category: engine.CategoryGeneral, SafeSearch: c.safeSearch})
+			got := dataUrls(res)
+			slices.Sort(got)
+			if !slices.Equal(got, c.want) {
+				t.Errorf("got %v, want %v", got, c.want)
+			}
+			for _, d := range res.GetData() {
+				if tagged := slices.Contains(d.Tags, result.TagNSFW); tagged != (c.tagged && d.Url == "https://a.example.com/porn") {
+					t.Errorf("tags of %s = %v", d.Url, d.Tags)
+				}
+			}
+		})
+	}
+}
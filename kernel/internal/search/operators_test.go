@@ -0,0 +1,51 @@
+package search
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"github.com/zvirgilx/searxng-go/kernel/internal/engine"
+)
+
+func TestSearchOperatorsPostFilter(t *testing.T) {
+	e := &mockEngine{name: "a", urls: []string{"https://go.dev/doc", "https://pkg.go.dev/fmt", "https://example.com/go", "https://go.dev/javascript"}}
+	setupSearch(t, Config{}, map[string][]engine.Engine{engine.CategoryGeneral: {e}})
+
+	res := Search(context.Background(), engine.Options{Query: "go site:go.dev -javascript", PageNo: 1, Category: engine.CategoryGeneral})
+	got := dataUrls(res)
+	slices.Sort(got)
+	if want := []string{"https://go.dev/doc", "https://pkg.go.dev/fmt"}; !slices.Equal(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+	if q := e.lastOptions().Query; q != "go" {
+		t.Errorf("the engine is requested with %q, want the query without operators", q)
+	}
+}
+
+// nativeOperatorsEngine supports the operators natively, its results are not post-filtered.
+type nativeOperatorsEngine struct{ *mockEngine }
+
+func (e *nativeOperatorsEngine) Capabilities() engine.Capabilities {
+	return engine.Capabilities{Operators: true}
+}
+
+func TestSearchOperatorsExcludeWords(t *testing.T) {
+	capless := &mockEngine{name: "exclude_capless", urls: []string{"https://java.example.com/a", "https://javascript.example.com/a", "https://example.com/java-intro"}}
+	native := &nativeOperatorsEngine{&mockEngine{name: "exclude_native", urls: []string{"https://java.example.com/b"}}}
+	setupSearch(t, Config{}, map[string][]engine.Engine{engine.CategoryGeneral: {capless, native}})
+
+	res := Search(context.Background(), engine.Options{Query: "tutorial -java", PageNo: 1, Category: engine.CategoryGeneral})
+	got := dataUrls(res)
+	slices.Sort(got)
+	// the results of capless engine containing the word are dropped, the ones containing it as a part of word are kept.
+	if want := []string{"https://java.example.com/b", "https://javascript.example.com/a"}; !slices.Equal(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+	if q := capless.lastOptions().Query; q != "tutorial" {
+		t.Errorf("the capless engine is requested with %q, want the query without operators", q)
+	}
+	if q := native.lastOptions().Query; q != "tutorial -java" {
+		t.Errorf("the engine of native operators is requested with %q", q)
+	}
+}
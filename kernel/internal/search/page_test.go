@@ -0,0 +1,61 @@
+package search
+
+import (
+	"context"
+	"testing"
+
+	"github.com/zvirgilx/searxng-go/kernel/internal/engine"
+)
+
+// pagelessEngine does not support requesting the next page.
+type pagelessEngine struct {
+	*mockEngine
+}
+
+func (e *pagelessEngine) Capabilities() engine.Capabilities {
+	return engine.Capabilities{Categories: []string{engine.CategoryGeneral}}
+}
+
+func TestSearchPageNo(t *testing.T) {
+	cases := map[string]struct {
+		maxPageNo, pageNo, want int
+	}{
+		"page 0":          {pageNo: 0, want: 1},
+		"page of request": {pageNo: 3, want: 3},
+		"beyond max page": {maxPageNo: 5, pageNo: 50, want: 5},
+		"unlimited page":  {pageNo: 50, want: 50},
+		"within max page": {maxPageNo: 5, pageNo: 2, want: 2},
+	}
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			a := &mockEngine{name: "a", urls: []string{"https://a.example.com/1"}}
+			b := &mockEngine{name: "b", urls: []string{"https://b.example.com/1"}}
+			setupSearch(t, Config{MaxPageNo: c.maxPageNo}, map[string][]engine.Engine{engine.CategoryGeneral: {a, b}})
+
+			Search(context.Background(), engine.Options{Query: "go", PageNo: c.pageNo, Category: engine.CategoryGeneral})
+			if got := a.lastOptions().PageNo; got != c.want {
+				t.Errorf("the engine is requested for page %d, want %d", got, c.want)
+			}
+		})
+	}
+}
+
+func TestSearchNextPageWithoutPaging(t *testing.T) {
+	a := &mockEngine{name: "a", urls: []string{"https://a.example.com/1"}}
+	pageless := &pagelessEngine{&mockEngine{name: "pageless", urls: []string{"https://pageless.example.com/1"}}}
+	setupSearch(t, Config{}, map[string][]engine.Engine{engine.CategoryGeneral: {a, pageless}})
+
+	res := Search(context.Background(), engine.Options{Query: "go", PageNo: 2, Category: engine.CategoryGeneral})
+	if pageless.calls.Load() != 0 {
+		t.Error("the engine without paging is requested for the next page")
+	}
+	if urls := dataUrls(res); len(urls) != 1 || urls[0] != "https://a.example.com/1" {
+		t.Errorf("got %v", urls)
+	}
+
+	// the first page normalized from 0 is requested.
+	Search(context.Background(), engine.Options{Query: "go", PageNo: 0, Category: engine.CategoryGeneral})
+	if pageless.calls.Load() != 1 {
+		t.Errorf("the engine without paging is requested %d times for the first page, want once", pageless.calls.Load())
+	}
+}
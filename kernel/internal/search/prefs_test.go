@@ -0,0 +1,40 @@
+package search
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/zvirgilx/searxng-go/kernel/internal/engine"
+	"github.com/zvirgilx/searxng-go/kernel/internal/prefs"
+)
+
+func TestSearchOptionsPrefs(t *testing.T) {
+	setupSearch(t, Config{}, map[string][]engine.Engine{
+		engine.CategoryGeneral: {&mockEngine{name: "prefs_a"}, &mockEngine{name: "prefs_b"}},
+		engine.CategoryNews:    {&mockEngine{name: "prefs_b"}},
+	})
+	strict := engine.SafeSearchStrict
+	tok := prefs.Encode(prefs.Preferences{Engines: []string{"prefs_a"}, Categories: []string{engine.CategoryNews}, SafeSearch: &strict})
+
+	// the preferences of token are the defaults of params.
+	opts, err := verifySearchOptions(queryParams(map[string]string{"q": "go", "prefs": tok}), "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(opts.Engines, []string{"prefs_a"}) || !slices.Equal(opts.Categories, []string{engine.CategoryNews}) || opts.SafeSearch != strict {
+		t.Errorf("engines = %v, categories = %v, safe search = %d", opts.Engines, opts.Categories, opts.SafeSearch)
+	}
+
+	// the params in url override the preferences.
+	opts, err = verifySearchOptions(queryParams(map[string]string{"q": "go", "prefs": tok, "engines": "prefs_b", "safe_search": "0"}), "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(opts.Engines, []string{"prefs_b"}) || opts.SafeSearch != engine.SafeSearchNone {
+		t.Errorf("engines = %v, safe search = %d", opts.Engines, opts.SafeSearch)
+	}
+
+	if _, err = verifySearchOptions(queryParams(map[string]string{"q": "go", "prefs": "invalid"}), ""); err == nil {
+		t.Error("the invalid token is accepted")
+	}
+}
@@ -0,0 +1,55 @@
+package search
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/zvirgilx/searxng-go/kernel/internal/engine"
+	"github.com/zvirgilx/searxng-go/kernel/internal/result"
+)
+
+func TestSearchWithProgress(t *testing.T) {
+	ok := &mockEngine{name: "ok", urls: []string{"https://ok.example.com/1", "https://ok.example.com/2"}}
+	failed := &mockEngine{name: "failed", err: errors.New("upstream error")}
+	slow := &mockEngine{name: "slow", urls: []string{"https://slow.example.com/1"}, delay: 5 * time.Second}
+	setupSearch(t, Config{Timeout: 200 * time.Millisecond}, map[string][]engine.Engine{engine.CategoryGeneral: {ok, failed, slow}})
+
+	// the progress is not called concurrently, so the reports are not guarded.
+	partials := map[string]*result.Result{}
+	errs := map[string]error{}
+	calls := 0
+	res := SearchWithProgress(context.Background(), engine.Options{Query: "go", PageNo: 1, Category: engine.CategoryGeneral},
+		func(name string, partial *result.Result, err error) {
+			calls++
+			partials[name], errs[name] = partial, err
+		})
+	waitLatencyRecorded(t, "slow")
+
+	if calls != 3 {
+		t.Errorf("progress is called %d times, want once for each engine", calls)
+	}
+	if errs["ok"] != nil || partials["ok"].GetDataSize() != 2 {
+		t.Errorf("progress of ok: %v, %d data", errs["ok"], partials["ok"].GetDataSize())
+	}
+	if errs["failed"] == nil || partials["failed"] != nil {
+		t.Errorf("progress of failed: %v, %v", errs["failed"], partials["failed"])
+	}
+	if !errors.Is(errs["slow"], context.DeadlineExceeded) || partials["slow"] != nil {
+		t.Errorf("progress of slow: %v, %v", errs["slow"], partials["slow"])
+	}
+	if res.GetDataSize() != 2 {
+		t.Errorf("count of data = %d, want the ones of ok", res.GetDataSize())
+	}
+}
+
+func TestSearchWithoutProgress(t *testing.T) {
+	a := &mockEngine{name: "a", urls: []string{"https://a.example.com/1"}}
+	b := &mockEngine{name: "b", urls: []string{"https://b.example.com/1"}}
+	setupSearch(t, Config{}, map[string][]engine.Engine{engine.CategoryGeneral: {a, b}})
+
+	if res := SearchWithProgress(context.Background(), engine.Options{Query: "go", PageNo: 1, Category: engine.CategoryGeneral}, nil); res.GetDataSize() != 2 {
+		t.Errorf("count of data = %d, want 2", res.GetDataSize())
+	}
+}
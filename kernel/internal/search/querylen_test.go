@@ -0,0 +1,36 @@
+package search
+
+import (
+	"context"
+	"testing"
+
+	"github.com/zvirgilx/searxng-go/kernel/internal/engine"
+)
+
+// heavyEngine is not requested for the queries shorter than 3 characters.
+type heavyEngine struct {
+	*mockEngine
+}
+
+func (e *heavyEngine) Capabilities() engine.Capabilities {
+	return engine.Capabilities{Categories: []string{engine.CategoryGeneral}, MinQueryLen: 3}
+}
+
+func TestSearchMinQueryLen(t *testing.T) {
+	heavy := &heavyEngine{&mockEngine{name: "heavy", urls: []string{"https://heavy.example.com/1"}}}
+	a := &mockEngine{name: "querylen_a", urls: []string{"https://a.example.com/1"}}
+	setupSearch(t, Config{}, map[string][]engine.Engine{engine.CategoryGeneral: {heavy, a}})
+
+	res := Search(context.Background(), engine.Options{Query: "go", PageNo: 1, Category: engine.CategoryGeneral})
+	if heavy.calls.Load() != 0 {
+		t.Error("the engine is requested for the query too short")
+	}
+	if urls := dataUrls(res); len(urls) != 1 || urls[0] != "https://a.example.com/1" {
+		t.Errorf("got %v", urls)
+	}
+
+	Search(context.Background(), engine.Options{Query: "golang", PageNo: 1, Category: engine.CategoryGeneral})
+	if heavy.calls.Load() != 1 {
+		t.Errorf("the engine is requested %d times for the long query, want once", heavy.calls.Load())
+	}
+}
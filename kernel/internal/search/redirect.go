@@ -0,0 +1,92 @@
+package search
+
+import (
+	"net/url"
+	"strings"
+
+	"github.com/zvirgilx/searxng-go/kernel/internal/result"
+)
+
+const (
+	defaultRedirectMinEngines = 2
+	defaultRedirectTopResults = 5
+)
+
+// RedirectConfig is the configuration of redirecting the navigational queries, e.g. github.com,
+// to the site directly rather than showing the results.
+type RedirectConfig struct {
+	Enable bool `mapstructure:"enable"`
+
+	// MinEngines is the count of engines which must have found the site, so that a single engine does not hijack the query.
+	MinEngines int `mapstructure:"min_engines"`
+	// TopResults is the count of top results checked, the query is ambiguous if they link to several sites matching it.
+	TopResults int `mapstructure:"top_results"`
+}
+
+// navigationalRedirect gets the url to redirect the query to, empty if the query is not clearly navigational.
+// The query must be a domain, e.g. github.com, the top result must be the only site matching it among the top results,
+// and enough engines must have found the site.
+func navigationalRedirect(c RedirectConfig, query string, data []*result.Data) string {
+	if c.MinEngines <= 0 {
+		c.MinEngines = defaultRedirectMinEngines
+	}
+	if c.TopResults <= 0 {
+		c.TopResults = defaultRedirectTopResults
+	}
+
+	q := navigationalQuery(query)
+	if q == "" || len(data) == 0 {
+		return ""
+	}
+
+	top := resultHost(data[0].Url)
+	if top != q {
+		return ""
+	}
+	for _, d := range data[1:min(len(data), c.TopResults)] {
+		if host := resultHost(d.Url); host != top && host == q {
+			return ""
+		}
+	}
+
+	engines := map[string]bool{}
+	for _, d := range data {
+		if resultHost(d.Url) != top {
+			continue
+		}
+		engines[d.Engine] = true
+		for _, name := range d.Engines {
+			engines[name] = true
+		}
+	}
+	if len(engines) < c.MinEngines {
+		return ""
+	}
+	return data[0].Url
+}
+
+// navigationalQuery normalizes the query of a domain, e.g. https://www.GitHub.com/ -> github.com.
+// Empty is returned if the query is not a single dotted domain, a dotless word like github is rather a search of it.
+func navigationalQuery(query string) string {
+	q := strings.ToLower(strings.TrimSpace(query))
+	if q == "" || strings.ContainsAny(q, " \t") {
+		return ""
+	}
+	q = strings.TrimPrefix(strings.TrimPrefix(q, "https://"), "http://")
+	q = strings.TrimSuffix(strings.TrimPrefix(q, "www."), "/")
+	// a path is not navigational to the site, and neither is a name without dots or with empty labels.
+	if strings.Contains(q, "/") || !strings.Contains(q, ".") || strings.Contains(q, "..") ||
+		strings.HasPrefix(q, ".") || strings.HasSuffix(q, ".") {
+		return ""
+	}
+	return q
+}
+
+// resultHost gets the host of url without www, empty if it is invalid.
+func resultHost(raw string) string {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return ""
+	}
+	return strings.TrimPrefix(strings.ToLower(u.Hostname()), "www.")
+}
@@ -0,0 +1,99 @@
+package search
+
+import (
+	"context"
+	"testing"
+
+	"github.com/zvirgilx/searxng-go/kernel/internal/engine"
+	"github.com/zvirgilx/searxng-go/kernel/internal/result"
+)
+
+func TestNavigationalQuery(t *testing.T) {
+	cases := map[string]string{
+		"github.com":               "github.com",
+		" https://www.GitHub.com/": "github.com",
+		"http://docs.github.com":   "docs.github.com",
+		"github":                   "",
+		"github com":               "",
+		"github.com/spf13":         "",
+		"...":                      "",
+		".com":                     "",
+		"github..com":              "",
+		"":                         "",
+	}
+	for query, want := range cases {
+		t.Run(query, func(t *testing.T) {
+			if got := navigationalQuery(query); got != want {
+				t.Errorf("navigational query = %q, want %q", got, want)
+			}
+		})
+	}
+}
+
+func TestNavigationalRedirect(t *testing.T) {
+	site := []*result.Data{
+		{Engine: "bing", Url: "https://github.com/", Engines: []string{"bing", "google"}},
+		{Engine: "bing", Url: "https://github.com/about"},
+		{Engine: "google", Url: "https://en.wikipedia.org/wiki/GitHub"},
+	}
+	cases := map[string]struct {
+		conf  RedirectConfig
+		query string
+		data  []*result.Data
+		want  string
+	}{
+		"domain":     {query: "github.com", data: site, want: "https://github.com/"},
+		"with www":   {query: "www.github.com", data: site, want: "https://github.com/"},
+		"url":        {query: "https://github.com/", data: site, want: "https://github.com/"},
+		"word":       {query: "github", data: site},
+		"phrase":     {query: "github com", data: site},
+		"no data":    {query: "github.com"},
+		"other site": {query: "gitlab.com", data: site},
+		"top is not the site": {query: "github.com", data: []*result.Data{
+			{Engine: "google", Url: "https://en.wikipedia.org/wiki/GitHub", Engines: []string{"bing", "google"}},
+			{Engine: "bing", Url: "https://github.com/", Engines: []string{"bing", "google"}},
+		}},
+		// the subdomain does not match the domain, so the query is not ambiguous.
+		"subdomain among top results": {query: "github.com", data: append([]*result.Data{site[0]}, &result.Data{Engine: "bing", Url: "https://docs.github.com/"}),
+			want: "https://github.com/"},
+		"too few engines": {query: "github.com", data: site[1:2]},
+		"min engines":     {conf: RedirectConfig{MinEngines: 1}, query: "github.com", data: site[1:2], want: "https://github.com/about"},
+		"engines of other sites not counted": {query: "github.com", data: []*result.Data{
+			{Engine: "bing", Url: "https://github.com/"},
+			{Engine: "google", Url: "https://en.wikipedia.org/wiki/GitHub"},
+		}},
+	}
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			if got := navigationalRedirect(c.conf, c.query, c.data); got != c.want {
+				t.Errorf("redirect url = %q, want %q", got, c.want)
+			}
+		})
+	}
+}
+
+func TestSearchRedirect(t *testing.T) {
+	cases := map[string]struct {
+		enable bool
+		query  string
+		pageNo int
+		want   string
+	}{
+		"domain":   {enable: true, query: "github.com", pageNo: 1, want: "https://github.com/"},
+		"word":     {enable: true, query: "github", pageNo: 1},
+		"page two": {enable: true, query: "github.com", pageNo: 2},
+		"disabled": {query: "github.com", pageNo: 1},
+	}
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			a := &mockEngine{name: "redirect_a", urls: []string{"https://github.com/"}}
+			b := &mockEngine{name: "redirect_b", urls: []string{"https://github.com/"}}
+			setupSearch(t, Config{Redirect: RedirectConfig{Enable: c.enable}}, map[string][]engine.Engine{engine.CategoryGeneral: {a, b}})
+
+			res := Search(context.Background(), engine.Options{Query: c.query, PageNo: c.pageNo, Category: engine.CategoryGeneral})
+			if res.RedirectURL != c.want {
+				t.Errorf("redirect url = %q, want %q", res.RedirectURL, c.want)
+			}
+		})
+	}
+}
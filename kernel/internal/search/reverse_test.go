@@ -0,0 +1,64 @@
+package search
+
+import (
+	"context"
+	"net/url"
+	"slices"
+	"testing"
+
+	"github.com/zvirgilx/searxng-go/kernel/internal/engine"
+)
+
+// reverseEngine links the image urls to its reverse search page.
+type reverseEngine struct {
+	*mockEngine
+}
+
+func (e *reverseEngine) ReverseImageURL(imageURL string, _ *engine.Options) string {
+	return "https://reverse.example.com/search?url=" + url.QueryEscape(imageURL)
+}
+
+func TestSearchReverseImage(t *testing.T) {
+	const image = "https://example.com/cat.jpg"
+	reverse := "https://reverse.example.com/search?url=" + url.QueryEscape(image)
+
+	cases := map[string]struct {
+		query       string
+		pageNo      int
+		want        []string
+		wantReverse int32
+	}{
+		// the engine without reverse search searches the image url as text.
+		"image url": {query: image, pageNo: 1, want: []string{reverse, "https://text.example.com/1"}},
+		// the reverse search page has a single page.
+		"next page":  {query: image, pageNo: 2, want: []string{"https://text.example.com/1"}},
+		"text query": {query: "cat", pageNo: 1, want: []string{"https://reverse.example.com/1", "https://text.example.com/1"}, wantReverse: 1},
+	}
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			r := &reverseEngine{&mockEngine{name: "reverse_lens", urls: []string{"https://reverse.example.com/1"}}}
+			text := &mockEngine{name: "reverse_text", urls: []string{"https://text.example.com/1"}}
+			setupSearch(t, Config{}, map[string][]engine.Engine{engine.CategoryImage: {r, text}})
+
+			res := Search(context.Background(), engine.Options{Query: c.query, PageNo: c.pageNo, Category: engine.CategoryImage})
+			got := dataUrls(res)
+			slices.Sort(got)
+			if !slices.Equal(got, c.want) {
+				t.Errorf("got %v, want %v", got, c.want)
+			}
+			// the image url is passed through without requesting the engine.
+			if n := r.calls.Load(); n != c.wantReverse {
+				t.Errorf("the reverse engine is requested %d times, want %d", n, c.wantReverse)
+			}
+			if text.calls.Load() != 1 {
+				t.Errorf("the text engine is requested %d times, want once", text.calls.Load())
+			}
+
+			for _, d := range res.GetData() {
+				if d.Url == reverse && (d.ImgSrc != image || d.Engine != "reverse_lens" || d.Category != engine.CategoryImage) {
+					t.Errorf("unexpected reverse search data %+v", d)
+				}
+			}
+		})
+	}
+}
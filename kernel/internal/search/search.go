@@ -4,76 +4,570 @@ import (
 	"context"
 	"errors"
 	"log/slog"
+	"net/http/cookiejar"
+	"slices"
 	"strconv"
+	"strings"
 	"sync"
 	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/zvirgilx/searxng-go/kernel/internal/analytics"
 	"github.com/zvirgilx/searxng-go/kernel/internal/engine"
-	"github.com/zvirgilx/searxng-go/kernel/internal/metrics"
+	"github.com/zvirgilx/searxng-go/kernel/internal/locale"
+	"github.com/zvirgilx/searxng-go/kernel/internal/network"
+	"github.com/zvirgilx/searxng-go/kernel/internal/prefs"
 	"github.com/zvirgilx/searxng-go/kernel/internal/result"
 	"github.com/zvirgilx/searxng-go/kernel/internal/util"
 )
 
+type Config struct {
+	// Timeout is the global deadline of a search, results arrived before the deadline are returned.
+	Timeout time.Duration `mapstructure:"timeout"`
+
+	// MaxResultsPerEngine is the default maximum of results of each engine, 0 means unlimited.
+	MaxResultsPerEngine int `mapstructure:"max_results_per_engine"`
+	// MaxResults is the default maximum of results of a search, 0 means unlimited.
+	MaxResults int `mapstructure:"max_results"`
+
+	// Budget allocates the deadline among engines, it requires timeout.
+	Budget BudgetConfig `mapstructure:"budget"`
+
+	// Redirect sets the redirect url of result for the clearly navigational queries, e.g. github.com.
+	Redirect RedirectConfig `mapstructure:"redirect"`
+
+	// AutoDisable disables the engines whose error rate over the last requests exceeds the threshold.
+	AutoDisable AutoDisableConfig `mapstructure:"auto_disable"`
+
+	// AdaptiveTimeout times out each engine by the p95 of its recent latencies.
+	AdaptiveTimeout AdaptiveTimeoutConfig `mapstructure:"adaptive_timeout"`
+
+	// TimeDecay is the half life of score of results by published date for categories, e.g. {"news": 24h}.
+	TimeDecay map[string]time.Duration `mapstructure:"time_decay"`
+
+	// Expansion expands the terms of query with synonyms to improve recall.
+	Expansion ExpansionConfig `mapstructure:"expansion"`
+
+	// Enrich fills out the thumbnail and content of top results by the open graph metadata of their pages.
+	Enrich EnrichConfig `mapstructure:"enrich"`
+
+	// Aliases are the short names of engines, e.g. {"g": "google"}, they override the built-in aliases.
+	Aliases map[string]string `mapstructure:"aliases"`
+
+	// ThumbnailSize is the default preferred size of thumbnails, e.g. small, medium, large.
+	ThumbnailSize string `mapstructure:"thumbnail_size"`
+
+	// MaxPageNo is the maximum of page number requested from engines, 0 means unlimited.
+	MaxPageNo int `mapstructure:"max_page_no"`
+
+	// AutoCorrectMinResults is the count of results below which the search is rerun with the correction of query.
+	AutoCorrectMinResults int `mapstructure:"auto_correct_min_results"`
+
+	// MinResults is the default count of results below which the fallback engines are searched, 0 disables it.
+	MinResults int `mapstructure:"min_results"`
+	// FallbackEngines are the engines of each category only searched if the results of other engines are fewer than MinResults.
+	// They must be enabled in the category as well.
+	FallbackEngines map[string][]string `mapstructure:"fallback_engines"`
+
+	// Trending allows searching without query, the trending items of engines implementing TrendingEngine are returned.
+	Trending bool `mapstructure:"trending"`
+
+	// AutodetectCategories suggests the categories from the query if the request does not specify any,
+	// e.g. music for "lyrics yesterday".
+	AutodetectCategories bool `mapstructure:"autodetect_categories"`
+
+	// LanguageFilter detects the languages of results, and drops the off-language ones if the language is requested.
+	LanguageFilter LanguageFilterConfig `mapstructure:"language_filter"`
+
+	// DetectLanguageThreshold is the minimum confidence of the language detected from query,
+	// the detected language is used if the language is not specified. 0 disables the detection.
+	DetectLanguageThreshold float64 `mapstructure:"detect_language_threshold"`
+
+	Cache CacheConfig `mapstructure:"cache"`
+
+	// StreamOrder emits the results of engines of higher priority first in the stream search.
+	StreamOrder StreamOrderConfig `mapstructure:"stream_order"`
+
+	// Singleflight shares the in-flight search of engine among the concurrent identical searches.
+	Singleflight bool `mapstructure:"singleflight"`
+
+	// DomainScores boost or penalize results by domain after results of engines are merged.
+	DomainScores []result.DomainScore `mapstructure:"domain_scores"`
+
+	// Running counts the engines searching if set, including the ones still running after the deadline of search,
+	// so that they can be waited for before the search is reset. It is set by code only.
+	Running *sync.WaitGroup `mapstructure:"-"`
+}
+
+var conf Config
+
+// cache is the cache of results of engines, nil if the cache is disabled.
+var cache *resultCache
+
+func InitConfig(c Config) {
+	conf = c
+	latencySamples.reset(c.AdaptiveTimeout.Window)
+
+	for alias, name := range c.Aliases {
+		engine.RegisterAlias(alias, name)
+	}
+
+	cache = nil
+	if c.Cache.TTL > 0 {
+		cache = newResultCache(c.Cache)
+		Use(cache.middleware)
+	}
+	// the identical searches are shared inside the cache, so that only the misses are shared.
+	if c.Singleflight {
+		Use(newFlightGroup(c.Timeout).middleware)
+	}
+	// the outcomes are recorded inside the cache, so that only the requests of engines are counted.
+	if c.AutoDisable.Enable {
+		Use(newFailureTracker(c.AutoDisable).middleware)
+	}
+}
+
+// ExpansionConfig is the configuration of query expansion.
+type ExpansionConfig struct {
+	Enable bool `mapstructure:"enable"`
+
+	// Dictionary is the synonyms of terms, e.g. {"js": ["javascript"]}, the terms are lowercase.
+	Dictionary map[string][]string `mapstructure:"dictionary"`
+}
+
+// engineResult is the result of an engine, res is nil if the engine failed.
+type engineResult struct {
+	name string
+	res  *result.Result
+	err  error
+}
+
+// errEnginePanic is the error of an engine panicked during the search.
+var errEnginePanic = errors.New("engine panicked")
+
+// Progress is called as each engine finishes, partial is the result of the engine, it is nil if err is not nil.
+// The engines not finished before the deadline are called with the error of context.
+// It is never called concurrently.
+type Progress func(engineName string, partial *result.Result, err error)
+
 func Search(ctx context.Context, options engine.Options) *result.Result {
+	return SearchWithProgress(ctx, options, nil)
+}
+
+// SearchWithProgress searches like Search, and reports the progress of engines by progress if it is not nil.
+// The event of search is recorded if analytics is enabled.
+func SearchWithProgress(ctx context.Context, options engine.Options, progress Progress) *result.Result {
+	if !analytics.Enabled() {
+		return searchWithProgress(ctx, options, progress)
+	}
+	return recordSearch(ctx, options, progress)
+}
+
+func searchWithProgress(ctx context.Context, options engine.Options, progress Progress) *result.Result {
 	log := slog.With("func", "search.Search")
 
 	log.InfoContext(ctx, "starting search", "query", options.Query)
 
-	enableEngines := engine.GetEnginesByCategory(options.Category)
-	if len(enableEngines) == 0 {
-		log.WarnContext(ctx, "engines not found", "category", options.Category)
+	enableEngines := getEnginesByCategories(options)
+	if len(enableEngines) == 0 && len(getFallbackEngines(options)) == 0 {
+		log.WarnContext(ctx, "engines not found", "categories", options.Categories)
 		return &result.Result{}
 	}
 
-	resCh := make(chan *result.Result, len(enableEngines))
+	if options.MaxResultsPerEngine == 0 {
+		options.MaxResultsPerEngine = conf.MaxResultsPerEngine
+	}
+	if options.MaxResults == 0 {
+		options.MaxResults = conf.MaxResults
+	}
+	if options.MinResults == 0 {
+		options.MinResults = conf.MinResults
+	}
 
-	w := &sync.WaitGroup{}
-	for _, e := range enableEngines {
-		w.Add(1)
+	// a single engine selected explicitly keeps its own order, merging the results of one engine only reorders them.
+	single := len(options.Engines) == 1 && len(enableEngines) == 1
+	gather := merge
+	if single {
+		gather = searchSingle
+		// the results are truncated at last rather than by the engine, which sorts them by relevance.
+		if options.MaxResultsPerEngine > 0 && (options.MaxResults == 0 || options.MaxResultsPerEngine < options.MaxResults) {
+			options.MaxResults = options.MaxResultsPerEngine
+		}
+		options.MaxResultsPerEngine = 0
+	}
+
+	res, succeeded := gather(ctx, options, enableEngines, progress)
+
+	// the fallback engines fill out the results if the primary engines returned too few.
+	if !single && res.GetDataSize() < options.MinResults {
+		if fallbacks := getFallbackEngines(options); len(fallbacks) > 0 {
+			log.InfoContext(ctx, "search fallback engines", "query", options.Query, "results", res.GetDataSize())
+			fallbackRes, fallbackSucceeded := merge(ctx, options, fallbacks, progress)
+			res.Merge(fallbackRes)
+			succeeded += fallbackSucceeded
+			enableEngines = append(enableEngines, fallbacks...)
+		}
+	}
+
+	// the search is rerun with the top correction only once, because the rerun does not correct again.
+	if options.AutoCorrect && !options.Verbatim && res.GetDataSize() < conf.AutoCorrectMinResults && len(res.Corrections) > 0 {
+		corrected := options
+		corrected.Query = res.Corrections[0]
+		corrected.AutoCorrect = false
+
+		log.InfoContext(ctx, "rerun search with correction", "query", options.Query, "correction", corrected.Query)
+		correctedRes, _ := gather(ctx, corrected, enableEngines, progress)
+		res.Merge(correctedRes)
+		res.CorrectedQuery = corrected.Query
+	}
+
+	// all engines failed, e.g. an upstream outage, the stale results of cache are served instead of an empty page.
+	if succeeded == 0 && cache != nil && conf.Cache.StaleTTL > 0 {
+		if stale := cache.staleResult(options, enableEngines, single); stale != nil {
+			log.WarnContext(ctx, "serve stale results since all engines failed", "query", options.Query)
+			stale.TimedOutEngines = res.TimedOutEngines
+			res = stale
+		}
+	}
+
+	filterNSFW(res, options.SafeSearch)
+	res.FilterByTag(options.Tags, options.ExcludeTags)
+	// the results in other languages than the requested one are dropped.
+	if conf.LanguageFilter.Enable {
+		detectResultLanguages(conf.LanguageFilter, res)
+		if options.LanguageSpecified && options.Locale != "" {
+			res.FilterByLanguage([]string{options.Locale})
+		}
+	}
+	res.ApplyDomainScores(conf.DomainScores)
+	// the fresher results are boosted in the categories sensitive to freshness, e.g. news.
+	if halfLife, ok := conf.TimeDecay[options.Category]; ok {
+		res.ApplyTimeDecay(halfLife, time.Now())
+	}
+	res = truncate(res, options)
+	res.Query = options.Query
+	if conf.Redirect.Enable && options.PageNo <= 1 {
+		res.RedirectURL = navigationalRedirect(conf.Redirect, options.Query, res.GetSortedData())
+	}
+
+	// the results are enriched after truncated, so that only the returned results are fetched.
+	if conf.Enrich.Enable {
+		enrich(ctx, conf.Enrich, res)
+	}
+	return res
+}
+
+// filterNSFW tags the adult results for moderate safe search, and drops them for strict one.
+func filterNSFW(res *result.Result, safeSearch int) {
+	if safeSearch == engine.SafeSearchNone {
+		return
+	}
+	res.FilterNSFW(safeSearch == engine.SafeSearchStrict)
+}
+
+// searchSingle searches by a single engine like merge, but its result keeps the order of engine,
+// so that it is filtered and truncated as the others without sorted by relevance.
+func searchSingle(ctx context.Context, options engine.Options, enableEngines []categoryEngine, progress Progress) (*result.Result, int) {
+	res := result.CreateResult("", options.PageNo)
+	succeeded := 0
+	timedOut := fanOut(ctx, options, enableEngines, func(name string, r *result.Result, err error) {
+		if err == nil {
+			succeeded++
+		}
+		if r != nil {
+			res = r
+		}
+		if progress != nil {
+			progress(name, r, err)
+		}
+	})
+	res.TimedOutEngines = timedOut
+	res.KeepOrder = true
+	return res, succeeded
+}
+
+// merge searches by the engines, and merges the results arrived before the deadline.
+// The count of engines succeeded is reported as well.
+func merge(ctx context.Context, options engine.Options, enableEngines []categoryEngine, progress Progress) (*result.Result, int) {
+	res := result.CreateResult("", options.PageNo)
+	succeeded := 0
+	res.TimedOutEngines = fanOut(ctx, options, enableEngines, func(name string, r *result.Result, err error) {
+		if err == nil {
+			succeeded++
+		}
+		if r != nil {
+			res.Merge(r)
+		}
+		if progress != nil {
+			progress(name, r, err)
+		}
+	})
+	return res, succeeded
+}
+
+// fanOut searches by the engines concurrently, onResult is called with the result of each engine once it returns,
+// the calls are serialized. The engines not finished before the deadline are called with the error of context and returned.
+func fanOut(ctx context.Context, options engine.Options, enableEngines []categoryEngine, onResult func(string, *result.Result, error)) []string {
+	log := slog.With("func", "search.fanOut")
+
+	if conf.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, conf.Timeout)
+		defer cancel()
+	}
+
+	// the remaining deadline is allocated among engines by their recorded latency.
+	var timeouts map[string]time.Duration
+	if deadline, ok := ctx.Deadline(); ok && conf.Budget.Enable {
+		names := make([]string, 0, len(enableEngines))
+		for _, ce := range enableEngines {
+			names = append(names, ce.engine.GetName())
+		}
+		timeouts = allocateBudget(conf.Budget, time.Until(deadline), names, latencies.get)
+	}
+	// the adaptive timeout of engine only shortens the one of budget.
+	if conf.AdaptiveTimeout.Enable {
+		if timeouts == nil {
+			timeouts = map[string]time.Duration{}
+		}
+		for _, ce := range enableEngines {
+			name := ce.engine.GetName()
+			timeout, ok := adaptiveTimeout(conf.AdaptiveTimeout, latencySamples, name)
+			if !ok {
+				continue
+			}
+			if budget, ok := timeouts[name]; ok {
+				timeout = min(timeout, budget)
+			}
+			timeouts[name] = timeout
+		}
+	}
+
+	// the channel is buffered, so that engines finished after the deadline will not be blocked.
+	resCh := make(chan engineResult, len(enableEngines))
+
+	pending := map[string]bool{}
+	running := conf.Running
+	for _, ce := range enableEngines {
+		pending[ce.engine.GetName()] = true
+
+		// each engine searches with its own category.
+		opts := options
+		opts.Category = ce.category
+		if running != nil {
+			running.Add(1)
+		}
 		go func(opts engine.Options, e engine.Engine) {
-			defer w.Done()
+			if running != nil {
+				defer running.Done()
+			}
+			er := engineResult{name: e.GetName(), err: errEnginePanic}
+			defer func() { resCh <- er }()
 			defer util.RecoverFromPanic()
-			r, err := process(ctx, opts, e)
-			if err != nil {
-				log.ErrorContext(ctx, "process error", slog.String("engine", e.GetName()), slog.String("err", err.Error()))
-				return
+
+			ctx := ctx
+			if timeout, ok := timeouts[e.GetName()]; ok {
+				var cancel context.CancelFunc
+				ctx, cancel = context.WithTimeout(ctx, timeout)
+				defer cancel()
 			}
 
-			resCh <- r
-		}(options, e)
+			er.res, er.err = process(ctx, opts, e)
+			if er.err != nil {
+				log.ErrorContext(ctx, "process error", slog.String("engine", e.GetName()), slog.String("err", er.err.Error()))
+				er.res = nil
+			}
+		}(opts, ce.engine)
 	}
-	w.Wait()
-	close(resCh)
 
-	result := result.CreateResult("", options.PageNo)
-	for r := range resCh {
-		if r == nil {
-			continue
+	// wait for engines until all of them are finished or the deadline is exceeded.
+	for len(pending) > 0 {
+		select {
+		case er := <-resCh:
+			delete(pending, er.name)
+			onResult(er.name, er.res, er.err)
+		case <-ctx.Done():
+			var timedOut []string
+			for name := range pending {
+				timedOut = append(timedOut, name)
+				onResult(name, nil, ctx.Err())
+			}
+			log.WarnContext(ctx, "search deadline exceeded", slog.Any("timedOutEngines", timedOut))
+			return timedOut
 		}
-		result.Merge(r)
 	}
 
-	return result
+	return nil
 }
 
-func process(ctx context.Context, options engine.Options, e engine.Engine) (res *result.Result, err error) {
-	log := slog.With("func", "search.process")
+// Stream searches by the engines like Search, but the data are emitted as each engine returns
+// instead of waiting for all engines, so that they are not sorted or truncated across engines.
+// The engines of higher priority in conf.StreamOrder are emitted first within its window.
+// The channel is closed once all engines are finished, the deadline is exceeded or ctx is done.
+func Stream(ctx context.Context, options engine.Options) <-chan *result.Data {
+	ch := make(chan *result.Data)
 
-	start := time.Now()
+	enableEngines := getEnginesByCategories(options)
+	if options.MaxResultsPerEngine == 0 {
+		options.MaxResultsPerEngine = conf.MaxResultsPerEngine
+	}
 
-	defer func() {
-		status := "ok"
-		if err != nil {
-			status = "error"
-		}
+	names := make([]string, 0, len(enableEngines))
+	for _, ce := range enableEngines {
+		names = append(names, ce.engine.GetName())
+	}
+	// the channel is buffered, so that fanOut is never blocked by the reordering.
+	arrivals := make(chan streamArrival, len(enableEngines))
+
+	go func() {
+		defer close(arrivals)
+		defer util.RecoverFromPanic()
+
+		seq := 0
+		fanOut(ctx, options, enableEngines, func(name string, r *result.Result, err error) {
+			arrivals <- streamArrival{name: name, res: r, seq: seq}
+			seq++
+		})
+	}()
 
-		metrics.EnginesResponseCounter.WithLabelValues(e.GetName(), status).Observe(time.Since(start).Seconds())
-		metrics.EnginesSearchResultCounter.WithLabelValues(e.GetName()).Add(float64(res.GetDataSize()))
+	go func() {
+		defer close(ch)
+		defer util.RecoverFromPanic()
 
+		reorder(ctx, conf.StreamOrder, names, arrivals, ch)
 	}()
+	return ch
+}
+
+// truncate keeps the top results of search after sorting.
+func truncate(r *result.Result, options engine.Options) *result.Result {
+	if options.MaxResults > 0 {
+		r.SortBy(options.SortBy)
+		r.Truncate(options.MaxResults)
+	}
+	return r
+}
+
+// categoryEngine is an engine with the category it is searched for.
+type categoryEngine struct {
+	category string
+	engine   engine.Engine
+}
+
+// getEnginesByCategories gets the union of enable engines of the searched categories except the fallback engines.
+// An engine enabled in several categories is searched only once for the first category.
+func getEnginesByCategories(options engine.Options) []categoryEngine {
+	return getEngines(options, false)
+}
+
+// getFallbackEngines gets the union of fallback engines of the searched categories.
+func getFallbackEngines(options engine.Options) []categoryEngine {
+	return getEngines(options, true)
+}
+
+// suggestCategories suggests the categories of query, the categories without enabled engines are dropped.
+func suggestCategories(q string) []string {
+	var categories []string
+	for _, category := range engine.SuggestCategories(q) {
+		if len(engine.GetEnginesByCategory(category)) > 0 {
+			categories = append(categories, category)
+		}
+	}
+	return categories
+}
+
+func getEngines(options engine.Options, fallback bool) []categoryEngine {
+	categories := options.Categories
+	if len(categories) == 0 {
+		categories = []string{options.Category}
+	}
+
+	var engines []categoryEngine
+	searched := map[string]bool{}
+	for _, category := range categories {
+		for name, e := range engine.GetEnginesByCategory(category) {
+			if searched[name] || slices.Contains(conf.FallbackEngines[category], name) != fallback || !engine.IsEnabled(name) {
+				continue
+			}
+			if len(options.Engines) > 0 && !slices.Contains(options.Engines, name) {
+				continue
+			}
+			// the engines are not requested for the queries too short for them.
+			if !engine.AcceptsQuery(e, options.Query) {
+				continue
+			}
+			searched[name] = true
+			engines = append(engines, categoryEngine{category: category, engine: e})
+		}
+	}
+	return engines
+}
+
+// process searches by the engine with the middlewares.
+func process(ctx context.Context, options engine.Options, e engine.Engine) (*result.Result, error) {
+	h := engine.Chain(e, func(ctx context.Context, opts *engine.Options) (*result.Result, error) {
+		start := time.Now()
+		res, err := request(ctx, *opts, e)
+		// only the requests of upstream are recorded, rather than the results served by the cache or skipped by the engine.
+		// the latency of engine cut by the deadline is at least the elapsed time, so it is recorded as well.
+		if err == nil && res != nil || ctx.Err() != nil {
+			elapsed := time.Since(start)
+			latencies.record(e.GetName(), elapsed)
+			latencySamples.record(e.GetName(), elapsed)
+		}
+		return res, err
+	}, middlewares...)
+	return h(ctx, &options)
+}
+
+// SearchEngine searches by a single engine without the middlewares, e.g. the cache,
+// so that the engine is exercised live for debugging.
+func SearchEngine(ctx context.Context, options engine.Options, e engine.Engine) (*result.Result, error) {
+	return request(ctx, options, e)
+}
+
+// request requests the engine and parses the response.
+func request(ctx context.Context, options engine.Options, e engine.Engine) (res *result.Result, err error) {
+	log := slog.With("func", "search.request")
+
+	// a new cookie jar is created for each search.
+	if se, ok := e.(engine.SessionEngine); ok && se.NeedSession() {
+		if options.CookieJar, err = cookiejar.New(nil); err != nil {
+			return nil, err
+		}
+	}
+
+	engine.NormalizeOptions(&options, conf.MaxPageNo)
+
+	// params not supported by the engine are not sent.
+	if !engine.ApplyCapabilities(e, &options) {
+		return nil, nil
+	}
 
-	if err = e.Request(ctx, &options); err != nil {
+	// the query is expanded with synonyms for engines supporting the OR syntax.
+	if conf.Expansion.Enable && !options.Verbatim && engine.SupportsQueryExpansion(e) {
+		options.Query = engine.ExpandQuery(options.Query, conf.Expansion.Dictionary)
+	}
+
+	// the image urls are passed through to the reverse search page of engines supporting it, rather than searched as text.
+	if re, ok := e.(engine.ReverseImageEngine); ok && engine.IsImageURL(options.Query) {
+		return reverseImageResult(e.GetName(), options, re.ReverseImageURL(options.Query, &options)), nil
+	}
+
+	// the trending items are requested without query if trending is enabled, engines not supporting it are skipped.
+	if options.Query == "" && conf.Trending {
+		te, ok := e.(engine.TrendingEngine)
+		if !ok {
+			return nil, nil
+		}
+		if err = te.Trending(ctx, &options); err != nil {
+			return nil, err
+		}
+	} else if err = e.Request(ctx, &options); err != nil {
+		return nil, err
+	}
+
+	if err = engine.ValidateRequest(&options); err != nil {
 		return nil, err
 	}
 
@@ -82,33 +576,233 @@ func process(ctx context.Context, options engine.Options, e engine.Engine) (res
 		return nil, nil
 	}
 
+	if options.CookieJar != nil {
+		req.Jar(options.CookieJar)
+	}
+
 	r := req.Do(ctx)
 	if r.Err != nil {
 		log.ErrorContext(ctx, "request engine error", slog.String("engine", e.GetName()), slog.String("err", r.Err.Error()))
 		return nil, r.Err
 	}
 
+	if err = engine.ValidateResponse(e, &options, r.ContentType, r.Body); err != nil {
+		return nil, err
+	}
+
 	res, err = e.Response(ctx, &options, r.Body)
 	if err != nil {
 		return nil, err
 	}
 
+	// the engines requesting in two steps are followed up by the first response, e.g. the summaries of searched ids.
+	if fe, ok := e.(engine.FollowUpEngine); ok && res != nil {
+		if err = followUp(ctx, options, fe, res, r.Body); err != nil {
+			log.ErrorContext(ctx, "follow up engine error", slog.String("engine", e.GetName()), slog.String("err", err.Error()))
+			return nil, err
+		}
+	}
+
+	// the redirect links of results are resolved after the response is parsed, e.g. https://www.baidu.com/link?url=...
+	if re, ok := e.(engine.RedirectEngine); ok && res != nil {
+		followUpRedirects(ctx, options, re, res)
+	}
+
+	// the rank of engine is kept before the results are filtered, truncated and merged.
+	res.AssignPositions()
+
+	// the relative urls of results are resolved, so that every engine returns absolute urls.
+	var baseUrl string
+	if ce, ok := e.(engine.CapableEngine); ok {
+		baseUrl = ce.Capabilities().BaseUrl
+	}
+	res.AbsolutizeURLs(baseUrl)
+
+	// the results of engine without native operators support are filtered by operators.
+	if !options.Operators.IsEmpty() {
+		res.Filter(func(d *result.Data) bool {
+			return options.Operators.Match(d.Url) && !options.Operators.Excluded(d.Title, d.Content, d.Url)
+		})
+	}
+
+	// the results of engine are truncated before merged.
+	if options.MaxResultsPerEngine > 0 && res != nil {
+		res.SortBy(result.SortByRelevance)
+		res.Truncate(options.MaxResultsPerEngine)
+	}
+
+	// tag the data with the searched category, so that they can be grouped by category.
+	for _, d := range res.GetData() {
+		d.Category = options.Category
+	}
+
 	return res, nil
 }
 
+// followUp requests the follow-up of engine and parses its response into the result of first response.
+func followUp(ctx context.Context, options engine.Options, fe engine.FollowUpEngine, res *result.Result, first []byte) error {
+	options.Request = nil
+	if err := fe.FollowUp(ctx, &options, res, first); err != nil {
+		return err
+	}
+	if err := engine.ValidateRequest(&options); err != nil {
+		return err
+	}
+
+	req := options.Request
+	if req == nil {
+		return nil
+	}
+	if options.CookieJar != nil {
+		req.Jar(options.CookieJar)
+	}
+
+	r := req.Do(ctx)
+	if r.Err != nil {
+		return r.Err
+	}
+	return fe.FollowUpResponse(ctx, &options, res, r.Body)
+}
+
+// redirectWorkers is the count of redirect links of an engine followed up concurrently.
+const redirectWorkers = 4
+
+// followUpRedirects replaces the redirect links of results with their locations by a bounded pool of workers.
+// The links failed or not resolved before the search is canceled are kept.
+func followUpRedirects(ctx context.Context, options engine.Options, re engine.RedirectEngine, res *result.Result) {
+	log := slog.With("func", "search.followUpRedirects")
+
+	type redirect struct {
+		d   *result.Data
+		req *network.Request
+	}
+	redirects := make(chan redirect)
+	w := &sync.WaitGroup{}
+	for i := 0; i < redirectWorkers; i++ {
+		w.Add(1)
+		go func() {
+			defer w.Done()
+			for r := range redirects {
+				if options.CookieJar != nil {
+					r.req.Jar(options.CookieJar)
+				}
+				resp := r.req.NoRedirect().Do(ctx)
+				if resp.Err != nil {
+					log.DebugContext(ctx, "failed to follow up redirect", slog.String("engine", re.GetName()), slog.String("url", r.d.Url), slog.String("err", resp.Err.Error()))
+					continue
+				}
+				if resp.Location == "" {
+					continue
+				}
+				// the relative locations are resolved against the redirect link.
+				loc, err := r.req.URL().Parse(resp.Location)
+				if err != nil {
+					continue
+				}
+				r.d.Url = loc.String()
+			}
+		}()
+	}
+
+send:
+	for _, d := range res.GetData() {
+		req := re.FollowUpRedirect(&options, d)
+		if req == nil {
+			continue
+		}
+		select {
+		case redirects <- redirect{d: d, req: req}:
+		case <-ctx.Done():
+			break send
+		}
+	}
+	close(redirects)
+	w.Wait()
+}
+
+// reverseImageResult is the result linking to the reverse search page of image url, it has a single page.
+func reverseImageResult(name string, options engine.Options, reverseURL string) *result.Result {
+	res := result.CreateResult(name, options.PageNo)
+	if options.PageNo > 1 || reverseURL == "" {
+		return res
+	}
+	res.AppendData(&result.Data{
+		Engine:    name,
+		Title:     "Search by image on " + name,
+		Url:       reverseURL,
+		Content:   options.Query,
+		ImgSrc:    options.Query,
+		Thumbnail: options.Query,
+		Category:  options.Category,
+		Query:     options.Query,
+	})
+	return res
+}
+
 func VerifySearchOptions(c *gin.Context) (engine.Options, error) {
-	q, ok := c.GetQuery("q")
+	return verifySearchOptions(c.GetQuery, c.ClientIP())
+}
+
+// searxParams are the params of searxng api different from ours, e.g. /search?pageno=2&safesearch=1.
+var searxParams = map[string]string{
+	"page_no":     "pageno",
+	"safe_search": "safesearch",
+}
+
+// VerifySearxOptions verifies the search options by params of searxng api.
+func VerifySearxOptions(c *gin.Context) (engine.Options, error) {
+	return verifySearchOptions(func(name string) (string, bool) {
+		if alias, ok := searxParams[name]; ok {
+			name = alias
+		}
+		return c.GetQuery(name)
+	}, c.ClientIP())
+}
+
+// verifySearchOptions verifies the search options by the params got from getQuery.
+func verifySearchOptions(getQuery func(string) (string, bool), clientIP string) (engine.Options, error) {
+	// the preferences of token are the defaults of params, e.g. /search?q=test&prefs=eyJ2IjoxLCJsIjoiZW4tVVMifQ.
+	if t, ok := getQuery("prefs"); ok && t != "" {
+		p, err := prefs.Decode(t)
+		if err != nil {
+			return engine.Options{}, err
+		}
+		getParam := getQuery
+		getQuery = func(name string) (string, bool) {
+			if v, ok := getParam(name); ok {
+				return v, true
+			}
+			return p.Param(name)
+		}
+	}
+
+	query := func(name string) string {
+		v, _ := getQuery(name)
+		return v
+	}
+
+	q, ok := getQuery("q")
 	if !ok {
 		return engine.Options{}, errors.New("empty query input")
 	}
+	// the blank query searches the trending items if trending is enabled.
+	if conf.Trending {
+		q = strings.TrimSpace(q)
+	}
 
-	lang, ok := c.GetQuery("language")
-	if !ok {
-		lang = "en-US"
+	// the default language is detected from the query, or derived from the client ip if geoip is enabled.
+	lang, languageSpecified := getQuery("language")
+	if !languageSpecified {
+		lang = locale.FromIP(clientIP, "en-US")
+		if l, confidence := engine.DetectLanguage(q); conf.DetectLanguageThreshold > 0 && confidence >= conf.DetectLanguageThreshold {
+			if detected := engine.LocaleOfLanguage(l); detected != "" {
+				lang = detected
+			}
+		}
 	}
 
 	pageNum := 1
-	pageNo, ok := c.GetQuery("page_no")
+	pageNo, ok := getQuery("page_no")
 	if ok {
 		num, err := strconv.Atoi(pageNo)
 		if err != nil || num < 0 {
@@ -117,15 +811,129 @@ func VerifySearchOptions(c *gin.Context) (engine.Options, error) {
 		pageNum = num
 	}
 
-	category, ok := c.GetQuery("category")
+	category, categorySpecified := getQuery("category")
+	if !categorySpecified {
+		category = engine.CategoryGeneral
+	}
+
+	// multiple categories are separated by comma, e.g. general,news.
+	categories := []string{category}
+	cs, ok := getQuery("categories")
+	if list := splitList(cs); len(list) > 0 {
+		categories = list
+		category = categories[0]
+	} else if !ok && !categorySpecified && conf.AutodetectCategories {
+		// the categories are suggested from the query if none is specified.
+		if suggested := suggestCategories(q); len(suggested) > 0 {
+			categories = suggested
+			category = categories[0]
+		}
+	}
+
+	// multiple engines are separated by comma, the aliases of engines are resolved, e.g. g,wikipedia.
+	var engines []string
+	if es := query("engines"); es != "" {
+		for _, name := range splitList(es) {
+			canonical, err := engine.ResolveEngineName(name)
+			if err != nil {
+				return engine.Options{}, err
+			}
+			// the aliases of the same engine are searched once.
+			if !slices.Contains(engines, canonical) {
+				engines = append(engines, canonical)
+			}
+		}
+	}
+
+	timeRange := query("time_range")
+	if _, _, ok := engine.TimeRangeBounds(timeRange, time.Now()); timeRange != "" && !ok {
+		return engine.Options{}, errors.New("time range error")
+	}
+
+	safeSearch := engine.SafeSearchNone
+	if ss, ok := getQuery("safe_search"); ok {
+		num, err := strconv.Atoi(ss)
+		if err != nil || num < engine.SafeSearchNone || num > engine.SafeSearchStrict {
+			return engine.Options{}, errors.New("safe search level error")
+		}
+		safeSearch = num
+	}
+
+	// the cached results older than max age are refetched, e.g. 10m.
+	var maxAge time.Duration
+	if ma, ok := getQuery("max_age"); ok {
+		d, err := time.ParseDuration(ma)
+		if err != nil || d < 0 {
+			return engine.Options{}, errors.New("max age error")
+		}
+		maxAge = d
+	}
+
+	// multiple tags are separated by comma, e.g. video,verified.
+	tags, excludeTags := splitList(query("tags")), splitList(query("exclude_tags"))
+
+	autoCorrect := false
+	if ac, ok := getQuery("auto_correct"); ok {
+		b, err := strconv.ParseBool(ac)
+		if err != nil {
+			return engine.Options{}, errors.New("auto correct error")
+		}
+		autoCorrect = b
+	}
+
+	thumbnailSize, ok := getQuery("thumbnail_size")
+	if !ok {
+		thumbnailSize = conf.ThumbnailSize
+	}
+	if thumbnailSize != "" && !engine.IsThumbnailSize(thumbnailSize) {
+		return engine.Options{}, errors.New("thumbnail size error")
+	}
+
+	verbatim := false
+	if v, ok := getQuery("verbatim"); ok {
+		b, err := strconv.ParseBool(v)
+		if err != nil {
+			return engine.Options{}, errors.New("verbatim error")
+		}
+		verbatim = b
+	}
+
+	sortBy, ok := getQuery("sort_by")
 	if !ok {
-		category = "general"
+		sortBy = result.SortByRelevance
+	}
+	if !slices.Contains([]string{result.SortByRelevance, result.SortByDate, result.SortByEnginePriority}, sortBy) {
+		return engine.Options{}, errors.New("sort strategy error")
 	}
 
 	return engine.Options{
-		Query:    q,
-		PageNo:   pageNum,
-		Locale:   lang,
-		Category: category,
+		Query:             q,
+		PageNo:            pageNum,
+		Locale:            lang,
+		LanguageSpecified: languageSpecified,
+		TimeRange:         timeRange,
+		SafeSearch:        safeSearch,
+		Category:          category,
+		Categories:        categories,
+		Engines:           engines,
+		SortBy:            sortBy,
+		AutoCorrect:       autoCorrect,
+		Verbatim:          verbatim,
+		ThumbnailSize:     thumbnailSize,
+		Tags:              tags,
+		MaxAge:            maxAge,
+		ExcludeTags:       excludeTags,
 	}, nil
 }
+
+// splitList splits the list separated by comma, the items are trimmed, and the empty and repeated ones are dropped,
+// e.g. " news, ,general,news" -> [news general].
+func splitList(s string) []string {
+	var items []string
+	for _, item := range strings.Split(s, ",") {
+		if item = strings.TrimSpace(item); item != "" && !slices.Contains(items, item) {
+			items = append(items, item)
+		}
+	}
+	return items
+}
@@ -0,0 +1,217 @@
+package search
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/zvirgilx/searxng-go/kernel/internal/engine"
+	"github.com/zvirgilx/searxng-go/kernel/internal/network"
+	"github.com/zvirgilx/searxng-go/kernel/internal/result"
+)
+
+// followUpEngine searches the ids on /ids, then follows up with their titles on /titles.
+type followUpEngine struct {
+	base   *url.URL
+	client *network.Client
+}
+
+func (e *followUpEngine) Request(_ context.Context, opts *engine.Options) error {
+	opts.Request = e.client.Get().Base(e.base).Path("/ids").Param("q", opts.Query)
+	return nil
+}
+
+func (e *followUpEngine) Response(_ context.Context, opts *engine.Options, _ []byte) (*result.Result, error) {
+	return result.CreateResult(e.GetName(), opts.PageNo), nil
+}
+
+func (e *followUpEngine) FollowUp(_ context.Context, opts *engine.Options, _ *result.Result, resp []byte) error {
+	if len(resp) == 0 {
+		return nil
+	}
+	opts.Request = e.client.Get().Base(e.base).Path("/titles").Param("ids", string(resp))
+	return nil
+}
+
+func (e *followUpEngine) FollowUpResponse(_ context.Context, opts *engine.Options, res *result.Result, resp []byte) error {
+	for _, title := range strings.Split(string(resp), ",") {
+		res.AppendData(&result.Data{Engine: e.GetName(), Title: title, Url: e.base.String() + "/" + title})
+	}
+	return nil
+}
+
+func (e *followUpEngine) GetName() string { return "follow_up" }
+
+func (e *followUpEngine) ApplyConfig(engine.Config) error { return nil }
+
+func TestRequestFollowUp(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain")
+		switch r.URL.Path {
+		case "/ids":
+			if r.URL.Query().Get("q") == "go" {
+				w.Write([]byte("1,2"))
+			}
+		case "/titles":
+			w.Write([]byte(r.URL.Query().Get("ids")))
+		}
+	}))
+	defer srv.Close()
+
+	base, _ := url.Parse(srv.URL)
+	e := &followUpEngine{base: base, client: network.NewClient(&network.Config{})}
+
+	res, err := SearchEngine(context.Background(), engine.Options{Query: "go", PageNo: 1}, e)
+	if err != nil {
+		t.Fatal(err)
+	}
+	data := res.GetData()
+	if len(data) != 2 || data[0].Title != "1" || data[1].Title != "2" {
+		t.Fatalf("the follow-up is not parsed, got %+v", data)
+	}
+	// the data of follow-up are post-processed as the first response, e.g. ranked.
+	if data[0].Position != 1 || data[1].Position != 2 {
+		t.Errorf("positions = %d, %d, want 1, 2", data[0].Position, data[1].Position)
+	}
+
+	// no follow-up is requested if nothing is found.
+	res, err = SearchEngine(context.Background(), engine.Options{Query: "nothing", PageNo: 1}, e)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n := res.GetDataSize(); n != 0 {
+		t.Errorf("got %d data, want 0", n)
+	}
+}
+
+// redirectEngine links its results to the redirects on /link of base, the last result links to the page directly.
+type redirectEngine struct {
+	base   *url.URL
+	client *network.Client
+	links  int
+}
+
+func (e *redirectEngine) Request(_ context.Context, opts *engine.Options) error {
+	opts.Request = e.client.Get().Base(e.base).Path("/search")
+	return nil
+}
+
+func (e *redirectEngine) Response(_ context.Context, opts *engine.Options, _ []byte) (*result.Result, error) {
+	res := result.CreateResult(e.GetName(), opts.PageNo)
+	for i := 0; i < e.links; i++ {
+		res.AppendData(&result.Data{Engine: e.GetName(), Title: strconv.Itoa(i), Url: e.base.String() + "/link?to=" + strconv.Itoa(i)})
+	}
+	res.AppendData(&result.Data{Engine: e.GetName(), Title: "page", Url: e.base.String() + "/page"})
+	return res, nil
+}
+
+func (e *redirectEngine) FollowUpRedirect(_ *engine.Options, d *result.Data) *network.Request {
+	u, _ := url.Parse(d.Url)
+	if u.Path != "/link" {
+		return nil
+	}
+	return e.client.Get().Method(http.MethodHead).Base(e.base).Path(u.Path).Param("to", u.Query().Get("to"))
+}
+
+func (e *redirectEngine) GetName() string { return "redirect_links" }
+
+func (e *redirectEngine) ApplyConfig(engine.Config) error { return nil }
+
+// newRedirectServer redirects /link?to=n to /target/n after the delay, the links to fail are answered with an error.
+func newRedirectServer(t *testing.T, delay time.Duration, maxActive *atomic.Int32) *httptest.Server {
+	t.Helper()
+	var active atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/link" {
+			w.Header().Set("Content-Type", "text/plain")
+			return
+		}
+		n := active.Add(1)
+		defer active.Add(-1)
+		for {
+			m := maxActive.Load()
+			if n <= m || maxActive.CompareAndSwap(m, n) {
+				break
+			}
+		}
+
+		select {
+		case <-time.After(delay):
+		case <-r.Context().Done():
+			return
+		}
+		to := r.URL.Query().Get("to")
+		if to == "3" {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Location", "/target/"+to)
+		w.WriteHeader(http.StatusFound)
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestRequestFollowUpRedirects(t *testing.T) {
+	var maxActive atomic.Int32
+	srv := newRedirectServer(t, 10*time.Millisecond, &maxActive)
+	base, _ := url.Parse(srv.URL)
+	e := &redirectEngine{base: base, client: network.NewClient(&network.Config{}), links: 12}
+
+	res, err := SearchEngine(context.Background(), engine.Options{Query: "go", PageNo: 1}, e)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if m := maxActive.Load(); m > redirectWorkers {
+		t.Errorf("%d redirects are followed up concurrently, want at most %d", m, redirectWorkers)
+	}
+
+	data := res.GetData()
+	if len(data) != e.links+1 {
+		t.Fatalf("got %d data, want %d", len(data), e.links+1)
+	}
+	for _, d := range data {
+		want := srv.URL + "/target/" + d.Title
+		switch d.Title {
+		case "3":
+			// the link failed to resolve is kept.
+			want = srv.URL + "/link?to=3"
+		case "page":
+			want = srv.URL + "/page"
+		}
+		if d.Url != want {
+			t.Errorf("url of %s = %q, want %q", d.Title, d.Url, want)
+		}
+	}
+}
+
+func TestRequestFollowUpRedirectsCanceled(t *testing.T) {
+	var maxActive atomic.Int32
+	srv := newRedirectServer(t, time.Second, &maxActive)
+	base, _ := url.Parse(srv.URL)
+	e := &redirectEngine{base: base, client: network.NewClient(&network.Config{}), links: 2}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	res, err := SearchEngine(ctx, engine.Options{Query: "go", PageNo: 1}, e)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if d := time.Since(start); d > 500*time.Millisecond {
+		t.Errorf("following up took %v after the search is canceled", d)
+	}
+	// the links not resolved are kept.
+	for _, d := range res.GetData() {
+		if d.Title != "page" && d.Url != srv.URL+"/link?to="+d.Title {
+			t.Errorf("url of %s = %q, want the link", d.Title, d.Url)
+		}
+	}
+}
@@ -0,0 +1,71 @@
+package search
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/zvirgilx/searxng-go/kernel/internal/engine"
+	"github.com/zvirgilx/searxng-go/kernel/internal/network"
+	"github.com/zvirgilx/searxng-go/kernel/internal/result"
+)
+
+// sessionEngine gets a token page setting the session cookie before requesting the search page.
+type sessionEngine struct {
+	base   *url.URL
+	client *network.Client
+}
+
+func (e *sessionEngine) Request(ctx context.Context, opts *engine.Options) error {
+	if r := e.client.Get().Base(e.base).Path("/token").Jar(opts.CookieJar).Do(ctx); r.Err != nil {
+		return r.Err
+	}
+	opts.Request = e.client.Get().Base(e.base).Path("/search").Param("q", opts.Query)
+	return nil
+}
+
+func (e *sessionEngine) Response(_ context.Context, opts *engine.Options, body []byte) (*result.Result, error) {
+	res := result.CreateResult(e.GetName(), opts.PageNo)
+	res.AppendData(&result.Data{Engine: e.GetName(), Title: string(body), Url: e.base.String() + "/search"})
+	return res, nil
+}
+
+func (e *sessionEngine) GetName() string { return "session" }
+
+func (e *sessionEngine) ApplyConfig(engine.Config) error { return nil }
+
+func (e *sessionEngine) NeedSession() bool { return true }
+
+func TestRequestSessionCookie(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain")
+		switch r.URL.Path {
+		case "/token":
+			http.SetCookie(w, &http.Cookie{Name: "sid", Value: "abc", Path: "/"})
+		case "/search":
+			c, err := r.Cookie("sid")
+			if err != nil {
+				w.Write([]byte("no session"))
+				return
+			}
+			w.Write([]byte(c.Value))
+		}
+	}))
+	defer srv.Close()
+
+	base, _ := url.Parse(srv.URL)
+	e := &sessionEngine{base: base, client: network.NewClient(&network.Config{})}
+
+	for i := 0; i < 2; i++ {
+		res, err := SearchEngine(context.Background(), engine.Options{Query: "go", PageNo: 1}, e)
+		if err != nil {
+			t.Fatal(err)
+		}
+		data := res.GetData()
+		if len(data) != 1 || data[0].Title != "abc" {
+			t.Fatalf("search %d: the session cookie is not sent, got %+v", i, data)
+		}
+	}
+}
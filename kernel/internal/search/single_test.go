@@ -0,0 +1,104 @@
+package search
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/zvirgilx/searxng-go/kernel/internal/engine"
+	"github.com/zvirgilx/searxng-go/kernel/internal/result"
+)
+
+func TestSearchSingleEngine(t *testing.T) {
+	urls := []string{"https://z.example.com/1", "https://a.example.com/1", "https://m.example.com/1"}
+	cases := map[string]struct {
+		conf Config
+		opts engine.Options
+		want []string
+	}{
+		"own order": {want: urls},
+		// the results are truncated after the filters rather than by the engine, and keep the order.
+		"max results per engine": {conf: Config{MaxResultsPerEngine: 2}, want: urls[:2]},
+		"max results":            {opts: engine.Options{MaxResults: 1}, want: urls[:1]},
+	}
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := &mockEngine{name: "single_order", urls: urls}
+			other := &mockEngine{name: "single_other", urls: []string{"https://b.example.com/1"}}
+			setupSearch(t, c.conf, map[string][]engine.Engine{engine.CategoryGeneral: {e, other}})
+
+			opts := c.opts
+			opts.Query, opts.PageNo, opts.Category, opts.Engines = "go", 1, engine.CategoryGeneral, []string{"single_order"}
+			res := Search(context.Background(), opts)
+			if got := dataUrls(res); !slices.Equal(got, c.want) {
+				t.Errorf("got %v, want %v", got, c.want)
+			}
+			if res.Query != "go" || other.calls.Load() != 0 {
+				t.Errorf("query = %q, the other engine is requested %d times", res.Query, other.calls.Load())
+			}
+		})
+	}
+}
+
+func TestSearchSingleEngineAutoCorrect(t *testing.T) {
+	e := &mockEngine{name: "single_correct", urls: []string{"https://z.example.com/1", "https://a.example.com/1"}, corrections: []string{"golang"}}
+	setupSearch(t, Config{AutoCorrectMinResults: 3}, map[string][]engine.Engine{engine.CategoryGeneral: {e}})
+
+	res := Search(context.Background(), engine.Options{Query: "golnag", PageNo: 1, Category: engine.CategoryGeneral, Engines: []string{"single_correct"}, AutoCorrect: true})
+	if res.CorrectedQuery != "golang" || e.calls.Load() != 2 {
+		t.Errorf("corrected query = %q, the engine is requested %d times", res.CorrectedQuery, e.calls.Load())
+	}
+	// the results of correction follow the original ones, both in the order of engine.
+	urls := []string{"https://z.example.com/1", "https://a.example.com/1"}
+	if got, want := dataUrls(res), append(urls, urls...); !slices.Equal(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestSearchSingleEngineStale(t *testing.T) {
+	e := &mockEngine{name: "single_stale", urls: []string{"https://z.example.com/1", "https://a.example.com/1", "https://m.example.com/1"}}
+	setupSearch(t, Config{MaxResultsPerEngine: 2, Cache: CacheConfig{TTL: 10 * time.Millisecond, StaleTTL: time.Minute}},
+		map[string][]engine.Engine{engine.CategoryGeneral: {e}})
+
+	opts := engine.Options{Query: "go", PageNo: 1, Category: engine.CategoryGeneral, Engines: []string{"single_stale"}}
+	Search(context.Background(), opts)
+	time.Sleep(20 * time.Millisecond)
+
+	// the stale results of the engine are served in its order when it fails.
+	e.err = errors.New("upstream outage")
+	res := Search(context.Background(), opts)
+	if !res.Stale {
+		t.Fatal("the stale results are not served")
+	}
+	if got, want := dataUrls(res), []string{"https://z.example.com/1", "https://a.example.com/1"}; !slices.Equal(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestSearchSingleEngineFilters(t *testing.T) {
+	e := &titledEngine{
+		mockEngine: &mockEngine{name: "single_filters", urls: []string{"https://github.com", "https://ru.example.com", "https://en.example.com"}},
+		titles: map[string]string{
+			"https://github.com":     "GitHub: where the world builds software",
+			"https://ru.example.com": "Как выучить язык программирования",
+			"https://en.example.com": "How to learn the go language",
+		},
+	}
+	setupSearch(t, Config{
+		LanguageFilter: LanguageFilterConfig{Enable: true, Threshold: 0.7},
+		Redirect:       RedirectConfig{Enable: true, MinEngines: 1},
+		DomainScores:   []result.DomainScore{{Domain: "en.example.com", Score: 10}},
+	}, map[string][]engine.Engine{engine.CategoryGeneral: {e}})
+
+	res := Search(context.Background(), engine.Options{Query: "github.com", PageNo: 1, Category: engine.CategoryGeneral, Engines: []string{"single_filters"},
+		Locale: "en-US", LanguageSpecified: true})
+	// the results in other languages are dropped, and the boosted domain does not reorder the results of engine.
+	if got, want := dataUrls(res), []string{"https://github.com", "https://en.example.com"}; !slices.Equal(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+	if res.RedirectURL != "https://github.com" {
+		t.Errorf("redirect url = %q, want https://github.com", res.RedirectURL)
+	}
+}
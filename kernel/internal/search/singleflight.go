@@ -0,0 +1,87 @@
+package search
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/zvirgilx/searxng-go/kernel/internal/engine"
+	"github.com/zvirgilx/searxng-go/kernel/internal/result"
+)
+
+// flightCall is an in-flight search of an engine shared by identical searches.
+type flightCall struct {
+	done chan struct{}
+	res  *result.Result
+	err  error
+}
+
+// defaultFlightTimeout bounds the shared search if the search has no timeout.
+const defaultFlightTimeout = 10 * time.Second
+
+// flightGroup deduplicates the concurrent identical searches of engines, so that an upstream is fetched once for them.
+type flightGroup struct {
+	// timeout bounds the shared search, which outlives the callers canceled before it is done.
+	timeout time.Duration
+
+	mu    sync.Mutex
+	calls map[string]*flightCall
+}
+
+func newFlightGroup(timeout time.Duration) *flightGroup {
+	if timeout <= 0 {
+		timeout = defaultFlightTimeout
+	}
+	return &flightGroup{timeout: timeout, calls: map[string]*flightCall{}}
+}
+
+// do calls fn for the key once at a time, the callers of the same key waiting for the in-flight call share its result.
+// The call is detached from the context of callers, so that a caller canceled does not fail the others,
+// each caller stops waiting once its own context is done.
+func (g *flightGroup) do(ctx context.Context, key string, fn func(ctx context.Context) (*result.Result, error)) (*result.Result, error) {
+	g.mu.Lock()
+	c, ok := g.calls[key]
+	if !ok {
+		c = &flightCall{done: make(chan struct{})}
+		g.calls[key] = c
+		go g.call(context.WithoutCancel(ctx), key, c, fn)
+	}
+	g.mu.Unlock()
+
+	select {
+	case <-c.done:
+		return c.res, c.err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+func (g *flightGroup) call(ctx context.Context, key string, c *flightCall, fn func(ctx context.Context) (*result.Result, error)) {
+	ctx, cancel := context.WithTimeout(ctx, g.timeout)
+	defer cancel()
+
+	defer func() {
+		g.mu.Lock()
+		delete(g.calls, key)
+		g.mu.Unlock()
+		close(c.done)
+	}()
+
+	c.res, c.err = fn(ctx)
+}
+
+// middleware shares the in-flight search of engine with the identical searches, keyed by the cache key.
+// Each caller gets a copy of the shared result, because the data are changed by merging and scoring.
+func (g *flightGroup) middleware(e engine.Engine, next engine.Handler) engine.Handler {
+	return func(ctx context.Context, opts *engine.Options) (*result.Result, error) {
+		// the shared search gets its own options, since it may outlive the caller.
+		shared := *opts
+		res, err := g.do(ctx, cacheKey(e, opts), func(ctx context.Context) (*result.Result, error) {
+			return next(ctx, &shared)
+		})
+		if err != nil || res == nil {
+			return res, err
+		}
+		return res.Clone(), nil
+	}
+}
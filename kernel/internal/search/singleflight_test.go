@@ -0,0 +1,115 @@
+package search
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/zvirgilx/searxng-go/kernel/internal/engine"
+	"github.com/zvirgilx/searxng-go/kernel/internal/result"
+)
+
+func TestSearchSingleflight(t *testing.T) {
+	a := &mockEngine{name: "flight_a", urls: []string{"https://a.example.com/1"}, delay: 100 * time.Millisecond}
+	setupSearch(t, Config{Singleflight: true}, map[string][]engine.Engine{engine.CategoryGeneral: {a}})
+
+	const n = 8
+	var wg sync.WaitGroup
+	results := make([]*result.Result, n)
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			results[i] = Search(context.Background(), engine.Options{Query: "go", PageNo: 1, Category: engine.CategoryGeneral})
+		}(i)
+	}
+	wg.Wait()
+
+	if calls := a.calls.Load(); calls != 1 {
+		t.Errorf("the engine is requested %d times, want once", calls)
+	}
+	for i, res := range results {
+		if urls := dataUrls(res); len(urls) != 1 || urls[0] != "https://a.example.com/1" {
+			t.Errorf("search %d got %v", i, urls)
+		}
+	}
+	// each search gets its own copy of the shared result.
+	if results[0].GetData()[0] == results[1].GetData()[0] {
+		t.Error("the data of shared result are shared by searches")
+	}
+}
+
+func TestFlightGroupCanceledCaller(t *testing.T) {
+	g := newFlightGroup(time.Second)
+	release := make(chan struct{})
+	fn := func(ctx context.Context) (*result.Result, error) {
+		select {
+		case <-release:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+		return result.CreateResult("a", 1), nil
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	first := make(chan error, 1)
+	go func() {
+		_, err := g.do(ctx, "key", fn)
+		first <- err
+	}()
+	second := make(chan error, 1)
+	go func() {
+		// the second caller joins the call of the first one.
+		for {
+			g.mu.Lock()
+			_, ok := g.calls["key"]
+			g.mu.Unlock()
+			if ok {
+				break
+			}
+			time.Sleep(time.Millisecond)
+		}
+		res, err := g.do(context.Background(), "key", func(context.Context) (*result.Result, error) {
+			return nil, errors.New("the shared call is called again")
+		})
+		if err == nil && res == nil {
+			err = errors.New("no result is shared")
+		}
+		second <- err
+	}()
+
+	// the first caller stops waiting once canceled, the shared call goes on for the second one.
+	time.Sleep(20 * time.Millisecond)
+	cancel()
+	if err := <-first; !errors.Is(err, context.Canceled) {
+		t.Errorf("err of canceled caller = %v, want canceled", err)
+	}
+	close(release)
+	if err := <-second; err != nil {
+		t.Errorf("err of waiting caller = %v", err)
+	}
+}
+
+func TestFlightGroupTimeout(t *testing.T) {
+	g := newFlightGroup(20 * time.Millisecond)
+	start := time.Now()
+	_, err := g.do(context.Background(), "key", func(ctx context.Context) (*result.Result, error) {
+		<-ctx.Done()
+		return nil, ctx.Err()
+	})
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("err = %v, want the shared call timed out", err)
+	}
+	if elapsed := time.Since(start); elapsed >= time.Second {
+		t.Errorf("the shared call took %v", elapsed)
+	}
+
+	// the key is released after the call is done.
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if len(g.calls) != 0 {
+		t.Errorf("the calls are left %v", g.calls)
+	}
+}
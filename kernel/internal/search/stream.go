@@ -0,0 +1,131 @@
+package search
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"github.com/zvirgilx/searxng-go/kernel/internal/result"
+)
+
+// StreamOrderConfig is the configuration of the order results of engines are streamed in.
+type StreamOrderConfig struct {
+	// Engines are the engine names ordered by priority, the unlisted engines have the lowest priority.
+	Engines []string `mapstructure:"engines"`
+	// Window is the time since the stream starts, within which the results of an engine are held back
+	// until the engines of higher priority are finished. 0 streams the results as they arrive.
+	Window time.Duration `mapstructure:"window"`
+}
+
+// streamArrival is the result of an engine arrived at the stream.
+type streamArrival struct {
+	name string
+	res  *result.Result
+	seq  int // seq is the order of arrival.
+}
+
+// reorderBuffer holds the results of engines arrived within the window,
+// so that the engines of higher priority are emitted first even if they finish later.
+type reorderBuffer struct {
+	conf StreamOrderConfig
+
+	pending  map[string]bool
+	buffered []streamArrival
+	expired  bool // expired means the window is over, the results are emitted as they arrive.
+}
+
+func newReorderBuffer(c StreamOrderConfig, names []string) *reorderBuffer {
+	b := &reorderBuffer{conf: c, pending: map[string]bool{}, expired: c.Window <= 0 || len(c.Engines) == 0}
+	for _, name := range names {
+		b.pending[name] = true
+	}
+	return b
+}
+
+// priority is the index of engine in the configured order, the unlisted engines are after the listed.
+func (b *reorderBuffer) priority(name string) int {
+	if i := slices.Index(b.conf.Engines, name); i >= 0 {
+		return i
+	}
+	return len(b.conf.Engines)
+}
+
+// add buffers the result of engine, the engine is finished even if the result is nil.
+func (b *reorderBuffer) add(a streamArrival) {
+	delete(b.pending, a.name)
+	if a.res != nil {
+		b.buffered = append(b.buffered, a)
+	}
+}
+
+// ready pops the buffered results allowed to emit in the order of priority then arrival.
+// A result is held if any engine of higher priority is pending within the window.
+func (b *reorderBuffer) ready() []streamArrival {
+	slices.SortStableFunc(b.buffered, func(x, y streamArrival) int {
+		if px, py := b.priority(x.name), b.priority(y.name); px != py {
+			return px - py
+		}
+		return x.seq - y.seq
+	})
+
+	// the highest priority of pending engines, results of lower priority are held.
+	blocking := len(b.conf.Engines) + 1
+	if !b.expired {
+		for name := range b.pending {
+			blocking = min(blocking, b.priority(name))
+		}
+	}
+
+	n := 0
+	for n < len(b.buffered) && b.priority(b.buffered[n].name) <= blocking {
+		n++
+	}
+	ready := b.buffered[:n:n]
+	b.buffered = b.buffered[n:]
+	return ready
+}
+
+// reorder emits the data of arrivals in the order of priority of engines within the window,
+// it returns once arrivals is closed and all data are emitted, or ctx is done.
+func reorder(ctx context.Context, c StreamOrderConfig, names []string, arrivals <-chan streamArrival, ch chan<- *result.Data) {
+	b := newReorderBuffer(c, names)
+
+	var windowC <-chan time.Time
+	if !b.expired {
+		timer := time.NewTimer(c.Window)
+		defer timer.Stop()
+		windowC = timer.C
+	}
+
+	emit := func() bool {
+		for _, a := range b.ready() {
+			for _, d := range a.res.GetSortedData() {
+				select {
+				case ch <- d:
+				case <-ctx.Done():
+					return false
+				}
+			}
+		}
+		return true
+	}
+
+	for {
+		select {
+		case a, ok := <-arrivals:
+			if !ok {
+				b.expired = true
+				emit()
+				return
+			}
+			b.add(a)
+		case <-windowC:
+			b.expired = true
+		case <-ctx.Done():
+			return
+		}
+		if !emit() {
+			return
+		}
+	}
+}
@@ -0,0 +1,138 @@
+package search
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/zvirgilx/searxng-go/kernel/internal/engine"
+	"github.com/zvirgilx/searxng-go/kernel/internal/result"
+)
+
+// collect receives the urls from ch until it is closed.
+func collect(t *testing.T, ch <-chan *result.Data) []string {
+	t.Helper()
+	var urls []string
+	timeout := time.After(5 * time.Second)
+	for {
+		select {
+		case d, ok := <-ch:
+			if !ok {
+				return urls
+			}
+			urls = append(urls, d.Url)
+		case <-timeout:
+			t.Fatalf("the stream is not closed, got %v", urls)
+		}
+	}
+}
+
+func TestStreamEmitsAsEnginesReturn(t *testing.T) {
+	fast := &mockEngine{name: "fast", urls: []string{"https://fast.example.com/1", "https://fast.example.com/2"}}
+	slow := &mockEngine{name: "slow", urls: []string{"https://slow.example.com/1"}, delay: 100 * time.Millisecond}
+	setupSearch(t, Config{}, map[string][]engine.Engine{engine.CategoryGeneral: {fast, slow}})
+
+	// the data of fast engine are emitted before the slow engine returns.
+	got := collect(t, Stream(context.Background(), engine.Options{Query: "go", PageNo: 1, Category: engine.CategoryGeneral}))
+	if len(got) != 3 || got[2] != "https://slow.example.com/1" {
+		t.Fatalf("got %v, want the data of slow engine last", got)
+	}
+	fastUrls := slices.Clone(got[:2])
+	slices.Sort(fastUrls)
+	if !slices.Equal(fastUrls, fast.urls) {
+		t.Errorf("got %v, want %v first", got[:2], fast.urls)
+	}
+}
+
+func TestStreamCanceled(t *testing.T) {
+	slow := &mockEngine{name: "slow", urls: []string{"https://slow.example.com/1"}, delay: time.Minute}
+	setupSearch(t, Config{}, map[string][]engine.Engine{engine.CategoryGeneral: {slow}})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	ch := Stream(ctx, engine.Options{Query: "go", PageNo: 1, Category: engine.CategoryGeneral})
+	cancel()
+	if got := collect(t, ch); len(got) != 0 {
+		t.Errorf("got %v after cancel", got)
+	}
+
+	// the stream is closed without waiting for the canceled engine,
+	// its latency is the last thing recorded, so the config is not reset until then.
+	waitLatencyRecorded(t, slow.name)
+}
+
+func waitLatencyRecorded(t *testing.T, name string) {
+	t.Helper()
+	for deadline := time.Now().Add(5 * time.Second); time.Now().Before(deadline); time.Sleep(time.Millisecond) {
+		if _, _, ok := latencySamples.percentile(name, adaptivePercentile); ok {
+			return
+		}
+	}
+	t.Fatalf("the latency of %s is not recorded", name)
+}
+
+func TestStreamOrder(t *testing.T) {
+	cases := map[string]struct {
+		window time.Duration
+		want   []string
+	}{
+		// the preferred engine is emitted first even if it finishes later.
+		"within window": {window: time.Second, want: []string{"https://preferred.example.com/1", "https://other.example.com/1"}},
+		// the other engine is not held back for the preferred one after the window.
+		"window expired": {window: 20 * time.Millisecond, want: []string{"https://other.example.com/1", "https://preferred.example.com/1"}},
+		"disabled":       {want: []string{"https://other.example.com/1", "https://preferred.example.com/1"}},
+	}
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			preferred := &mockEngine{name: "order_preferred", urls: []string{"https://preferred.example.com/1"}, delay: 100 * time.Millisecond}
+			other := &mockEngine{name: "order_other", urls: []string{"https://other.example.com/1"}}
+			setupSearch(t, Config{StreamOrder: StreamOrderConfig{Engines: []string{"order_preferred"}, Window: c.window}},
+				map[string][]engine.Engine{engine.CategoryGeneral: {preferred, other}})
+
+			got := collect(t, Stream(context.Background(), engine.Options{Query: "go", PageNo: 1, Category: engine.CategoryGeneral}))
+			if !slices.Equal(got, c.want) {
+				t.Errorf("got %v, want %v", got, c.want)
+			}
+		})
+	}
+}
+
+func TestReorderBuffer(t *testing.T) {
+	arrival := func(name string, seq int) streamArrival {
+		return streamArrival{name: name, res: result.CreateResult(name, 1), seq: seq}
+	}
+	names := func(arrivals []streamArrival) []string {
+		var s []string
+		for _, a := range arrivals {
+			s = append(s, a.name)
+		}
+		return s
+	}
+
+	b := newReorderBuffer(StreamOrderConfig{Engines: []string{"a", "b"}, Window: time.Second}, []string{"a", "b", "c", "d"})
+
+	// the unlisted engines are held while the listed ones are pending.
+	b.add(arrival("c", 0))
+	b.add(streamArrival{name: "d", seq: 1})
+	if got := b.ready(); len(got) != 0 {
+		t.Errorf("ready %v while a is pending", names(got))
+	}
+	// the engine of second priority is held until the first one is finished.
+	b.add(arrival("b", 2))
+	if got := b.ready(); len(got) != 0 {
+		t.Errorf("ready %v while a is pending", names(got))
+	}
+	// the results are emitted in the order of priority, the engine without result is finished as well.
+	b.add(arrival("a", 3))
+	if got := names(b.ready()); !slices.Equal(got, []string{"a", "b", "c"}) {
+		t.Errorf("ready %v, want [a b c]", got)
+	}
+
+	// the results are emitted as they arrive once the window is expired.
+	b = newReorderBuffer(StreamOrderConfig{Engines: []string{"a"}, Window: time.Second}, []string{"a", "c"})
+	b.add(arrival("c", 0))
+	b.expired = true
+	if got := names(b.ready()); !slices.Equal(got, []string{"c"}) {
+		t.Errorf("ready %v after the window, want [c]", got)
+	}
+}
@@ -0,0 +1,60 @@
+package search
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"github.com/zvirgilx/searxng-go/kernel/internal/engine"
+	"github.com/zvirgilx/searxng-go/kernel/internal/result"
+)
+
+// taggedEngine tags its data with the tags of urls.
+type taggedEngine struct {
+	*mockEngine
+	tags map[string][]string
+}
+
+func (e *taggedEngine) Response(ctx context.Context, opts *engine.Options, body []byte) (*result.Result, error) {
+	res, err := e.mockEngine.Response(ctx, opts, body)
+	if err != nil {
+		return nil, err
+	}
+	for _, d := range res.GetData() {
+		d.Tags = e.tags[d.Url]
+	}
+	return res, nil
+}
+
+func TestVerifySearchOptionsTags(t *testing.T) {
+	setupSearch(t, Config{}, nil)
+
+	opts, err := verifySearchOptions(queryParams(map[string]string{"q": "go", "tags": "video,verified", "exclude_tags": "nsfw"}), "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(opts.Tags, []string{"video", "verified"}) || !slices.Equal(opts.ExcludeTags, []string{"nsfw"}) {
+		t.Errorf("tags = %v, exclude tags = %v", opts.Tags, opts.ExcludeTags)
+	}
+
+	if opts, _ = verifySearchOptions(queryParams(map[string]string{"q": "go", "tags": ""}), ""); opts.Tags != nil || opts.ExcludeTags != nil {
+		t.Errorf("the empty tags filter the data: %v, %v", opts.Tags, opts.ExcludeTags)
+	}
+}
+
+func TestSearchTags(t *testing.T) {
+	a := &taggedEngine{
+		mockEngine: &mockEngine{name: "a", urls: []string{"https://a.example.com/1", "https://a.example.com/2", "https://a.example.com/3"}},
+		tags: map[string][]string{
+			"https://a.example.com/1": {"video", "verified"},
+			"https://a.example.com/2": {"video", "nsfw"},
+		},
+	}
+	b := &mockEngine{name: "b", urls: []string{"https://b.example.com/1"}}
+	setupSearch(t, Config{}, map[string][]engine.Engine{engine.CategoryGeneral: {a, b}})
+
+	res := Search(context.Background(), engine.Options{Query: "go", PageNo: 1, Category: engine.CategoryGeneral, Tags: []string{"video"}, ExcludeTags: []string{"nsfw"}})
+	if urls := dataUrls(res); !slices.Equal(urls, []string{"https://a.example.com/1"}) {
+		t.Errorf("got %v, want the video without nsfw", urls)
+	}
+}
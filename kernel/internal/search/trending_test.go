@@ -0,0 +1,66 @@
+package search
+
+import (
+	"context"
+	"testing"
+
+	"github.com/zvirgilx/searxng-go/kernel/internal/engine"
+)
+
+// trendingEngine returns its urls for the search, and the trending urls without query.
+type trendingEngine struct {
+	mockEngine
+	trending []string
+}
+
+func (e *trendingEngine) Trending(ctx context.Context, opts *engine.Options) error {
+	e.urls = e.trending
+	return e.mockEngine.Request(ctx, opts)
+}
+
+func TestSearchTrending(t *testing.T) {
+	te := &trendingEngine{mockEngine: mockEngine{name: "trending"}, trending: []string{"https://trending.example.com/1"}}
+	plain := &mockEngine{name: "plain", urls: []string{"https://plain.example.com/1"}}
+	setupSearch(t, Config{Trending: true}, map[string][]engine.Engine{engine.CategoryGeneral: {te, plain}})
+
+	// the engines not supporting trending are skipped.
+	res := Search(context.Background(), engine.Options{PageNo: 1, Category: engine.CategoryGeneral})
+	if urls := dataUrls(res); len(urls) != 1 || urls[0] != "https://trending.example.com/1" {
+		t.Errorf("got %v, want the trending items only", urls)
+	}
+	if plain.calls.Load() != 0 {
+		t.Errorf("the engine not supporting trending is requested %d times", plain.calls.Load())
+	}
+}
+
+func TestSearchEmptyQueryWithoutTrending(t *testing.T) {
+	te := &trendingEngine{mockEngine: mockEngine{name: "trending", urls: []string{"https://trending.example.com/search"}}, trending: []string{"https://trending.example.com/1"}}
+	plain := &mockEngine{name: "plain", urls: []string{"https://plain.example.com/1"}}
+	setupSearch(t, Config{}, map[string][]engine.Engine{engine.CategoryGeneral: {te, plain}})
+
+	// the empty query is searched as usual if trending is disabled.
+	Search(context.Background(), engine.Options{PageNo: 1, Category: engine.CategoryGeneral})
+	if plain.calls.Load() != 1 || te.calls.Load() != 1 || len(te.urls) != 1 || te.urls[0] != "https://trending.example.com/search" {
+		t.Errorf("the engines are not requested for the search, calls %d and %d", plain.calls.Load(), te.calls.Load())
+	}
+}
+
+func TestVerifySearchOptionsEmptyQuery(t *testing.T) {
+	setupSearch(t, Config{}, nil)
+
+	if _, err := verifySearchOptions(queryParams(map[string]string{}), ""); err == nil {
+		t.Error("the search without q is accepted")
+	}
+	if opts, err := verifySearchOptions(queryParams(map[string]string{"q": ""}), ""); err != nil || opts.Query != "" {
+		t.Errorf("the empty q is rejected: %v", err)
+	}
+	if opts, err := verifySearchOptions(queryParams(map[string]string{"q": " "}), ""); err != nil || opts.Query != " " {
+		t.Errorf("the blank q is changed to %q, err %v", opts.Query, err)
+	}
+
+	// the blank query searches the trending items if trending is enabled.
+	setupSearch(t, Config{Trending: true}, nil)
+	if opts, err := verifySearchOptions(queryParams(map[string]string{"q": " "}), ""); err != nil || opts.Query != "" {
+		t.Errorf("the blank q is not trimmed for trending, got %q, err %v", opts.Query, err)
+	}
+}
@@ -0,0 +1,33 @@
+package search
+
+import (
+	"context"
+	"testing"
+
+	"github.com/zvirgilx/searxng-go/kernel/internal/engine"
+)
+
+// relativeEngine returns the urls relative to its base url.
+type relativeEngine struct {
+	*mockEngine
+}
+
+func (e *relativeEngine) Capabilities() engine.Capabilities {
+	return engine.Capabilities{Categories: []string{engine.CategoryGeneral}, BaseUrl: "https://relative.example.com"}
+}
+
+func TestSearchAbsolutizeURLs(t *testing.T) {
+	relative := &relativeEngine{&mockEngine{name: "relative", urls: []string{"/watch?v=1", "//cdn.example.com/2"}}}
+	setupSearch(t, Config{}, map[string][]engine.Engine{engine.CategoryGeneral: {relative}})
+
+	got := dataUrls(Search(context.Background(), engine.Options{Query: "go", PageNo: 1, Category: engine.CategoryGeneral}))
+	want := map[string]bool{"https://relative.example.com/watch?v=1": true, "https://cdn.example.com/2": true}
+	if len(got) != len(want) {
+		t.Fatalf("got %v", got)
+	}
+	for _, u := range got {
+		if !want[u] {
+			t.Errorf("the url %s is not resolved against the base url", u)
+		}
+	}
+}
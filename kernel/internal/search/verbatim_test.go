@@ -0,0 +1,41 @@
+package search
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/zvirgilx/searxng-go/kernel/internal/engine"
+)
+
+func TestVerifySearchOptionsVerbatim(t *testing.T) {
+	setupSearch(t, Config{}, nil)
+
+	if opts, err := verifySearchOptions(queryParams(map[string]string{"q": "go", "verbatim": "true"}), ""); err != nil || !opts.Verbatim {
+		t.Errorf("verbatim = %v, err %v", opts.Verbatim, err)
+	}
+	if opts, _ := verifySearchOptions(queryParams(map[string]string{"q": "go"}), ""); opts.Verbatim {
+		t.Error("verbatim by default")
+	}
+	if _, err := verifySearchOptions(queryParams(map[string]string{"q": "go", "verbatim": "exact"}), ""); err == nil {
+		t.Error("the invalid verbatim is accepted")
+	}
+}
+
+func TestSearchVerbatimCache(t *testing.T) {
+	a := &mockEngine{name: "a", urls: []string{"https://a.example.com/1"}}
+	b := &mockEngine{name: "b", urls: []string{"https://b.example.com/1"}}
+	setupSearch(t, Config{Cache: CacheConfig{TTL: time.Minute}}, map[string][]engine.Engine{engine.CategoryGeneral: {a, b}})
+
+	// the verbatim search is not served by the cached results of the rewritten one.
+	opts := engine.Options{Query: "golnag", PageNo: 1, Category: engine.CategoryGeneral}
+	Search(context.Background(), opts)
+	opts.Verbatim = true
+	Search(context.Background(), opts)
+	if a.calls.Load() != 2 {
+		t.Errorf("the engine is requested %d times, want 2", a.calls.Load())
+	}
+	if !a.lastOptions().Verbatim {
+		t.Error("verbatim is not passed to the engine")
+	}
+}
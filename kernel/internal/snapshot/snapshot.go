@@ -0,0 +1,272 @@
+// Package snapshot records the live responses of engines and replays them through Response,
+// so that the changes of upstream layout are detected by diffing against the expected data.
+package snapshot
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+
+	"github.com/zvirgilx/searxng-go/kernel/internal/engine"
+	"github.com/zvirgilx/searxng-go/kernel/internal/result"
+)
+
+// DefaultDir is the default directory of snapshots relative to the kernel, each engine has a sub directory.
+// The snapshots under it are replayed by the tests of engines.
+const DefaultDir = "internal/engines/testdata/snapshots"
+
+// Options are the options the snapshot is recorded with.
+type Options struct {
+	Query    string `json:"query"`
+	PageNo   int    `json:"page_no"`
+	Locale   string `json:"locale"`
+	Category string `json:"category"`
+}
+
+// Data are the stable fields of result data, fields derived from the time of parsing are excluded,
+// e.g. the published date parsed from "2 days ago".
+type Data struct {
+	Title     string `json:"title"`
+	Url       string `json:"url"`
+	Content   string `json:"content"`
+	ImgSrc    string `json:"img_src,omitempty"`
+	Thumbnail string `json:"thumbnail,omitempty"`
+	Author    string `json:"author,omitempty"`
+	Views     int64  `json:"views,omitempty"`
+}
+
+// Snapshot is the expected data of a recorded response, the raw response is stored beside it.
+// The response of follow-up request is stored as well for the engines requesting in two steps.
+type Snapshot struct {
+	Options Options         `json:"options"`
+	Data    []Data          `json:"data"`
+	InfoBox *result.InfoBox `json:"infobox,omitempty"`
+}
+
+// paths returns the path of expected data, raw response and follow-up response of snapshot.
+func paths(dir, engineName, name string) (string, string, string) {
+	base := filepath.Join(dir, engineName, name)
+	return base + ".json", base + ".body", base + ".followup.body"
+}
+
+// prepare gets the options of engine like the search does, the params not supported by the engine are dropped.
+func prepare(e engine.Engine, opts Options) (engine.Options, error) {
+	options := opts.engineOptions()
+	engine.NormalizeOptions(&options, 0)
+	if !engine.ApplyCapabilities(e, &options) {
+		return options, errors.New("the options are not supported by engine")
+	}
+	return options, nil
+}
+
+// Record requests the engine with options, then stores the raw response and the parsed data as the snapshot.
+func Record(ctx context.Context, e engine.Engine, opts Options, dir, name string) error {
+	options, err := prepare(e, opts)
+	if err != nil {
+		return err
+	}
+	if err := e.Request(ctx, &options); err != nil {
+		return err
+	}
+	body, err := do(ctx, &options)
+	if err != nil {
+		return err
+	}
+	if body == nil {
+		return errors.New("no request of engine")
+	}
+
+	res, err := e.Response(ctx, &options, body)
+	if err != nil {
+		return err
+	}
+
+	var followUpBody []byte
+	if fe, ok := e.(engine.FollowUpEngine); ok && res != nil {
+		options.Request = nil
+		if err := fe.FollowUp(ctx, &options, res, body); err != nil {
+			return err
+		}
+		if followUpBody, err = do(ctx, &options); err != nil {
+			return err
+		}
+		if followUpBody != nil {
+			if err := fe.FollowUpResponse(ctx, &options, res, followUpBody); err != nil {
+				return err
+			}
+		}
+	}
+
+	jsonPath, bodyPath, followUpPath := paths(dir, e.GetName(), name)
+	if err := os.MkdirAll(filepath.Dir(jsonPath), 0o755); err != nil {
+		return err
+	}
+	if err := os.WriteFile(bodyPath, body, 0o644); err != nil {
+		return err
+	}
+	if followUpBody != nil {
+		if err := os.WriteFile(followUpPath, followUpBody, 0o644); err != nil {
+			return err
+		}
+	}
+	return write(jsonPath, opts, res)
+}
+
+// do sends the request of options, nil is returned if the engine has no request.
+func do(ctx context.Context, options *engine.Options) ([]byte, error) {
+	if err := engine.ValidateRequest(options); err != nil {
+		return nil, err
+	}
+	if options.Request == nil {
+		return nil, nil
+	}
+	r := options.Request.Do(ctx)
+	if r.Err != nil {
+		return nil, r.Err
+	}
+	return r.Body, nil
+}
+
+func write(jsonPath string, opts Options, res *result.Result) error {
+	b, err := json.MarshalIndent(Snapshot{Options: opts, Data: toData(res), InfoBox: infoBox(res)}, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(jsonPath, append(b, '\n'), 0o644)
+}
+
+// Replay parses the recorded response by the engine, and diffs the data against the expected data.
+// An error describing the differences is returned if they are not equal.
+func Replay(ctx context.Context, e engine.Engine, dir, name string) error {
+	expected, res, err := parse(ctx, e, dir, name)
+	if err != nil {
+		return err
+	}
+	return diff(expected, res)
+}
+
+// Update parses the recorded response by the engine, and rewrites the expected data by the parsed data.
+// It is used after the parsing of engine is changed on purpose, the response is not recorded again.
+func Update(ctx context.Context, e engine.Engine, dir, name string) error {
+	expected, res, err := parse(ctx, e, dir, name)
+	if err != nil {
+		return err
+	}
+	jsonPath, _, _ := paths(dir, e.GetName(), name)
+	return write(jsonPath, expected.Options, res)
+}
+
+// parse reads the expected snapshot, and parses the recorded responses by the engine like the search does.
+func parse(ctx context.Context, e engine.Engine, dir, name string) (Snapshot, *result.Result, error) {
+	jsonPath, bodyPath, followUpPath := paths(dir, e.GetName(), name)
+
+	var expected Snapshot
+	b, err := os.ReadFile(jsonPath)
+	if err != nil {
+		return expected, nil, err
+	}
+	if err := json.Unmarshal(b, &expected); err != nil {
+		return expected, nil, err
+	}
+
+	body, err := os.ReadFile(bodyPath)
+	if err != nil {
+		return expected, nil, err
+	}
+
+	options, err := prepare(e, expected.Options)
+	if err != nil {
+		return expected, nil, err
+	}
+	res, err := e.Response(ctx, &options, body)
+	if err != nil {
+		return expected, nil, err
+	}
+
+	// the follow-up request is initiated, but the recorded response is parsed instead of sending it.
+	if fe, ok := e.(engine.FollowUpEngine); ok && res != nil {
+		followUpBody, err := os.ReadFile(followUpPath)
+		if err != nil && !errors.Is(err, os.ErrNotExist) {
+			return expected, nil, err
+		}
+		if err == nil {
+			options.Request = nil
+			if err := fe.FollowUp(ctx, &options, res, body); err != nil {
+				return expected, nil, err
+			}
+			if err := fe.FollowUpResponse(ctx, &options, res, followUpBody); err != nil {
+				return expected, nil, err
+			}
+		}
+	}
+	return expected, res, nil
+}
+
+// Names lists the names of snapshots of engine.
+func Names(dir, engineName string) ([]string, error) {
+	matches, err := filepath.Glob(filepath.Join(dir, engineName, "*.json"))
+	if err != nil {
+		return nil, err
+	}
+
+	names := make([]string, 0, len(matches))
+	for _, m := range matches {
+		names = append(names, strings.TrimSuffix(filepath.Base(m), ".json"))
+	}
+	return names, nil
+}
+
+func (o Options) engineOptions() engine.Options {
+	return engine.Options{Query: o.Query, PageNo: o.PageNo, Locale: o.Locale, Category: o.Category}
+}
+
+func toData(res *result.Result) []Data {
+	data := make([]Data, 0, res.GetDataSize())
+	for _, d := range res.GetData() {
+		data = append(data, Data{
+			Title:     d.Title,
+			Url:       d.Url,
+			Content:   d.Content,
+			ImgSrc:    d.ImgSrc,
+			Thumbnail: d.Thumbnail,
+			Author:    d.Author,
+			Views:     d.Views,
+		})
+	}
+	return data
+}
+
+// infoBox gets the infobox of result, it is nil-safe.
+func infoBox(res *result.Result) *result.InfoBox {
+	if res == nil {
+		return nil
+	}
+	return res.InfoBox
+}
+
+// diff reports the differences of data in order and the infobox.
+func diff(snapshot Snapshot, res *result.Result) error {
+	expected, actual := snapshot.Data, toData(res)
+
+	var diffs []string
+	if !reflect.DeepEqual(snapshot.InfoBox, infoBox(res)) {
+		diffs = append(diffs, fmt.Sprintf("infobox:\n  expected %+v\n  got      %+v", snapshot.InfoBox, infoBox(res)))
+	}
+	if len(expected) != len(actual) {
+		diffs = append(diffs, fmt.Sprintf("count of data: expected %d, got %d", len(expected), len(actual)))
+	}
+	for i := 0; i < min(len(expected), len(actual)); i++ {
+		if !reflect.DeepEqual(expected[i], actual[i]) {
+			diffs = append(diffs, fmt.Sprintf("data %d:\n  expected %+v\n  got      %+v", i, expected[i], actual[i]))
+		}
+	}
+	if len(diffs) == 0 {
+		return nil
+	}
+	return errors.New(strings.Join(diffs, "\n"))
+}
@@ -0,0 +1,164 @@
+package snapshot
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/zvirgilx/searxng-go/kernel/internal/engine"
+	"github.com/zvirgilx/searxng-go/kernel/internal/network"
+	"github.com/zvirgilx/searxng-go/kernel/internal/result"
+)
+
+// fakeEngine searches a text page of one title per line, and follows up the first line for the infobox.
+// It does not support language, so the locale is dropped before it requests.
+type fakeEngine struct {
+	base   *url.URL
+	client *network.Client
+}
+
+func (e *fakeEngine) Request(_ context.Context, opts *engine.Options) error {
+	opts.Request = e.client.Get().Base(e.base).Path("/search").Param("q", opts.Query).Param("hl", opts.Locale)
+	return nil
+}
+
+func (e *fakeEngine) Response(_ context.Context, opts *engine.Options, body []byte) (*result.Result, error) {
+	res := result.CreateResult(e.GetName(), opts.PageNo)
+	for _, line := range strings.Fields(string(body)) {
+		res.AppendData(&result.Data{Engine: e.GetName(), Title: line, Url: "https://example.com/" + line})
+	}
+	return res, nil
+}
+
+func (e *fakeEngine) FollowUp(_ context.Context, opts *engine.Options, res *result.Result, _ []byte) error {
+	data := res.GetData()
+	if len(data) == 0 {
+		return nil
+	}
+	opts.Request = e.client.Get().Base(e.base).Path("/info").Param("title", data[0].Title)
+	return nil
+}
+
+func (e *fakeEngine) FollowUpResponse(_ context.Context, _ *engine.Options, res *result.Result, body []byte) error {
+	res.InfoBox = &result.InfoBox{Title: string(body), Engine: e.GetName()}
+	return nil
+}
+
+func (e *fakeEngine) Capabilities() engine.Capabilities {
+	return engine.Capabilities{Categories: []string{engine.CategoryGeneral}, Paging: true}
+}
+
+func (e *fakeEngine) GetName() string { return "fake" }
+
+func (e *fakeEngine) ApplyConfig(engine.Config) error { return nil }
+
+func newFakeEngine(t *testing.T, lines string) (*fakeEngine, *url.Values) {
+	var query url.Values
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain")
+		switch r.URL.Path {
+		case "/search":
+			query = r.URL.Query()
+			w.Write([]byte(lines))
+		case "/info":
+			w.Write([]byte("about " + r.URL.Query().Get("title")))
+		}
+	}))
+	t.Cleanup(srv.Close)
+
+	base, _ := url.Parse(srv.URL)
+	return &fakeEngine{base: base, client: network.NewClient(&network.Config{})}, &query
+}
+
+func TestRecordReplay(t *testing.T) {
+	dir := t.TempDir()
+	e, query := newFakeEngine(t, "go\nrust")
+
+	if err := Record(context.Background(), e, Options{Query: "lang", Locale: "en-US"}, dir, "basic"); err != nil {
+		t.Fatal(err)
+	}
+	// the locale is dropped as the search does, since the engine does not support language.
+	if query.Get("hl") != "" {
+		t.Errorf("the locale of unsupported language is sent: %q", query.Get("hl"))
+	}
+	for _, name := range []string{"basic.json", "basic.body", "basic.followup.body"} {
+		if _, err := os.Stat(filepath.Join(dir, "fake", name)); err != nil {
+			t.Errorf("%s is not recorded: %v", name, err)
+		}
+	}
+
+	names, err := Names(dir, "fake")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(names) != 1 || names[0] != "basic" {
+		t.Fatalf("names of snapshots: got %v", names)
+	}
+
+	if err := Replay(context.Background(), e, dir, "basic"); err != nil {
+		t.Fatal(err)
+	}
+	expected, res, err := parse(context.Background(), e, dir, "basic")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(expected.Data) != 2 || res.InfoBox == nil || res.InfoBox.Title != "about go" {
+		t.Errorf("the follow-up response is not replayed: %+v, infobox %+v", expected.Data, res.InfoBox)
+	}
+}
+
+func TestReplayDiff(t *testing.T) {
+	dir := t.TempDir()
+	e, _ := newFakeEngine(t, "go\nrust")
+	if err := Record(context.Background(), e, Options{Query: "lang"}, dir, "basic"); err != nil {
+		t.Fatal(err)
+	}
+
+	// the upstream layout changed, the recorded response is parsed differently.
+	_, bodyPath, followUpPath := paths(dir, "fake", "basic")
+	if err := os.WriteFile(bodyPath, []byte("zig\nrust\ngo"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Remove(followUpPath); err != nil {
+		t.Fatal(err)
+	}
+
+	err := Replay(context.Background(), e, dir, "basic")
+	if err == nil {
+		t.Fatal("expected differences of replay")
+	}
+	for _, want := range []string{"infobox", "count of data: expected 2, got 3", "data 0"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("the differences do not report %q:\n%s", want, err)
+		}
+	}
+
+	// update rewrites the expected data by the recorded response, the replay passes again.
+	if err := Update(context.Background(), e, dir, "basic"); err != nil {
+		t.Fatal(err)
+	}
+	if err := Replay(context.Background(), e, dir, "basic"); err != nil {
+		t.Errorf("replay after update: %v", err)
+	}
+}
+
+func TestRecordUnsupportedPage(t *testing.T) {
+	e, _ := newFakeEngine(t, "go")
+	noPaging := &noPagingEngine{e}
+	if err := Record(context.Background(), noPaging, Options{Query: "lang", PageNo: 2}, t.TempDir(), "page"); err == nil {
+		t.Error("expected the error of unsupported page")
+	}
+}
+
+type noPagingEngine struct {
+	*fakeEngine
+}
+
+func (e *noPagingEngine) Capabilities() engine.Capabilities {
+	return engine.Capabilities{Categories: []string{engine.CategoryGeneral}}
+}
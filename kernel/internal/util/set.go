@@ -4,46 +4,69 @@ import (
 	"sync"
 )
 
+// Set is a set keeping the insertion order of values, it is safe for concurrent use.
 type Set struct {
-	store sync.Map
+	mu     sync.Mutex
+	index  map[any]struct{}
+	values []any
 }
 
 var emptyStruct struct{}
 
 func NewSet() *Set {
-	return &Set{store: sync.Map{}}
+	return &Set{index: map[any]struct{}{}}
 }
 
 func SetAdd[T int | string](s *Set, value T) {
-	_, ok := s.store.Load(value)
-	if ok {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.index[value]; ok {
 		return
 	}
-	s.store.Store(value, emptyStruct)
+	if s.index == nil {
+		s.index = map[any]struct{}{}
+	}
+	s.index[value] = emptyStruct
+	s.values = append(s.values, value)
 }
 
 func SetMerge[T int | string](s1 *Set, s2 *Set) {
 	if s2 == nil {
 		return
 	}
-	s2.store.Range(func(key, value any) bool {
-		SetAdd(s1, key.(T))
-		return true
-	})
+	for _, value := range SetToArray[T](s2) {
+		SetAdd(s1, value)
+	}
 }
 
 func SetRemove[T int | string](s *Set, value T) {
-	s.store.Delete(value)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.index[value]; !ok {
+		return
+	}
+	delete(s.index, value)
+	for i, v := range s.values {
+		if v == any(value) {
+			s.values = append(s.values[:i], s.values[i+1:]...)
+			break
+		}
+	}
 }
 
+// SetToArray returns the values of set in the order they are added.
 func SetToArray[T int | string](s *Set) []T {
 	set := make([]T, 0)
 	if s == nil {
 		return set
 	}
-	s.store.Range(func(key, value interface{}) bool {
-		set = append(set, key.(T))
-		return true
-	})
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, v := range s.values {
+		set = append(set, v.(T))
+	}
 	return set
 }
@@ -0,0 +1,24 @@
+package util
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestSetOrder(t *testing.T) {
+	s := NewSet()
+	for _, v := range []string{"b", "a", "c", "a"} {
+		SetAdd(s, v)
+	}
+	SetRemove(s, "c")
+
+	other := NewSet()
+	SetAdd(other, "d")
+	SetAdd(other, "b")
+	SetMerge[string](s, other)
+
+	// the values are kept in the order they are added, the duplicates are dropped.
+	if got := SetToArray[string](s); !slices.Equal(got, []string{"b", "a", "d"}) {
+		t.Errorf("got %v, want [b a d]", got)
+	}
+}